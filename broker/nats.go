@@ -0,0 +1,55 @@
+// Package broker publishes fission events to message brokers, so simulations can feed
+// streaming pipelines.
+package broker
+
+import (
+	"encoding/json"
+
+	"github.com/nats-io/nats.go"
+
+	"physics/eventlog"
+)
+
+// DefaultSubject is NATS subject of events.
+const DefaultSubject = "fission.events"
+
+// NATSPublisher publishes each record as JSON message to NATS subject.
+// Message is the same object as a line of eventlog.NDJSONWriter.
+type NATSPublisher struct {
+	conn    *nats.Conn
+	subject string
+	events  int64
+}
+
+// DialNATS connects to NATS server at url, e.g. nats.DefaultURL.
+func DialNATS(url, subject string) (*NATSPublisher, error) {
+	conn, err := nats.Connect(url, nats.Name("fission-mc"))
+	if err != nil {
+		return nil, err
+	}
+	return NewNATSPublisher(conn, subject), nil
+}
+
+// NewNATSPublisher creates publisher of records to subject through conn, DefaultSubject if empty.
+func NewNATSPublisher(conn *nats.Conn, subject string) *NATSPublisher {
+	if subject == "" {
+		subject = DefaultSubject
+	}
+	return &NATSPublisher{conn: conn, subject: subject}
+}
+
+// Write publishes record.
+func (p *NATSPublisher) Write(r eventlog.Record) error {
+	data, err := json.Marshal(eventlog.NewRow(p.events, r))
+	if err != nil {
+		return err
+	}
+	p.events++
+	return p.conn.Publish(p.subject, data)
+}
+
+// Close flushes pending messages and closes connection.
+func (p *NATSPublisher) Close() error {
+	defer p.conn.Close()
+	return p.conn.Flush()
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"physics/fission"
+	"physics/isotope"
+)
+
+func chartCmd() *cobra.Command {
+	var (
+		output   outputFlags
+		opts     isotope.ChartOptions
+		renderer string
+	)
+	cmd := &cobra.Command{
+		Use:   "chart <run directory>",
+		Short: "Render charts of elements, probabilities and neutrons of a saved run",
+		Long: "Render charts of elements, probabilities and neutrons of a saved run.\n" +
+			"Charts are saved to run directory unless --out is given.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := fission.LoadBundle(args[0])
+			if err != nil {
+				return err
+			}
+			if output.dir == "" {
+				output.dir = args[0]
+			}
+			out, err := output.config()
+			if err != nil {
+				return err
+			}
+			if opts.Renderer, err = parseRenderer(renderer); err != nil {
+				return err
+			}
+			symbols := isotope.SymbolCounts(b.Symbols)
+			for _, save := range []func(isotope.OutputConfig, isotope.ChartOptions) error{symbols.SaveChart, symbols.Probabilities().SaveChart, b.NeutronStats().SaveChart} {
+				if err := save(out, opts); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	output.add(cmd)
+	f := cmd.Flags()
+	f.StringVar(&opts.Title, "title", "", "title of charts")
+	f.IntVar(&opts.Width, "width", 0, "width of charts in pixels")
+	f.IntVar(&opts.Height, "height", 0, "height of charts in pixels")
+	f.Float64Var(&opts.FontSize, "font-size", 0, "font size")
+	f.StringSliceVar(&opts.Colors, "colors", nil, "comma separated colors of bars as hex #rrggbb")
+	f.StringVar(&renderer, "renderer", "gochart", "library which draws charts: gochart or gonum")
+	return cmd
+}
@@ -0,0 +1,47 @@
+// Command libfissionmc is C shared library of simulation, so it can be called from
+// Python, Julia or C without running the binary:
+//
+//	go build -buildmode=c-shared -tags nochart -o libfissionmc.so ./cmd/libfissionmc
+//
+// RunSimulation takes JSON config, e.g. '{"isotope": "U-235", "events": "10k"}', and
+// returns JSON document of results, or object with "error" field. Returned string must
+// be released with FreeString. From Python:
+//
+//	lib = ctypes.CDLL("./libfissionmc.so")
+//	lib.RunSimulation.restype = ctypes.c_void_p
+//	p = lib.RunSimulation(b'{"events": 1000}')
+//	results = json.loads(ctypes.string_at(p))
+//	lib.FreeString(ctypes.c_void_p(p))
+package main
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"unsafe"
+
+	"physics/fission"
+)
+
+//export RunSimulation
+func RunSimulation(config *C.char) *C.char {
+	var data []byte
+	if config != nil {
+		data = []byte(C.GoString(config))
+	}
+	doc, err := fission.RunJSON(data)
+	if err != nil {
+		doc, _ = json.Marshal(map[string]string{"error": err.Error()})
+	}
+	return C.CString(string(doc))
+}
+
+//export FreeString
+func FreeString(s *C.char) {
+	C.free(unsafe.Pointer(s))
+}
+
+func main() {}
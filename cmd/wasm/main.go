@@ -0,0 +1,35 @@
+//go:build js && wasm
+
+// Command wasm exposes simulation to JavaScript when compiled to WebAssembly:
+//
+//	GOOS=js GOARCH=wasm go build -tags nochart -o fission-mc.wasm ./cmd/wasm
+//
+// It defines global fissionmc.run(config) which takes JSON config, e.g.
+// '{"isotope": "U-235", "events": "10k"}', and returns JSON document of results,
+// or JavaScript Error if simulation can't be run.
+package main
+
+import (
+	"syscall/js"
+
+	"physics/fission"
+)
+
+func main() {
+	js.Global().Set("fissionmc", js.ValueOf(map[string]any{
+		"run": js.FuncOf(run),
+	}))
+	select {}
+}
+
+func run(this js.Value, args []js.Value) any {
+	var config []byte
+	if len(args) > 0 && args[0].Type() == js.TypeString {
+		config = []byte(args[0].String())
+	}
+	doc, err := fission.RunJSON(config)
+	if err != nil {
+		return js.Global().Get("Error").New(err.Error())
+	}
+	return string(doc)
+}
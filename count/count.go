@@ -0,0 +1,103 @@
+// Package count tallies occurences of keys, e.g. element symbols, isotopes or neutron
+// multiplicities, so that every tally of a simulation shares the same operations.
+package count
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Counter is number of occurences of each key.
+type Counter[K comparable] map[K]int
+
+// New creates empty counter.
+func New[K comparable]() Counter[K] {
+	return make(Counter[K])
+}
+
+// Add adds n occurences of key.
+func (c Counter[K]) Add(key K, n int) {
+	c[key] += n
+}
+
+// Merge adds counts of other.
+func (c Counter[K]) Merge(other Counter[K]) {
+	for k, n := range other {
+		c[k] += n
+	}
+}
+
+// Total returns sum of all counts.
+func (c Counter[K]) Total() int {
+	sum := 0
+	for _, n := range c {
+		sum += n
+	}
+	return sum
+}
+
+// Normalize returns fraction of total of each key. Empty counter has no fractions.
+func (c Counter[K]) Normalize() map[K]float64 {
+	total := c.Total()
+	fractions := make(map[K]float64, len(c))
+	if total == 0 {
+		return fractions
+	}
+	for k, n := range c {
+		fractions[k] = float64(n) / float64(total)
+	}
+	return fractions
+}
+
+// Entry is key with its count.
+type Entry[K comparable] struct {
+	Key   K
+	Count int
+}
+
+// TopN returns n most common keys, all keys if n isn't positive. Keys with the same count
+// are ordered by key.
+func (c Counter[K]) TopN(n int) []Entry[K] {
+	entries := make([]Entry[K], 0, len(c))
+	for k, v := range c {
+		entries = append(entries, Entry[K]{Key: k, Count: v})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+		return compare(entries[i].Key, entries[j].Key) < 0
+	})
+	if n > 0 && n < len(entries) {
+		entries = entries[:n]
+	}
+	return entries
+}
+
+// SortedKeys returns keys of m in ascending order.
+func SortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// compare orders keys of common types naturally, keys with Compare method by it and other
+// keys by their formatting.
+func compare[K comparable](a, b K) int {
+	switch a := any(a).(type) {
+	case interface{ Compare(K) int }:
+		return a.Compare(b)
+	case string:
+		return cmp.Compare(a, any(b).(string))
+	case int:
+		return cmp.Compare(a, any(b).(int))
+	case float64:
+		return cmp.Compare(a, any(b).(float64))
+	}
+	return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"physics/isotope"
+	"physics/report"
+)
+
+func dashboardCmd() *cobra.Command {
+	var dir string
+	cmd := &cobra.Command{
+		Use:   "dashboard <run directory>...",
+		Short: "Generate static site comparing saved runs",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			d, err := report.NewDashboard(args...)
+			if err != nil {
+				return err
+			}
+			return d.Save(isotope.OutputConfig{Dir: dir})
+		},
+	}
+	cmd.Flags().StringVarP(&dir, "out", "o", "dashboard", "output directory")
+	return cmd
+}
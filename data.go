@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"physics/isotope"
+	"physics/livechart"
+)
+
+func dataCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "data",
+		Short: "Inspect nuclear data of isotopes",
+	}
+	cmd.AddCommand(dataListCmd(), dataShowCmd(), dataFetchCmd(), dataChecksumCmd())
+	return cmd
+}
+
+func dataListCmd() *cobra.Command {
+	var element string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List isotopes of table",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			isos, err := isotope.Isotopes()
+			if err != nil {
+				return err
+			}
+			for _, iso := range isos {
+				if element == "" || iso.Symbol == element {
+					fmt.Println(iso)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&element, "element", "", "list only isotopes of element symbol, e.g. Xe")
+	return cmd
+}
+
+func dataShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <isotope>",
+		Short: "Print data of isotope, e.g. U-235",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			iso, err := isotope.Parse(args[0])
+			if err != nil {
+				return err
+			}
+			return printJSON(iso)
+		},
+	}
+}
+
+func dataFetchCmd() *cobra.Command {
+	var c livechart.Client
+	cmd := &cobra.Command{
+		Use:   "fetch <nuclides>",
+		Short: "Fetch ground state data from IAEA Livechart, e.g. 235u or all",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			isos, err := c.Fetch(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return printJSON(isos)
+		},
+	}
+	f := cmd.Flags()
+	f.StringVar(&c.CacheDir, "cache", "", "directory of cached responses")
+	f.DurationVar(&c.MaxAge, "max-age", 30*24*time.Hour, "age after which cached responses are fetched again")
+	return cmd
+}
+
+func dataChecksumCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "checksum",
+		Short: "Print checksum of isotopes table",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sum, err := isotope.Checksum()
+			if err != nil {
+				return err
+			}
+			fmt.Println(sum)
+			return nil
+		},
+	}
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", " ")
+	return enc.Encode(v)
+}
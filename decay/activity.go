@@ -0,0 +1,94 @@
+package decay
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"physics/isotope"
+)
+
+// Series is activity of a radionuclide in Bq at times of Activities.
+type Series struct {
+	Nuclide  string    `json:"nuclide" yaml:"nuclide"`
+	Activity []float64 `json:"activity_bq" yaml:"activity_bq"`
+}
+
+// Activities are activities of radionuclides over time after end of run.
+type Activities struct {
+	// Times in s after end of run.
+	Times  []float64 `json:"times_s" yaml:"times_s"`
+	Series []Series  `json:"series" yaml:"series"`
+}
+
+// Saves to .json file
+func (a *Activities) SaveJson(out isotope.OutputConfig) error {
+	return out.Save("activity.json", a.WriteJSON)
+}
+
+// WriteJSON writes indented json to w
+func (a *Activities) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(a, "", " ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Saves to .yaml file
+func (a *Activities) SaveYAML(out isotope.OutputConfig) error {
+	return out.Save("activity.yaml", a.WriteYAML)
+}
+
+// WriteYAML writes yaml to w
+func (a *Activities) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(a); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Saves to .csv file
+func (a *Activities) SaveCSV(out isotope.OutputConfig) error {
+	return out.Save("activity.csv", a.WriteCSV)
+}
+
+// WriteCSV writes a row of each time to w, with activity of each nuclide in columns after time
+func (a *Activities) WriteCSV(w io.Writer) error {
+	format := func(v float64) string { return strconv.FormatFloat(v, 'g', -1, 64) }
+	header := []string{"time_s"}
+	for _, s := range a.Series {
+		header = append(header, s.Nuclide)
+	}
+	cw := csv.NewWriter(w)
+	cw.Write(header)
+	for i, t := range a.Times {
+		row := []string{format(t)}
+		for _, s := range a.Series {
+			row = append(row, format(s.Activity[i]))
+		}
+		cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Save saves activities in format
+func (a *Activities) Save(out isotope.OutputConfig, format isotope.Format) error {
+	switch format {
+	case isotope.JSON:
+		return a.SaveJson(out)
+	case isotope.YAML:
+		return a.SaveYAML(out)
+	case isotope.CSV:
+		return a.SaveCSV(out)
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
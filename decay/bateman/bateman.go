@@ -0,0 +1,171 @@
+// Package bateman solves Bateman equations of linear decay chains, so that atoms and
+// activities of products are known exactly at any time after they're produced with
+// independent yields.
+package bateman
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"physics/decay"
+	"physics/isotope"
+)
+
+// Chain is linear decay chain, each nuclide decays to the next one. The last nuclide is
+// stable if its decay constant is zero.
+type Chain struct {
+	Nuclides []isotope.ZA
+
+	// Constants are decay constants of nuclides in 1/s.
+	Constants []float64
+}
+
+// Follow returns chain of decays of nuclide until nuclide without decay data, which is
+// taken as stable. Short lived precursors without decay data of beta emitters of the same
+// mass, e.g. Sn-131 and Sb-131 of Te-131, decay at once, so chain starts at the first
+// nuclide which has decay data.
+func Follow(za isotope.ZA) Chain {
+	za = Precursor(za)
+	var c Chain
+	for seen := make(map[isotope.ZA]bool); !seen[za]; {
+		seen[za] = true
+		c.Nuclides = append(c.Nuclides, za)
+		d, ok := decay.Lookup(za)
+		if !ok {
+			c.Constants = append(c.Constants, 0)
+			break
+		}
+		c.Constants = append(c.Constants, d.Constant())
+		za = d.Mode.Daughter(za)
+	}
+	return c
+}
+
+// betaEmitters are the lightest atomic numbers of beta emitters of each mass number.
+var betaEmitters = sync.OnceValue(func() map[int]int {
+	numbers := make(map[int]int)
+	for _, za := range decay.Nuclides() {
+		if d, _ := decay.Lookup(za); d.Mode != decay.BetaMinus {
+			continue
+		}
+		if z, ok := numbers[za.Mass]; !ok || za.Number < z {
+			numbers[za.Mass] = za.Number
+		}
+	}
+	return numbers
+})
+
+// Precursor returns nuclide to which nuclide decays at once, nuclide itself unless it's
+// lighter than the lightest beta emitter of its mass which has decay data. Precursors stop
+// at stable nuclide which shields emitter, see decay.Shielding.
+func Precursor(za isotope.ZA) isotope.ZA {
+	z, ok := betaEmitters()[za.Mass]
+	if !ok {
+		return za
+	}
+	for ; za.Number < z; za.Number++ {
+		if decay.Shielding(za) {
+			return za
+		}
+	}
+	return za
+}
+
+// Solve returns atoms of each nuclide of chain t seconds after there were initial atoms of
+// them. It's superposition of analytic solutions of chains starting at each nuclide.
+func (c Chain) Solve(initial []float64, t float64) []float64 {
+	atoms := make([]float64, len(c.Nuclides))
+	for i, n := range initial {
+		if n == 0 {
+			continue
+		}
+		for j := i; j < len(c.Nuclides); j++ {
+			atoms[j] += n * c.transfer(i, j, t)
+		}
+	}
+	return atoms
+}
+
+// transfer returns fraction of atoms of nuclide i which are atoms of nuclide j after t seconds,
+// by Bateman's solution
+//
+//	N_j(t) = N_i(0) λ_i ... λ_(j-1) Σ_k exp(-λ_k t) / Π_(l≠k) (λ_l - λ_k)
+//
+// Equal decay constants would divide by zero, so they're separated by tiny perturbation.
+func (c Chain) transfer(i, j int, t float64) float64 {
+	lambdas := make([]float64, 0, j-i+1)
+	for k := i; k <= j; k++ {
+		l := c.Constants[k]
+		for _, m := range lambdas {
+			if math.Abs(l-m) <= 1e-9*max(l, m) {
+				l = m * (1 + 1e-6)
+			}
+		}
+		lambdas = append(lambdas, l)
+	}
+	product := 1.0
+	for _, l := range lambdas[:len(lambdas)-1] {
+		product *= l
+	}
+	sum := 0.0
+	for k, lk := range lambdas {
+		d := 1.0
+		for l, ll := range lambdas {
+			if l != k {
+				d *= ll - lk
+			}
+		}
+		sum += math.Exp(-lk*t) / d
+	}
+	return product * sum
+}
+
+// Inventory returns atoms of each nuclide t seconds after atoms of nuclides were produced,
+// e.g. products of fissions by their independent yields.
+func Inventory(atoms map[isotope.ZA]float64, t float64) map[isotope.ZA]float64 {
+	result := make(map[isotope.ZA]float64)
+	for za, n := range atoms {
+		c := Follow(za)
+		initial := make([]float64, len(c.Nuclides))
+		initial[0] = n
+		for i, a := range c.Solve(initial, t) {
+			result[c.Nuclides[i]] += a
+		}
+	}
+	return result
+}
+
+// Activities returns activities of nuclides, e.g. "I-131", at points times between from and
+// to seconds after atoms of nuclides were produced, spaced evenly on logarithmic scale.
+func Activities(atoms map[isotope.ZA]float64, nuclides []string, from, to float64, points int) (*decay.Activities, error) {
+	if from <= 0 || to <= from {
+		return nil, fmt.Errorf("activities: invalid times from %g s to %g s", from, to)
+	}
+	points = max(points, 2)
+	a := &decay.Activities{}
+	var zas []isotope.ZA
+	var lambdas []float64
+	for _, s := range nuclides {
+		iso, err := isotope.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		d, ok := decay.Lookup(iso.ZA())
+		if !ok {
+			return nil, fmt.Errorf("activities: %s has no decay data", iso.Name())
+		}
+		zas = append(zas, iso.ZA())
+		lambdas = append(lambdas, d.Constant())
+		a.Series = append(a.Series, decay.Series{Nuclide: iso.Name(), Activity: make([]float64, points)})
+	}
+	for i := range points {
+		t := from * math.Pow(to/from, float64(i)/float64(points-1))
+		a.Times = append(a.Times, t)
+		inv := Inventory(atoms, t)
+		for j, za := range zas {
+			a.Series[j].Activity[i] = lambdas[j] * inv[za]
+		}
+	}
+	return a, nil
+}
@@ -0,0 +1,85 @@
+//go:build !nochart
+
+package decay
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/wcharczuk/go-chart/v2"
+
+	"physics/isotope"
+)
+
+// Saves activities over time to image file
+func (a *Activities) SaveChart(out isotope.OutputConfig, opts isotope.ChartOptions) error {
+	return out.Save("activity"+out.Image.Ext(), func(w io.Writer) error {
+		return a.RenderChart(w, out.Image, opts)
+	})
+}
+
+// RenderChart renders activities over time in format to w, on logarithmic axes. Times
+// without activity are left out.
+func (a *Activities) RenderChart(w io.Writer, format isotope.ImageFormat, opts isotope.ChartOptions) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", isotope.ErrChartFailed, r)
+		}
+	}()
+
+	// go-chart has no logarithmic axes, so logarithms are plotted with ticks of decades
+	lo, hi := math.Inf(1), math.Inf(-1)
+	graph := chart.Chart{
+		Title: "Activity after end of run",
+		Background: chart.Style{
+			Padding: chart.Box{Top: 50, Left: 20, Right: 20},
+		},
+		Width:  1200,
+		Height: 600,
+	}
+	for i, s := range a.Series {
+		var xs, ys []float64
+		for j, t := range a.Times {
+			if s.Activity[j] > 0 {
+				xs = append(xs, math.Log10(t))
+				ys = append(ys, math.Log10(s.Activity[j]))
+				lo, hi = min(lo, ys[len(ys)-1]), max(hi, ys[len(ys)-1])
+			}
+		}
+		if len(xs) < 2 {
+			continue
+		}
+		graph.Series = append(graph.Series, chart.ContinuousSeries{
+			Name:    s.Nuclide,
+			XValues: xs,
+			YValues: ys,
+			Style: chart.Style{
+				StrokeColor: chart.GetDefaultColor(i),
+				StrokeWidth: 2,
+			},
+		})
+	}
+	if len(graph.Series) == 0 {
+		return errors.New("activity: no nuclide has activity")
+	}
+	graph.XAxis = chart.XAxis{Name: "time [s]", Ticks: decades(math.Log10(a.Times[0]), math.Log10(a.Times[len(a.Times)-1]))}
+	graph.YAxis = chart.YAxis{Name: "activity [Bq]", Ticks: decades(lo, hi)}
+	graph.Elements = []chart.Renderable{chart.Legend(&graph)}
+	opts.Line(&graph)
+	return graph.Render(format.Renderer(), w)
+}
+
+// decades returns ticks of powers of ten of axis of logarithms between lo and hi.
+func decades(lo, hi float64) []chart.Tick {
+	lo, hi = math.Floor(lo), math.Ceil(hi)
+	if hi == lo {
+		hi++
+	}
+	var ticks []chart.Tick
+	for e := lo; e <= hi; e++ {
+		ticks = append(ticks, chart.Tick{Value: e, Label: fmt.Sprintf("1e%d", int(e))})
+	}
+	return ticks
+}
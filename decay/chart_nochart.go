@@ -0,0 +1,19 @@
+//go:build nochart
+
+package decay
+
+import (
+	"io"
+
+	"physics/isotope"
+)
+
+// Saves activities over time to image file
+func (a *Activities) SaveChart(out isotope.OutputConfig, opts isotope.ChartOptions) error {
+	return isotope.ErrChartsDisabled
+}
+
+// RenderChart renders activities over time in format to w, on logarithmic axes
+func (a *Activities) RenderChart(w io.Writer, format isotope.ImageFormat, opts isotope.ChartOptions) error {
+	return isotope.ErrChartsDisabled
+}
@@ -0,0 +1,160 @@
+// Package decay holds radioactive decay data of nuclides, so that inventories of fuel and
+// fission products can be followed over time.
+package decay
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+
+	"physics/isotope"
+)
+
+// Units of half-lives in seconds.
+const (
+	Minute = 60.0
+	Hour   = 60 * Minute
+	Day    = 24 * Hour
+	Year   = 365.25 * Day
+)
+
+// Mode of radioactive decay.
+type Mode int
+
+const (
+	BetaMinus Mode = iota
+	Alpha
+	ElectronCapture
+)
+
+func (m Mode) String() string {
+	switch m {
+	case BetaMinus:
+		return "beta-"
+	case Alpha:
+		return "alpha"
+	case ElectronCapture:
+		return "ec"
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// Daughter returns nuclide to which nuclide decays by mode.
+func (m Mode) Daughter(za isotope.ZA) isotope.ZA {
+	switch m {
+	case BetaMinus:
+		return isotope.ZA{Number: za.Number + 1, Mass: za.Mass}
+	case Alpha:
+		return isotope.ZA{Number: za.Number - 2, Mass: za.Mass - 4}
+	case ElectronCapture:
+		return isotope.ZA{Number: za.Number - 1, Mass: za.Mass}
+	}
+	return za
+}
+
+// Data is decay of a radioactive nuclide.
+type Data struct {
+	// HalfLife in s.
+	HalfLife float64
+	Mode     Mode
+}
+
+// Constant returns decay constant in 1/s.
+func (d Data) Constant() float64 {
+	return math.Ln2 / d.HalfLife
+}
+
+// nuclides are decay data of ENSDF of actinides of breeding chains, of the most important
+// fission products, which are in ground states, and of activation products of structures.
+var nuclides = map[isotope.ZA]Data{
+	{Number: 1, Mass: 3}:    {HalfLife: 12.32 * Year, Mode: BetaMinus},
+	{Number: 11, Mass: 24}:  {HalfLife: 14.997 * Hour, Mode: BetaMinus},
+	{Number: 26, Mass: 55}:  {HalfLife: 2.744 * Year, Mode: ElectronCapture},
+	{Number: 26, Mass: 59}:  {HalfLife: 44.495 * Day, Mode: BetaMinus},
+	{Number: 36, Mass: 85}:  {HalfLife: 10.739 * Year, Mode: BetaMinus},
+	{Number: 36, Mass: 87}:  {HalfLife: 76.3 * Minute, Mode: BetaMinus},
+	{Number: 36, Mass: 88}:  {HalfLife: 2.825 * Hour, Mode: BetaMinus},
+	{Number: 37, Mass: 88}:  {HalfLife: 17.77 * Minute, Mode: BetaMinus},
+	{Number: 38, Mass: 89}:  {HalfLife: 50.563 * Day, Mode: BetaMinus},
+	{Number: 38, Mass: 90}:  {HalfLife: 28.79 * Year, Mode: BetaMinus},
+	{Number: 38, Mass: 91}:  {HalfLife: 9.65 * Hour, Mode: BetaMinus},
+	{Number: 39, Mass: 90}:  {HalfLife: 64.05 * Hour, Mode: BetaMinus},
+	{Number: 39, Mass: 91}:  {HalfLife: 58.51 * Day, Mode: BetaMinus},
+	{Number: 40, Mass: 95}:  {HalfLife: 64.032 * Day, Mode: BetaMinus},
+	{Number: 41, Mass: 95}:  {HalfLife: 34.991 * Day, Mode: BetaMinus},
+	{Number: 42, Mass: 99}:  {HalfLife: 65.976 * Hour, Mode: BetaMinus},
+	{Number: 43, Mass: 99}:  {HalfLife: 2.111e5 * Year, Mode: BetaMinus},
+	{Number: 44, Mass: 103}: {HalfLife: 39.247 * Day, Mode: BetaMinus},
+	{Number: 44, Mass: 106}: {HalfLife: 371.8 * Day, Mode: BetaMinus},
+	{Number: 45, Mass: 106}: {HalfLife: 30.07, Mode: BetaMinus},
+	{Number: 51, Mass: 125}: {HalfLife: 2.7576 * Year, Mode: BetaMinus},
+	{Number: 52, Mass: 132}: {HalfLife: 3.204 * Day, Mode: BetaMinus},
+	{Number: 53, Mass: 129}: {HalfLife: 1.57e7 * Year, Mode: BetaMinus},
+	{Number: 53, Mass: 131}: {HalfLife: 8.0252 * Day, Mode: BetaMinus},
+	{Number: 53, Mass: 132}: {HalfLife: 2.295 * Hour, Mode: BetaMinus},
+	{Number: 53, Mass: 133}: {HalfLife: 20.83 * Hour, Mode: BetaMinus},
+	{Number: 53, Mass: 135}: {HalfLife: 6.58 * Hour, Mode: BetaMinus},
+	{Number: 54, Mass: 133}: {HalfLife: 5.2475 * Day, Mode: BetaMinus},
+	{Number: 54, Mass: 135}: {HalfLife: 9.14 * Hour, Mode: BetaMinus},
+	{Number: 55, Mass: 134}: {HalfLife: 2.0652 * Year, Mode: BetaMinus},
+	{Number: 55, Mass: 136}: {HalfLife: 13.16 * Day, Mode: BetaMinus},
+	{Number: 55, Mass: 137}: {HalfLife: 30.08 * Year, Mode: BetaMinus},
+	{Number: 56, Mass: 140}: {HalfLife: 12.7527 * Day, Mode: BetaMinus},
+	{Number: 57, Mass: 140}: {HalfLife: 1.6781 * Day, Mode: BetaMinus},
+	{Number: 58, Mass: 141}: {HalfLife: 32.511 * Day, Mode: BetaMinus},
+	{Number: 58, Mass: 144}: {HalfLife: 284.91 * Day, Mode: BetaMinus},
+	{Number: 59, Mass: 143}: {HalfLife: 13.57 * Day, Mode: BetaMinus},
+	{Number: 59, Mass: 144}: {HalfLife: 17.28 * Minute, Mode: BetaMinus},
+	{Number: 60, Mass: 147}: {HalfLife: 10.98 * Day, Mode: BetaMinus},
+	{Number: 61, Mass: 147}: {HalfLife: 2.6234 * Year, Mode: BetaMinus},
+	{Number: 62, Mass: 151}: {HalfLife: 90 * Year, Mode: BetaMinus},
+	{Number: 63, Mass: 154}: {HalfLife: 8.601 * Year, Mode: BetaMinus},
+	{Number: 63, Mass: 155}: {HalfLife: 4.753 * Year, Mode: BetaMinus},
+
+	{Number: 90, Mass: 232}: {HalfLife: 1.40e10 * Year, Mode: Alpha},
+	{Number: 90, Mass: 233}: {HalfLife: 21.83 * Minute, Mode: BetaMinus},
+	{Number: 91, Mass: 233}: {HalfLife: 26.975 * Day, Mode: BetaMinus},
+	{Number: 92, Mass: 233}: {HalfLife: 1.592e5 * Year, Mode: Alpha},
+	{Number: 92, Mass: 235}: {HalfLife: 7.04e8 * Year, Mode: Alpha},
+	{Number: 92, Mass: 238}: {HalfLife: 4.468e9 * Year, Mode: Alpha},
+	{Number: 92, Mass: 239}: {HalfLife: 23.45 * Minute, Mode: BetaMinus},
+	{Number: 93, Mass: 239}: {HalfLife: 2.356 * Day, Mode: BetaMinus},
+	{Number: 94, Mass: 239}: {HalfLife: 24110 * Year, Mode: Alpha},
+	{Number: 94, Mass: 240}: {HalfLife: 6561 * Year, Mode: Alpha},
+	{Number: 94, Mass: 241}: {HalfLife: 14.329 * Year, Mode: BetaMinus},
+	{Number: 95, Mass: 241}: {HalfLife: 432.6 * Year, Mode: Alpha},
+}
+
+// shielding are stable nuclides of mass chains of fission products which shield radionuclides
+// of the same mass from decays of lighter precursors, e.g. Xe-134 shields Cs-134, which is
+// produced only by capture or independent yield.
+var shielding = map[isotope.ZA]bool{
+	{Number: 54, Mass: 134}: true,
+	{Number: 54, Mass: 136}: true,
+	{Number: 62, Mass: 154}: true,
+}
+
+// Shielding reports whether nuclide is stable nuclide which shields heavier radionuclides
+// of its mass from decays of precursors.
+func Shielding(za isotope.ZA) bool {
+	return shielding[za]
+}
+
+// Lookup returns decay data of nuclide and false if nuclide is stable or has no data.
+func Lookup(za isotope.ZA) (Data, bool) {
+	d, ok := nuclides[za]
+	return d, ok
+}
+
+// Nuclides returns nuclides which have decay data, by atomic and mass number.
+func Nuclides() []isotope.ZA {
+	zas := make([]isotope.ZA, 0, len(nuclides))
+	for za := range nuclides {
+		zas = append(zas, za)
+	}
+	slices.SortFunc(zas, func(a, b isotope.ZA) int {
+		return cmp.Or(cmp.Compare(a.Number, b.Number), cmp.Compare(a.Mass, b.Mass))
+	})
+	return zas
+}
@@ -0,0 +1,136 @@
+package decay
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"physics/isotope"
+)
+
+// joulesPerMeV converts energy of photons to joules.
+const joulesPerMeV = 1.602176634e-13
+
+// NuclideDose is contribution of radionuclide to dose rate.
+type NuclideDose struct {
+	Nuclide  string  `json:"nuclide" yaml:"nuclide"`
+	DoseRate float64 `json:"dose_rate_usv_h" yaml:"dose_rate_usv_h"`
+	Fraction float64 `json:"fraction" yaml:"fraction"`
+}
+
+// Dose is dose rate of gammas of inventory at a distance, sorted from the largest contribution.
+type Dose struct {
+	// Distance from inventory in m.
+	Distance float64 `json:"distance_m" yaml:"distance_m"`
+
+	// DoseRate in µSv/h.
+	DoseRate float64       `json:"dose_rate_usv_h" yaml:"dose_rate_usv_h"`
+	Nuclides []NuclideDose `json:"nuclides" yaml:"nuclides"`
+}
+
+// Dose returns dose rate at distance in m from inventory, which is unshielded point source
+// in air. It's air kerma rate of its gamma lines, which is taken as dose equivalent, and
+// attenuation and scattering in air are neglected, so it's only an estimate for teaching.
+func (inv *Inventory) Dose(distance float64) (*Dose, error) {
+	if distance <= 0 {
+		return nil, fmt.Errorf("dose: distance %g m isn't positive", distance)
+	}
+	d := &Dose{Distance: distance, Nuclides: []NuclideDose{}}
+	r := 100 * distance // in cm
+	for _, a := range inv.Activities {
+		// energy absorbed in air per photon flux in MeV·cm²/g
+		e := 0.0
+		for _, l := range Lines(a.za) {
+			e += l.Intensity * l.Energy * Absorption(l.Energy)
+		}
+		if e == 0 {
+			continue
+		}
+		// Gy/s = Bq / cm² · MeV cm²/g · J/MeV · g/kg, to µSv/h
+		rate := a.Activity / (4 * math.Pi * r * r) * e * joulesPerMeV * 1000 * 1e6 * 3600
+		d.Nuclides = append(d.Nuclides, NuclideDose{Nuclide: a.Nuclide, DoseRate: rate})
+		d.DoseRate += rate
+	}
+	slices.SortFunc(d.Nuclides, func(a, b NuclideDose) int {
+		return cmp.Or(cmp.Compare(b.DoseRate, a.DoseRate), cmp.Compare(a.Nuclide, b.Nuclide))
+	})
+	for i := range d.Nuclides {
+		d.Nuclides[i].Fraction = d.Nuclides[i].DoseRate / d.DoseRate
+	}
+	return d, nil
+}
+
+// Top returns n radionuclides of the largest dose rate, all if n isn't positive.
+func (d *Dose) Top(n int) []NuclideDose {
+	if n <= 0 || n > len(d.Nuclides) {
+		return d.Nuclides
+	}
+	return d.Nuclides[:n]
+}
+
+// Saves to .json file
+func (d *Dose) SaveJson(out isotope.OutputConfig) error {
+	return out.Save("dose.json", d.WriteJSON)
+}
+
+// WriteJSON writes indented json to w
+func (d *Dose) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(d, "", " ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Saves to .yaml file
+func (d *Dose) SaveYAML(out isotope.OutputConfig) error {
+	return out.Save("dose.yaml", d.WriteYAML)
+}
+
+// WriteYAML writes yaml to w
+func (d *Dose) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(d); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Saves to .csv file
+func (d *Dose) SaveCSV(out isotope.OutputConfig) error {
+	return out.Save("dose.csv", d.WriteCSV)
+}
+
+// WriteCSV writes a row of each radionuclide to w, from the largest dose rate
+func (d *Dose) WriteCSV(w io.Writer) error {
+	format := func(v float64) string { return strconv.FormatFloat(v, 'g', -1, 64) }
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"nuclide", "distance_m", "dose_rate_usv_h", "fraction"})
+	for _, n := range d.Nuclides {
+		cw.Write([]string{n.Nuclide, format(d.Distance), format(n.DoseRate), format(n.Fraction)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Save saves dose rate in format
+func (d *Dose) Save(out isotope.OutputConfig, format isotope.Format) error {
+	switch format {
+	case isotope.JSON:
+		return d.SaveJson(out)
+	case isotope.YAML:
+		return d.SaveYAML(out)
+	case isotope.CSV:
+		return d.SaveCSV(out)
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
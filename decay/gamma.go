@@ -0,0 +1,84 @@
+package decay
+
+import (
+	"math"
+	"sort"
+
+	"physics/isotope"
+)
+
+// Line is gamma line of decay with Energy in MeV and Intensity, number of photons per decay.
+type Line struct {
+	Energy    float64
+	Intensity float64
+}
+
+// lines are the strongest gamma lines of ENSDF of radionuclides. Lines of short lived
+// daughters in equilibrium are given with parents, e.g. of Ba-137m with Cs-137.
+var lines = map[isotope.ZA][]Line{
+	{Number: 11, Mass: 24}:  {{1.369, 1.000}, {2.754, 0.999}},
+	{Number: 26, Mass: 59}:  {{1.099, 0.565}, {1.292, 0.432}},
+	{Number: 36, Mass: 85}:  {{0.514, 0.0043}},
+	{Number: 36, Mass: 87}:  {{0.403, 0.495}, {2.555, 0.092}},
+	{Number: 36, Mass: 88}:  {{2.392, 0.346}, {0.196, 0.260}, {2.196, 0.133}, {0.835, 0.130}},
+	{Number: 37, Mass: 88}:  {{1.836, 0.214}, {0.898, 0.140}},
+	{Number: 38, Mass: 91}:  {{1.024, 0.330}, {0.750, 0.237}},
+	{Number: 40, Mass: 95}:  {{0.757, 0.545}, {0.724, 0.441}},
+	{Number: 41, Mass: 95}:  {{0.766, 0.998}},
+	{Number: 42, Mass: 99}:  {{0.740, 0.121}, {0.181, 0.060}, {0.778, 0.043}},
+	{Number: 44, Mass: 103}: {{0.497, 0.910}},
+	{Number: 45, Mass: 106}: {{0.512, 0.204}, {0.622, 0.099}},
+	{Number: 51, Mass: 125}: {{0.428, 0.296}, {0.601, 0.178}, {0.636, 0.113}, {0.463, 0.105}},
+	{Number: 52, Mass: 132}: {{0.228, 0.880}},
+	{Number: 53, Mass: 131}: {{0.364, 0.815}, {0.637, 0.072}, {0.284, 0.061}, {0.723, 0.018}},
+	{Number: 53, Mass: 132}: {{0.668, 0.987}, {0.773, 0.762}, {0.955, 0.176}, {0.523, 0.160}, {0.630, 0.133}},
+	{Number: 53, Mass: 133}: {{0.530, 0.870}},
+	{Number: 53, Mass: 135}: {{1.260, 0.287}, {1.132, 0.227}, {1.678, 0.095}},
+	{Number: 54, Mass: 133}: {{0.081, 0.370}},
+	{Number: 54, Mass: 135}: {{0.250, 0.900}},
+	{Number: 55, Mass: 134}: {{0.605, 0.976}, {0.796, 0.855}, {0.569, 0.154}, {0.802, 0.087}, {0.563, 0.083}, {1.365, 0.030}},
+	{Number: 55, Mass: 136}: {{0.819, 1.000}, {1.048, 0.800}, {0.341, 0.420}, {1.235, 0.200}, {0.153, 0.075}},
+	{Number: 55, Mass: 137}: {{0.662, 0.851}},
+	{Number: 56, Mass: 140}: {{0.537, 0.244}, {0.163, 0.062}},
+	{Number: 57, Mass: 140}: {{1.596, 0.954}, {0.487, 0.455}, {0.816, 0.233}, {0.329, 0.203}, {0.925, 0.069}, {0.868, 0.055}},
+	{Number: 58, Mass: 141}: {{0.145, 0.484}},
+	{Number: 58, Mass: 144}: {{0.134, 0.111}},
+	{Number: 59, Mass: 144}: {{0.696, 0.013}},
+	{Number: 60, Mass: 147}: {{0.091, 0.280}, {0.531, 0.130}},
+	{Number: 63, Mass: 154}: {{0.123, 0.404}, {1.274, 0.350}, {0.723, 0.200}, {1.005, 0.180}},
+	{Number: 63, Mass: 155}: {{0.087, 0.310}, {0.105, 0.210}},
+	{Number: 91, Mass: 233}: {{0.312, 0.384}},
+	{Number: 92, Mass: 239}: {{0.075, 0.510}},
+	{Number: 93, Mass: 239}: {{0.106, 0.259}, {0.278, 0.144}, {0.228, 0.113}},
+	{Number: 95, Mass: 241}: {{0.0595, 0.359}},
+}
+
+// Lines returns gamma lines of nuclide, none if it has no gammas or no data.
+func Lines(za isotope.ZA) []Line {
+	return lines[za]
+}
+
+// absorption are mass energy absorption coefficients of dry air of NIST in cm²/g by photon
+// energy in MeV.
+var absorption = [][2]float64{
+	{0.01, 4.742}, {0.015, 1.334}, {0.02, 0.5389}, {0.03, 0.1537}, {0.04, 0.06833},
+	{0.05, 0.04098}, {0.06, 0.03041}, {0.08, 0.02407}, {0.1, 0.02325}, {0.15, 0.02496},
+	{0.2, 0.02672}, {0.3, 0.02872}, {0.4, 0.02949}, {0.5, 0.02966}, {0.6, 0.02953},
+	{0.8, 0.02882}, {1, 0.02789}, {1.25, 0.02666}, {1.5, 0.02547}, {2, 0.02345},
+	{3, 0.02057}, {4, 0.01870}, {5, 0.01740}, {6, 0.01647}, {8, 0.01525}, {10, 0.01450},
+}
+
+// Absorption returns mass energy absorption coefficient of air in cm²/g of photons of energy
+// in MeV, interpolated on logarithmic scales and constant outside of table.
+func Absorption(energy float64) float64 {
+	i := sort.Search(len(absorption), func(i int) bool { return absorption[i][0] >= energy })
+	switch {
+	case i == 0:
+		return absorption[0][1]
+	case i == len(absorption):
+		return absorption[len(absorption)-1][1]
+	}
+	lo, hi := absorption[i-1], absorption[i]
+	f := math.Log(energy/lo[0]) / math.Log(hi[0]/lo[0])
+	return lo[1] * math.Pow(hi[1]/lo[1], f)
+}
@@ -0,0 +1,138 @@
+package decay
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"physics/isotope"
+)
+
+// Activity is number of atoms of a radionuclide and its activity.
+type Activity struct {
+	Nuclide  string  `json:"nuclide" yaml:"nuclide"`
+	Atoms    float64 `json:"atoms" yaml:"atoms"`
+	HalfLife float64 `json:"half_life_s" yaml:"half_life_s"`
+	Mode     string  `json:"mode" yaml:"mode"`
+
+	// Activity in Bq and its Fraction of activity of inventory.
+	Activity float64 `json:"activity_bq" yaml:"activity_bq"`
+	Fraction float64 `json:"fraction" yaml:"fraction"`
+
+	za isotope.ZA
+}
+
+// Inventory is source term of radionuclides, sorted from the most active.
+type Inventory struct {
+	Activities []Activity `json:"activities" yaml:"activities"`
+
+	// Activity is total activity in Bq.
+	Activity float64 `json:"activity_bq" yaml:"activity_bq"`
+}
+
+// NewInventory returns inventory of radionuclides of atoms, nuclides without decay data are
+// left out.
+func NewInventory(atoms map[isotope.ZA]float64) *Inventory {
+	inv := &Inventory{Activities: []Activity{}}
+	for za, n := range atoms {
+		d, ok := Lookup(za)
+		if !ok || n <= 0 {
+			continue
+		}
+		a := Activity{Nuclide: name(za), Atoms: n, HalfLife: d.HalfLife, Mode: d.Mode.String(), Activity: d.Constant() * n, za: za}
+		inv.Activities = append(inv.Activities, a)
+		inv.Activity += a.Activity
+	}
+	slices.SortFunc(inv.Activities, func(a, b Activity) int {
+		if c := cmp.Compare(b.Activity, a.Activity); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Nuclide, b.Nuclide)
+	})
+	for i := range inv.Activities {
+		inv.Activities[i].Fraction = inv.Activities[i].Activity / inv.Activity
+	}
+	return inv
+}
+
+// name returns name of nuclide like "Cs-137".
+func name(za isotope.ZA) string {
+	if iso, ok := isotope.Lookup(za.Number, za.Mass); ok {
+		return iso.Name()
+	}
+	return fmt.Sprintf("Z%d-%d", za.Number, za.Mass)
+}
+
+// Top returns n most active radionuclides, all if n isn't positive.
+func (inv *Inventory) Top(n int) []Activity {
+	if n <= 0 || n > len(inv.Activities) {
+		return inv.Activities
+	}
+	return inv.Activities[:n]
+}
+
+// Saves to .json file
+func (inv *Inventory) SaveJson(out isotope.OutputConfig) error {
+	return out.Save("inventory.json", inv.WriteJSON)
+}
+
+// WriteJSON writes indented json to w
+func (inv *Inventory) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(inv, "", " ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Saves to .yaml file
+func (inv *Inventory) SaveYAML(out isotope.OutputConfig) error {
+	return out.Save("inventory.yaml", inv.WriteYAML)
+}
+
+// WriteYAML writes yaml to w
+func (inv *Inventory) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(inv); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Saves to .csv file
+func (inv *Inventory) SaveCSV(out isotope.OutputConfig) error {
+	return out.Save("inventory.csv", inv.WriteCSV)
+}
+
+// WriteCSV writes a row of each radionuclide to w, from the most active
+func (inv *Inventory) WriteCSV(w io.Writer) error {
+	format := func(v float64) string { return strconv.FormatFloat(v, 'g', -1, 64) }
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"nuclide", "atoms", "half_life_s", "mode", "activity_bq", "fraction"})
+	for _, a := range inv.Activities {
+		cw.Write([]string{a.Nuclide, format(a.Atoms), format(a.HalfLife), a.Mode, format(a.Activity), format(a.Fraction)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Save saves inventory in format
+func (inv *Inventory) Save(out isotope.OutputConfig, format isotope.Format) error {
+	switch format {
+	case isotope.JSON:
+		return inv.SaveJson(out)
+	case isotope.YAML:
+		return inv.SaveYAML(out)
+	case isotope.CSV:
+		return inv.SaveCSV(out)
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
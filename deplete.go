@@ -0,0 +1,130 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"physics/depletion"
+	"physics/fission"
+	"physics/isotope"
+	"physics/units"
+)
+
+func depleteCmd() *cobra.Command {
+	var (
+		output   outputFlags
+		path     string
+		fuel     string
+		spectrum isotope.Spectrum
+		flux     float64
+		atoms    float64
+		duration = units.Duration(3 * 365 * 24 * time.Hour)
+		steps    int
+		format   string
+	)
+	cmd := &cobra.Command{
+		Use:   "deplete",
+		Short: "Irradiate fuel by constant flux and report its composition and conversion ratio",
+		Long: "Irradiate fuel by constant neutron flux, following fission and capture of its nuclides\n" +
+			"and decay of their products, e.g. breeding of Pu-239 from U-238 and U-233 from Th-232.\n" +
+			"Fuel is fuel of config, or its isotope, unless --fuel is given.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := fission.DefaultConfig()
+			if path != "" {
+				var err error
+				if c, err = fission.LoadConfig(path); err != nil {
+					return err
+				}
+			}
+			f := cmd.Flags()
+			if f.Changed("fuel") || (path == "" && len(c.Fuel) == 0) {
+				nuclides, err := fission.ParseFuel(fuel)
+				if err != nil {
+					return err
+				}
+				c.Fuel = nuclides
+			}
+			if f.Changed("spectrum") {
+				c.Spectrum = spectrum
+			}
+			if len(c.Fuel) == 0 {
+				c.Fuel = []fission.Nuclide{{Isotope: c.Isotope, Atoms: 1}}
+			}
+			out, err := output.config()
+			if err != nil {
+				return err
+			}
+			formats, err := isotope.ParseFormats(format)
+			if err != nil {
+				return err
+			}
+
+			total := 0.0
+			for _, n := range c.Fuel {
+				total += n.Atoms
+			}
+			composition := make(map[isotope.ZA]float64)
+			for _, n := range c.Fuel {
+				iso, err := isotope.Parse(n.Isotope)
+				if err != nil {
+					return err
+				}
+				composition[iso.ZA()] += atoms * n.Atoms / total
+			}
+			d, err := depletion.New(composition, flux, c.Spectrum)
+			if err != nil {
+				return err
+			}
+			h := d.Run(time.Duration(duration), steps)
+
+			fmt.Printf("irradiated %s by flux %.3g /cm²/s of %s neutrons: %.4g fissions\n", duration, flux, c.Spectrum, d.Fissions)
+			fmt.Printf("fissile atoms produced %.4g, consumed %.4g, conversion ratio %.4f\n", h.Produced, h.Consumed, h.ConversionRatio)
+			last := h.States[len(h.States)-1]
+			for _, e := range topAtoms(last.Atoms, 10) {
+				fmt.Printf("  %-8s %.4g\n", e.name, e.atoms)
+			}
+			for _, f := range formats {
+				if err := h.Save(out, f); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	output.add(cmd)
+	f := cmd.Flags()
+	f.StringVarP(&path, "config", "c", "", "simulation config file, whose fuel or isotope is irradiated")
+	f.StringVar(&fuel, "fuel", "U-235:0.03,U-238:0.97", "nuclides of fuel with relative atoms")
+	f.Var(&spectrum, "spectrum", "spectrum of neutrons, chooses cross sections: thermal or fast")
+	f.Float64Var(&flux, "flux", 3e13, "neutron flux in 1/(cm²·s)")
+	f.Float64Var(&atoms, "atoms", 1e24, "number of atoms of fuel")
+	f.Var(&duration, "duration", "time of irradiation, e.g. 300d or 3y")
+	f.IntVar(&steps, "steps", 36, "number of steps of irradiation saved")
+	f.StringVar(&format, "format", "csv", "comma separated data formats: json, yaml, csv")
+	return cmd
+}
+
+type nuclideAtoms struct {
+	name  string
+	atoms float64
+}
+
+// topAtoms returns n nuclides of the most atoms, by name if they have the same number.
+func topAtoms(atoms map[string]float64, n int) []nuclideAtoms {
+	var top []nuclideAtoms
+	for name, a := range atoms {
+		top = append(top, nuclideAtoms{name, a})
+	}
+	slices.SortFunc(top, func(a, b nuclideAtoms) int {
+		if c := cmp.Compare(b.atoms, a.atoms); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.name, b.name)
+	})
+	return top[:min(n, len(top))]
+}
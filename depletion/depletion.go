@@ -0,0 +1,231 @@
+// Package depletion follows composition of fuel irradiated by constant neutron flux, by
+// fission and capture of its nuclides and decay of their products, e.g. breeding of fissile
+// Pu-239 from U-238 and of U-233 from Th-232.
+package depletion
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"physics/decay"
+	"physics/isotope"
+)
+
+// barn is cross section unit in cm².
+const barn = 1e-24
+
+// maxStep is the longest step of integration, longer steps are divided.
+const maxStep = 24 * time.Hour
+
+// fissiles are nuclides which fission by thermal neutrons.
+var fissiles = map[isotope.ZA]bool{
+	{Number: 92, Mass: 233}: true,
+	{Number: 92, Mass: 235}: true,
+	{Number: 94, Mass: 239}: true,
+	{Number: 94, Mass: 241}: true,
+}
+
+// Fissile reports whether nuclide fissions by thermal neutrons.
+func Fissile(za isotope.ZA) bool {
+	return fissiles[za]
+}
+
+// Depletion is composition of fuel irradiated by neutrons. Nuclides capture neutrons to the
+// next heavier isotope and radioactive nuclides decay to their daughters, nuclides without
+// cross sections or decay data only accumulate atoms.
+type Depletion struct {
+	// Flux of neutrons in 1/(cm²·s).
+	Flux float64
+
+	// Spectrum of neutrons, it chooses cross sections of nuclides.
+	Spectrum isotope.Spectrum
+
+	// Time of irradiation.
+	Time time.Duration
+
+	// Fissions is number of fissions so far.
+	Fissions float64
+
+	// Produced is number of fissile atoms bred from nuclides which aren't fissile,
+	// Consumed is number of fissile atoms which absorbed neutron.
+	Produced float64
+	Consumed float64
+
+	nuclides []nuclide
+}
+
+// nuclide is state of a nuclide with its reactions.
+type nuclide struct {
+	za      isotope.ZA
+	name    string
+	atoms   float64
+	cs      isotope.CrossSections
+	decay   float64 // decay constant in 1/s, zero if stable
+	sources []source
+	fissile bool
+}
+
+// source is reaction which produces nuclide from nuclide of index from.
+type source struct {
+	from int
+
+	// rate per atom per s of flux of one neutron per cm² per s if capture, otherwise decay constant
+	rate    float64
+	capture bool
+}
+
+// New creates depletion of fuel of atoms of each nuclide in flux of neutrons of spectrum.
+// Products of captures and decays of fuel are followed too.
+func New(atoms map[isotope.ZA]float64, flux float64, spectrum isotope.Spectrum) (*Depletion, error) {
+	if len(atoms) == 0 {
+		return nil, errors.New("depletion: no nuclides")
+	}
+	if flux < 0 || math.IsInf(flux, 0) || math.IsNaN(flux) {
+		return nil, fmt.Errorf("depletion: invalid flux %g", flux)
+	}
+	for za, n := range atoms {
+		if n < 0 {
+			return nil, fmt.Errorf("depletion: negative atoms of Z=%d A=%d", za.Number, za.Mass)
+		}
+	}
+
+	// nuclides reachable from fuel by captures and decays
+	found := make(map[isotope.ZA]bool)
+	var queue []isotope.ZA
+	for za := range atoms {
+		found[za] = true
+		queue = append(queue, za)
+	}
+	for len(queue) > 0 {
+		za := queue[0]
+		queue = queue[1:]
+		var next []isotope.ZA
+		if cs, ok := (isotope.Isotope{Number: za.Number, Mass: za.Mass}).CrossSections(spectrum); ok && cs.Capture > 0 {
+			next = append(next, isotope.ZA{Number: za.Number, Mass: za.Mass + 1})
+		}
+		if d, ok := decay.Lookup(za); ok {
+			next = append(next, d.Mode.Daughter(za))
+		}
+		for _, n := range next {
+			if !found[n] {
+				found[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	// parents of captures and beta decays are lighter, or of the same mass with lower
+	// atomic number, so they're integrated before their products
+	zas := make([]isotope.ZA, 0, len(found))
+	for za := range found {
+		zas = append(zas, za)
+	}
+	sort.Slice(zas, func(i, j int) bool {
+		if zas[i].Mass != zas[j].Mass {
+			return zas[i].Mass < zas[j].Mass
+		}
+		return zas[i].Number < zas[j].Number
+	})
+	d := &Depletion{Flux: flux, Spectrum: spectrum}
+	index := make(map[isotope.ZA]int, len(zas))
+	for i, za := range zas {
+		n := nuclide{za: za, name: Name(za), atoms: atoms[za], fissile: Fissile(za)}
+		n.cs, _ = (isotope.Isotope{Number: za.Number, Mass: za.Mass}).CrossSections(spectrum)
+		if dd, ok := decay.Lookup(za); ok {
+			n.decay = dd.Constant()
+		}
+		d.nuclides = append(d.nuclides, n)
+		index[za] = i
+	}
+	for i, n := range d.nuclides {
+		if n.cs.Capture > 0 {
+			j := index[isotope.ZA{Number: n.za.Number, Mass: n.za.Mass + 1}]
+			d.nuclides[j].sources = append(d.nuclides[j].sources, source{from: i, rate: n.cs.Capture * barn, capture: true})
+		}
+		if dd, ok := decay.Lookup(n.za); ok {
+			j := index[dd.Mode.Daughter(n.za)]
+			d.nuclides[j].sources = append(d.nuclides[j].sources, source{from: i, rate: n.decay})
+		}
+	}
+	return d, nil
+}
+
+// Name returns name of nuclide like "Pu-239".
+func Name(za isotope.ZA) string {
+	if iso, ok := isotope.Lookup(za.Number, za.Mass); ok {
+		return iso.Name()
+	}
+	return fmt.Sprintf("Z%d-%d", za.Number, za.Mass)
+}
+
+// Atoms returns number of atoms of nuclide.
+func (d *Depletion) Atoms(za isotope.ZA) float64 {
+	for _, n := range d.nuclides {
+		if n.za == za {
+			return n.atoms
+		}
+	}
+	return 0
+}
+
+// Inventory returns number of atoms of each followed nuclide by name.
+func (d *Depletion) Inventory() map[string]float64 {
+	inv := make(map[string]float64, len(d.nuclides))
+	for _, n := range d.nuclides {
+		inv[n.name] = n.atoms
+	}
+	return inv
+}
+
+// ConversionRatio returns ratio of fissile atoms produced to fissile atoms consumed since
+// start. Fuel breeds more fissile atoms than it consumes if it's above one.
+func (d *Depletion) ConversionRatio() float64 {
+	if d.Consumed == 0 {
+		return 0
+	}
+	return d.Produced / d.Consumed
+}
+
+// Step advances irradiation by dt. It's integrated by implicit Euler method in steps of at
+// most a day, so short lived intermediates like U-239 don't need short steps.
+func (d *Depletion) Step(dt time.Duration) {
+	if dt <= 0 {
+		return
+	}
+	n := int((dt + maxStep - 1) / maxStep)
+	h := dt.Seconds() / float64(n)
+	for range n {
+		d.step(h)
+	}
+	d.Time += dt
+}
+
+// step advances irradiation by h seconds. Nuclides are updated in order, so sources use
+// atoms at the end of step, except of alpha decays of heavier parents, which use atoms at
+// its start.
+func (d *Depletion) step(h float64) {
+	for i := range d.nuclides {
+		n := &d.nuclides[i]
+		produced := 0.0
+		for _, s := range n.sources {
+			rate := s.rate
+			if s.capture {
+				rate *= d.Flux
+			}
+			p := h * rate * d.nuclides[s.from].atoms
+			if n.fissile && !d.nuclides[s.from].fissile {
+				d.Produced += p
+			}
+			produced += p
+		}
+		absorption := n.cs.Absorption() * barn * d.Flux
+		n.atoms = (n.atoms + produced) / (1 + h*(absorption+n.decay))
+		d.Fissions += h * n.cs.Fission * barn * d.Flux * n.atoms
+		if n.fissile {
+			d.Consumed += h * absorption * n.atoms
+		}
+	}
+}
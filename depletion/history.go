@@ -0,0 +1,128 @@
+package depletion
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"physics/count"
+	"physics/isotope"
+)
+
+// State is composition of fuel at a time of irradiation.
+type State struct {
+	// Time in days.
+	Time     float64 `json:"time" yaml:"time"`
+	Fissions float64 `json:"fissions" yaml:"fissions"`
+
+	// ConversionRatio is ratio of fissile atoms produced to consumed since start.
+	ConversionRatio float64 `json:"conversion_ratio" yaml:"conversion_ratio"`
+
+	// Atoms of each nuclide by name.
+	Atoms map[string]float64 `json:"atoms" yaml:"atoms"`
+}
+
+// History is composition of fuel over irradiation.
+type History struct {
+	States []State `json:"states" yaml:"states"`
+
+	// Produced and Consumed are fissile atoms bred and absorbing neutron during irradiation.
+	Produced        float64 `json:"produced" yaml:"produced"`
+	Consumed        float64 `json:"consumed" yaml:"consumed"`
+	ConversionRatio float64 `json:"conversion_ratio" yaml:"conversion_ratio"`
+}
+
+// Run irradiates fuel for duration in steps of equal length, and returns composition at
+// start and after every step.
+func (d *Depletion) Run(duration time.Duration, steps int) *History {
+	steps = max(steps, 1)
+	h := &History{States: []State{d.state()}}
+	for i := 1; i <= steps; i++ {
+		d.Step(duration*time.Duration(i)/time.Duration(steps) - duration*time.Duration(i-1)/time.Duration(steps))
+		h.States = append(h.States, d.state())
+	}
+	h.Produced, h.Consumed, h.ConversionRatio = d.Produced, d.Consumed, d.ConversionRatio()
+	return h
+}
+
+func (d *Depletion) state() State {
+	return State{Time: d.Time.Hours() / 24, Fissions: d.Fissions, ConversionRatio: d.ConversionRatio(), Atoms: d.Inventory()}
+}
+
+// Saves to .json file
+func (h *History) SaveJson(out isotope.OutputConfig) error {
+	return out.Save("depletion.json", h.WriteJSON)
+}
+
+// WriteJSON writes indented json to w
+func (h *History) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(h, "", " ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Saves to .yaml file
+func (h *History) SaveYAML(out isotope.OutputConfig) error {
+	return out.Save("depletion.yaml", h.WriteYAML)
+}
+
+// WriteYAML writes yaml to w
+func (h *History) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(h); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Saves to .csv file
+func (h *History) SaveCSV(out isotope.OutputConfig) error {
+	return out.Save("depletion.csv", h.WriteCSV)
+}
+
+// WriteCSV writes a row of each state to w, with atoms of each nuclide in columns after
+// time, fissions and conversion ratio
+func (h *History) WriteCSV(w io.Writer) error {
+	names := make(map[string]bool)
+	for _, s := range h.States {
+		for name := range s.Atoms {
+			names[name] = true
+		}
+	}
+	nuclides := count.SortedKeys(names)
+
+	format := func(v float64) string { return strconv.FormatFloat(v, 'g', -1, 64) }
+	cw := csv.NewWriter(w)
+	cw.Write(append([]string{"time", "fissions", "conversion_ratio"}, nuclides...))
+	for _, s := range h.States {
+		row := []string{format(s.Time), format(s.Fissions), format(s.ConversionRatio)}
+		for _, name := range nuclides {
+			row = append(row, format(s.Atoms[name]))
+		}
+		cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Save saves history in format
+func (h *History) Save(out isotope.OutputConfig, format isotope.Format) error {
+	switch format {
+	case isotope.JSON:
+		return h.SaveJson(out)
+	case isotope.YAML:
+		return h.SaveYAML(out)
+	case isotope.CSV:
+		return h.SaveCSV(out)
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
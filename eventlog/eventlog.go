@@ -0,0 +1,141 @@
+// Package eventlog stores fission events in a compact binary log of fixed size records,
+// which can be read by index without loading a whole file.
+//
+// File starts with 16 byte header: 8 byte magic, little endian uint32 record size
+// and 4 reserved bytes. Each record holds little endian uint16 values of parent,
+// first and second fragment atomic and mass numbers, number of neutrons and generation,
+// followed by float32 energy released and kinetic energies of fragments in MeV. Logs with
+// 16 byte records have no energies and logs with 20 byte records no kinetic energies.
+package eventlog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+
+	"physics/isotope"
+)
+
+const (
+	magic      = "FMCEVT1\n"
+	headerSize = 16
+
+	// RecordSize is size of a record written by this version.
+	RecordSize = 28
+
+	// Size of records without energy.
+	minRecordSize = 16
+)
+
+// ErrFormat is returned when data is not an event log.
+var ErrFormat = errors.New("eventlog: invalid format")
+
+// Record is a single fission event.
+type Record struct {
+	Parent   isotope.ZA
+	First    isotope.ZA
+	Second   isotope.ZA
+	Neutrons int
+
+	// Generation of fission in chain of fissions, zero for independent fissions.
+	Generation int
+
+	// Energy released in fission and KineticEnergy of each fragment in MeV.
+	Energy        float64
+	KineticEnergy [2]float64
+}
+
+// NewRecord creates record of fission of parent into products.
+func NewRecord(parent *isotope.Isotope, prods isotope.Products, neutrons int) Record {
+	r := Record{Parent: parent.ZA(), Neutrons: neutrons, Energy: isotope.QValue(parent, prods)}
+	if len(prods) > 0 {
+		r.First = prods[0].ZA()
+	}
+	if len(prods) > 1 {
+		r.Second = prods[1].ZA()
+	}
+	return r
+}
+
+func (r Record) encode(b []byte) {
+	le := binary.LittleEndian
+	le.PutUint16(b[0:], uint16(r.Parent.Number))
+	le.PutUint16(b[2:], uint16(r.Parent.Mass))
+	le.PutUint16(b[4:], uint16(r.First.Number))
+	le.PutUint16(b[6:], uint16(r.First.Mass))
+	le.PutUint16(b[8:], uint16(r.Second.Number))
+	le.PutUint16(b[10:], uint16(r.Second.Mass))
+	le.PutUint16(b[12:], uint16(r.Neutrons))
+	le.PutUint16(b[14:], uint16(r.Generation))
+	le.PutUint32(b[16:], math.Float32bits(float32(r.Energy)))
+	le.PutUint32(b[20:], math.Float32bits(float32(r.KineticEnergy[0])))
+	le.PutUint32(b[24:], math.Float32bits(float32(r.KineticEnergy[1])))
+}
+
+func decode(b []byte) Record {
+	le := binary.LittleEndian
+	u := func(i int) int { return int(le.Uint16(b[i:])) }
+	r := Record{
+		Parent:     isotope.ZA{Number: u(0), Mass: u(2)},
+		First:      isotope.ZA{Number: u(4), Mass: u(6)},
+		Second:     isotope.ZA{Number: u(8), Mass: u(10)},
+		Neutrons:   u(12),
+		Generation: u(14),
+	}
+	f := func(i int) float64 { return float64(math.Float32frombits(le.Uint32(b[i:]))) }
+	if len(b) >= 20 {
+		r.Energy = f(16)
+	}
+	if len(b) >= 28 {
+		r.KineticEnergy = [2]float64{f(20), f(24)}
+	}
+	return r
+}
+
+// Writer appends records to an event log.
+type Writer struct {
+	w   *bufio.Writer
+	buf [RecordSize]byte
+}
+
+// NewWriter writes header of event log to w.
+func NewWriter(w io.Writer) (*Writer, error) {
+	bw := bufio.NewWriter(w)
+	var h [headerSize]byte
+	copy(h[:], magic)
+	binary.LittleEndian.PutUint32(h[8:], RecordSize)
+	if _, err := bw.Write(h[:]); err != nil {
+		return nil, err
+	}
+	return &Writer{w: bw}, nil
+}
+
+// Write appends record.
+func (w *Writer) Write(r Record) error {
+	r.encode(w.buf[:])
+	_, err := w.w.Write(w.buf[:])
+	return err
+}
+
+// Flush writes buffered records to underlying writer.
+func (w *Writer) Flush() error {
+	return w.w.Flush()
+}
+
+// Close flushes buffered records. Underlying writer is not closed.
+func (w *Writer) Close() error {
+	return w.Flush()
+}
+
+func parseHeader(h []byte) (int, error) {
+	if len(h) < headerSize || string(h[:len(magic)]) != magic {
+		return 0, ErrFormat
+	}
+	size := int(binary.LittleEndian.Uint32(h[8:]))
+	if size < minRecordSize {
+		return 0, ErrFormat
+	}
+	return size, nil
+}
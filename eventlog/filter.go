@@ -0,0 +1,34 @@
+package eventlog
+
+import "physics/isotope"
+
+// Filter selects records, its zero value selects all of them.
+type Filter struct {
+	// Parent and Fragment, either of fragments, select records of nuclide if they're set.
+	Parent   isotope.ZA
+	Fragment isotope.ZA
+
+	// Neutrons selects records of number of neutrons if it's set.
+	Neutrons *int
+
+	// MinTKE and MaxTKE select records of total kinetic energy of fragments in MeV at least
+	// and at most, MaxTKE isn't limit if it's zero.
+	MinTKE float64
+	MaxTKE float64
+}
+
+// Match reports whether filter selects record.
+func (f Filter) Match(r Record) bool {
+	tke := r.KineticEnergy[0] + r.KineticEnergy[1]
+	switch {
+	case f.Parent != (isotope.ZA{}) && r.Parent != f.Parent:
+		return false
+	case f.Fragment != (isotope.ZA{}) && r.First != f.Fragment && r.Second != f.Fragment:
+		return false
+	case f.Neutrons != nil && r.Neutrons != *f.Neutrons:
+		return false
+	case tke < f.MinTKE || (f.MaxTKE > 0 && tke > f.MaxTKE):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,17 @@
+//go:build !unix
+
+package eventlog
+
+import (
+	"errors"
+	"os"
+)
+
+// Files are read with ReadAt where memory mapping is not supported.
+func mmap(f *os.File, size int64) ([]byte, error) {
+	return nil, errors.New("eventlog: memory mapping not supported")
+}
+
+func munmap(data []byte) error {
+	return nil
+}
@@ -0,0 +1,32 @@
+package eventlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// NDJSONWriter streams records as newline delimited JSON, one object per event.
+type NDJSONWriter struct {
+	w      *bufio.Writer
+	enc    *json.Encoder
+	events int64
+}
+
+// NewNDJSONWriter creates NDJSON writer of records to w. Close must be called to flush buffered lines.
+func NewNDJSONWriter(w io.Writer) *NDJSONWriter {
+	bw := bufio.NewWriter(w)
+	return &NDJSONWriter{w: bw, enc: json.NewEncoder(bw)}
+}
+
+// Write appends record as a single line.
+func (jw *NDJSONWriter) Write(r Record) error {
+	err := jw.enc.Encode(NewRow(jw.events, r))
+	jw.events++
+	return err
+}
+
+// Close flushes buffered lines. Underlying writer is not closed.
+func (jw *NDJSONWriter) Close() error {
+	return jw.w.Flush()
+}
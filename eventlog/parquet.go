@@ -0,0 +1,74 @@
+package eventlog
+
+import (
+	"io"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// Row is a record in Parquet file and a line of NDJSON stream.
+type Row struct {
+	Event    int64   `parquet:"event" json:"event"`
+	ParentZ  int32   `parquet:"parent_z" json:"parent_z"`
+	ParentA  int32   `parquet:"parent_a" json:"parent_a"`
+	FirstZ   int32   `parquet:"first_z" json:"first_z"`
+	FirstA   int32   `parquet:"first_a" json:"first_a"`
+	SecondZ  int32   `parquet:"second_z" json:"second_z"`
+	SecondA  int32   `parquet:"second_a" json:"second_a"`
+	Neutrons int32   `parquet:"neutrons" json:"neutrons"`
+	Energy   float64 `parquet:"energy_mev" json:"energy_mev"`
+
+	Generation int32   `parquet:"generation" json:"generation"`
+	FirstKE    float64 `parquet:"first_ke_mev" json:"first_ke_mev"`
+	SecondKE   float64 `parquet:"second_ke_mev" json:"second_ke_mev"`
+}
+
+// NewRow converts record with index of event to a row.
+func NewRow(event int64, r Record) Row {
+	return Row{
+		Event:    event,
+		ParentZ:  int32(r.Parent.Number),
+		ParentA:  int32(r.Parent.Mass),
+		FirstZ:   int32(r.First.Number),
+		FirstA:   int32(r.First.Mass),
+		SecondZ:  int32(r.Second.Number),
+		SecondA:  int32(r.Second.Mass),
+		Neutrons: int32(r.Neutrons),
+		Energy:   r.Energy,
+
+		Generation: int32(r.Generation),
+		FirstKE:    r.KineticEnergy[0],
+		SecondKE:   r.KineticEnergy[1],
+	}
+}
+
+// Parquet row group size, large enough for efficient column compression of millions of events.
+const rowGroupSize = 1 << 20
+
+// ParquetWriter writes records to Apache Parquet file with zstd compressed columns.
+type ParquetWriter struct {
+	w      *parquet.GenericWriter[Row]
+	events int64
+}
+
+// NewParquetWriter creates Parquet writer of records to w. Close must be called to write file footer.
+func NewParquetWriter(w io.Writer) *ParquetWriter {
+	return &ParquetWriter{
+		w: parquet.NewGenericWriter[Row](w,
+			parquet.Compression(&parquet.Zstd),
+			parquet.MaxRowsPerRowGroup(rowGroupSize),
+		),
+	}
+}
+
+// Write appends record.
+func (pw *ParquetWriter) Write(r Record) error {
+	_, err := pw.w.Write([]Row{NewRow(pw.events, r)})
+	pw.events++
+	return err
+}
+
+// Close flushes rows and writes file footer. Underlying writer is not closed.
+func (pw *ParquetWriter) Close() error {
+	return pw.w.Close()
+}
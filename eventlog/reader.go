@@ -0,0 +1,97 @@
+package eventlog
+
+import (
+	"fmt"
+	"os"
+)
+
+// Reader gives random access to records of an event log file. On unix systems
+// file is memory mapped, so logs larger than memory can be scanned.
+type Reader struct {
+	f      *os.File
+	data   []byte // mapped file, nil if not mapped
+	size   int    // record size
+	length int
+}
+
+// Open opens event log file for reading.
+func Open(path string) (*Reader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	var h [headerSize]byte
+	if _, err := f.ReadAt(h[:], 0); err != nil {
+		f.Close()
+		return nil, ErrFormat
+	}
+	size, err := parseHeader(h[:])
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	r := &Reader{f: f, size: size, length: int((info.Size() - headerSize) / int64(size))}
+	if data, err := mmap(f, info.Size()); err == nil {
+		r.data = data
+	}
+	return r, nil
+}
+
+// Len returns number of records.
+func (r *Reader) Len() int {
+	return r.length
+}
+
+// At returns record at index.
+func (r *Reader) At(i int) (Record, error) {
+	if i < 0 || i >= r.length {
+		return Record{}, fmt.Errorf("eventlog: index %d out of range [0, %d)", i, r.length)
+	}
+	off := headerSize + i*r.size
+	if r.data != nil {
+		return decode(r.data[off : off+r.size]), nil
+	}
+	buf := make([]byte, r.size)
+	if _, err := r.f.ReadAt(buf, int64(off)); err != nil {
+		return Record{}, err
+	}
+	return decode(buf), nil
+}
+
+// Range calls fn for records with index in [from, to) until fn returns false.
+func (r *Reader) Range(from, to int, fn func(i int, rec Record) bool) error {
+	if from < 0 {
+		from = 0
+	}
+	if to > r.length {
+		to = r.length
+	}
+	for i := from; i < to; i++ {
+		rec, err := r.At(i)
+		if err != nil {
+			return err
+		}
+		if !fn(i, rec) {
+			break
+		}
+	}
+	return nil
+}
+
+// Close unmaps and closes the file.
+func (r *Reader) Close() error {
+	if r.data != nil {
+		if err := munmap(r.data); err != nil {
+			r.f.Close()
+			return err
+		}
+		r.data = nil
+	}
+	return r.f.Close()
+}
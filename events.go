@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"physics/eventlog"
+	"physics/isotope"
+)
+
+func eventsCmd() *cobra.Command {
+	var (
+		filter           eventlog.Filter
+		parent, fragment string
+		neutrons         int
+		from, limit      int
+		format           string
+	)
+	cmd := &cobra.Command{
+		Use:   "events <event log>",
+		Short: "Filter and print fission events of event log",
+		Long: "Filter and print fission events of event log saved by simulate --eventlog, with their\n" +
+			"parent, fragments, neutrons and energies, and summarize events which match.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := cmd.Flags()
+			for _, nuclide := range []struct {
+				name string
+				za   *isotope.ZA
+			}{{parent, &filter.Parent}, {fragment, &filter.Fragment}} {
+				if nuclide.name == "" {
+					continue
+				}
+				iso, err := isotope.Parse(nuclide.name)
+				if err != nil {
+					return err
+				}
+				*nuclide.za = iso.ZA()
+			}
+			if f.Changed("neutrons") {
+				filter.Neutrons = &neutrons
+			}
+			var write func(i int, r eventlog.Record) error
+			var flush func() error
+			switch format {
+			case "table":
+				fmt.Printf("%8s  %-8s %-8s %-8s %8s %10s %10s\n", "event", "parent", "first", "second", "neutrons", "q_mev", "tke_mev")
+				write = func(i int, r eventlog.Record) error {
+					_, err := fmt.Printf("%8d  %-8s %-8s %-8s %8d %10.2f %10.2f\n", i, nuclideName(r.Parent), nuclideName(r.First), nuclideName(r.Second),
+						r.Neutrons, r.Energy, r.KineticEnergy[0]+r.KineticEnergy[1])
+					return err
+				}
+				flush = func() error { return nil }
+			case "csv":
+				cw := csv.NewWriter(os.Stdout)
+				cw.Write([]string{"event", "parent", "first", "second", "neutrons", "generation", "energy_mev", "first_ke_mev", "second_ke_mev"})
+				format := func(v float64) string { return strconv.FormatFloat(v, 'g', -1, 64) }
+				write = func(i int, r eventlog.Record) error {
+					return cw.Write([]string{strconv.Itoa(i), nuclideName(r.Parent), nuclideName(r.First), nuclideName(r.Second),
+						strconv.Itoa(r.Neutrons), strconv.Itoa(r.Generation), format(r.Energy), format(r.KineticEnergy[0]), format(r.KineticEnergy[1])})
+				}
+				flush = func() error {
+					cw.Flush()
+					return cw.Error()
+				}
+			case "ndjson":
+				enc := json.NewEncoder(os.Stdout)
+				write = func(i int, r eventlog.Record) error {
+					return enc.Encode(eventlog.NewRow(int64(i), r))
+				}
+				flush = func() error { return nil }
+			default:
+				return fmt.Errorf("events: unknown format %q, expected table, csv or ndjson", format)
+			}
+
+			log, err := eventlog.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer log.Close()
+			matched, printed := 0, 0
+			var neutronSum, tkeSum float64
+			var werr error
+			err = log.Range(0, log.Len(), func(i int, r eventlog.Record) bool {
+				if !filter.Match(r) {
+					return true
+				}
+				matched++
+				neutronSum += float64(r.Neutrons)
+				tkeSum += r.KineticEnergy[0] + r.KineticEnergy[1]
+				if matched > from && (limit <= 0 || printed < limit) {
+					printed++
+					werr = write(i, r)
+				}
+				return werr == nil
+			})
+			if err == nil {
+				err = werr
+			}
+			if err == nil {
+				err = flush()
+			}
+			if err != nil {
+				return err
+			}
+			// summary goes to stderr, so data formats can be piped
+			w := os.Stdout
+			if format != "table" {
+				w = os.Stderr
+			}
+			fmt.Fprintf(w, "%d of %d events match", matched, log.Len())
+			if matched > 0 {
+				fmt.Fprintf(w, ", mean neutrons %.4f, mean TKE %.2f MeV", neutronSum/float64(matched), tkeSum/float64(matched))
+			}
+			fmt.Fprintln(w)
+			return nil
+		},
+	}
+	f := cmd.Flags()
+	f.StringVar(&parent, "parent", "", "only events of fission of parent nuclide, e.g. U-235")
+	f.StringVar(&fragment, "fragment", "", "only events with fragment, e.g. Cs-137")
+	f.IntVar(&neutrons, "neutrons", 0, "only events which released number of neutrons")
+	f.Float64Var(&filter.MinTKE, "min-tke", 0, "only events of total kinetic energy of fragments at least in MeV")
+	f.Float64Var(&filter.MaxTKE, "max-tke", 0, "only events of total kinetic energy of fragments at most in MeV, no limit if zero")
+	f.IntVar(&from, "skip", 0, "number of matching events skipped before printing")
+	f.IntVar(&limit, "limit", 20, "number of matching events printed, 0 prints all")
+	f.StringVar(&format, "format", "table", "output format: table, csv or ndjson")
+	return cmd
+}
+
+// nuclideName returns name of nuclide like "Cs-137", "-" for missing fragment.
+func nuclideName(za isotope.ZA) string {
+	if za == (isotope.ZA{}) {
+		return "-"
+	}
+	if iso, ok := isotope.Lookup(za.Number, za.Mass); ok {
+		return iso.Name()
+	}
+	return fmt.Sprintf("Z%d-%d", za.Number, za.Mass)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"physics/fission"
+)
+
+// experiment is config file of simulate command: simulation and its outputs.
+type experiment struct {
+	fission.Config `yaml:",inline"`
+
+	Output experimentOutput `json:"output" yaml:"output" toml:"output"`
+}
+
+// experimentOutput mirrors output flags of simulate command.
+type experimentOutput struct {
+	Dir       string   `json:"dir" yaml:"dir" toml:"dir"`
+	Prefix    string   `json:"prefix" yaml:"prefix" toml:"prefix"`
+	Overwrite string   `json:"overwrite" yaml:"overwrite" toml:"overwrite"`
+	Formats   []string `json:"formats" yaml:"formats" toml:"formats"`
+	Image     string   `json:"image" yaml:"image" toml:"image"`
+	Compress  string   `json:"compress" yaml:"compress" toml:"compress"`
+	Charts    *bool    `json:"charts" yaml:"charts" toml:"charts"`
+	Report    bool     `json:"report" yaml:"report" toml:"report"`
+	Parquet   string   `json:"parquet" yaml:"parquet" toml:"parquet"`
+	NDJSON    string   `json:"ndjson" yaml:"ndjson" toml:"ndjson"`
+	Snapshot  int      `json:"snapshot" yaml:"snapshot" toml:"snapshot"`
+}
+
+// loadExperiment reads config file at path, defaults of missing fields are the defaults of flags.
+func loadExperiment(path string) (experiment, error) {
+	e := experiment{Config: fission.DefaultConfig()}
+	err := fission.LoadFile(path, &e)
+	return e, err
+}
+
+// apply sets flags that weren't given on command line to values of the file.
+func (o experimentOutput) apply(f *pflag.FlagSet) error {
+	values := map[string]string{
+		"out":       o.Dir,
+		"prefix":    o.Prefix,
+		"overwrite": o.Overwrite,
+		"format":    strings.Join(o.Formats, ","),
+		"image":     o.Image,
+		"compress":  o.Compress,
+		"parquet":   o.Parquet,
+		"ndjson":    o.NDJSON,
+	}
+	if o.Charts != nil {
+		values["nocharts"] = strconv.FormatBool(!*o.Charts)
+	}
+	if o.Report {
+		values["report"] = "true"
+	}
+	if o.Snapshot > 0 {
+		values["snapshot"] = strconv.Itoa(o.Snapshot)
+	}
+	for name, v := range values {
+		if v == "" || f.Changed(name) {
+			continue
+		}
+		if err := f.Set(name, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
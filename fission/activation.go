@@ -0,0 +1,147 @@
+package fission
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"physics/count"
+	"physics/isotope"
+	"physics/random"
+)
+
+// Structure is background material, e.g. steel of cladding or coolant, which captures
+// Fraction of neutrons released in fission. Captures activate its nuclides to the next
+// heavier isotope, e.g. Fe-58 to Fe-59, Na-23 to Na-24 or deuterium of heavy water to tritium.
+type Structure struct {
+	// Material is "Fe", "Na", "H2O" or "D2O".
+	Material string  `json:"material" yaml:"material" toml:"material"`
+	Fraction float64 `json:"fraction" yaml:"fraction" toml:"fraction"`
+}
+
+// target is nuclide of material with rate of its captures, which is number of its atoms
+// per molecule times its abundance and thermal capture cross section in barns.
+type target struct {
+	za   isotope.ZA
+	rate float64
+}
+
+// materials are natural compositions of structural materials with thermal capture cross
+// sections of ENDF/B-VIII.0.
+var materials = map[string][]target{
+	"Fe": {
+		{isotope.ZA{Number: 26, Mass: 54}, 0.05845 * 2.25},
+		{isotope.ZA{Number: 26, Mass: 56}, 0.91754 * 2.59},
+		{isotope.ZA{Number: 26, Mass: 57}, 0.02119 * 2.48},
+		{isotope.ZA{Number: 26, Mass: 58}, 0.00282 * 1.30},
+	},
+	"Na": {
+		{isotope.ZA{Number: 11, Mass: 23}, 0.530},
+	},
+	"H2O": {
+		{isotope.ZA{Number: 1, Mass: 1}, 2 * 0.999885 * 0.3326},
+		{isotope.ZA{Number: 1, Mass: 2}, 2 * 0.000115 * 0.000519},
+		{isotope.ZA{Number: 8, Mass: 16}, 0.99757 * 0.00019},
+	},
+	"D2O": {
+		{isotope.ZA{Number: 1, Mass: 2}, 2 * 0.000519},
+		{isotope.ZA{Number: 8, Mass: 16}, 0.99757 * 0.00019},
+	},
+}
+
+// Materials returns names of structural materials.
+func Materials() []string {
+	return count.SortedKeys(materials)
+}
+
+// ParseStructures parses comma separated materials with fractions of neutrons they capture,
+// e.g. "Fe:0.05,H2O:0.1".
+func ParseStructures(s string) ([]Structure, error) {
+	var structures []Structure
+	for _, f := range strings.Split(s, ",") {
+		name, fraction, ok := strings.Cut(strings.TrimSpace(f), ":")
+		if name == "" {
+			continue
+		}
+		if !ok {
+			return nil, fmt.Errorf("structures %q: %s has no fraction", s, name)
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(fraction), 64)
+		if err != nil {
+			return nil, fmt.Errorf("structures %q: invalid fraction %q", s, fraction)
+		}
+		structures = append(structures, Structure{Material: name, Fraction: v})
+	}
+	return structures, nil
+}
+
+// Activation is capture of neutrons released in fission by structural materials.
+type Activation struct {
+	Structures []Structure
+
+	// fractions are cumulative fractions of neutrons captured by each structure, rates
+	// are cumulative rates of captures of its nuclides and products are their products
+	fractions []float64
+	rates     [][]float64
+	products  [][]*isotope.Isotope
+}
+
+// NewActivation creates activation of structures, which together capture at most all neutrons.
+func NewActivation(structures []Structure) (*Activation, error) {
+	if len(structures) == 0 {
+		return nil, errors.New("activation: no structures")
+	}
+	a := &Activation{Structures: structures}
+	total := 0.0
+	for _, s := range structures {
+		targets, ok := materials[s.Material]
+		if !ok {
+			return nil, fmt.Errorf("activation: unknown material %q, known are %s", s.Material, strings.Join(Materials(), ", "))
+		}
+		if s.Fraction < 0 {
+			return nil, fmt.Errorf("activation: %s has negative fraction %g", s.Material, s.Fraction)
+		}
+		total += s.Fraction
+		a.fractions = append(a.fractions, total)
+
+		var rates []float64
+		var products []*isotope.Isotope
+		sum := 0.0
+		for _, t := range targets {
+			p, ok := isotope.Lookup(t.za.Number, t.za.Mass+1)
+			if !ok {
+				return nil, fmt.Errorf("activation: no product of capture by Z=%d A=%d", t.za.Number, t.za.Mass)
+			}
+			sum += t.rate
+			rates = append(rates, sum)
+			products = append(products, p)
+		}
+		a.rates = append(a.rates, rates)
+		a.products = append(a.products, products)
+	}
+	if total > 1 {
+		return nil, fmt.Errorf("activation: structures capture %g of neutrons, more than all", total)
+	}
+	return a, nil
+}
+
+// Capture draws whether neutron is captured by structure, and which structure and product
+// of capture it is.
+func (a *Activation) Capture(rng random.Rand) (int, *isotope.Isotope, bool) {
+	u := rng.Float64()
+	for i, f := range a.fractions {
+		if u >= f {
+			continue
+		}
+		rates := a.rates[i]
+		v := rng.Float64() * rates[len(rates)-1]
+		for j, r := range rates {
+			if v < r {
+				return i, a.products[i][j], true
+			}
+		}
+		return i, a.products[i][len(rates)-1], true
+	}
+	return 0, nil, false
+}
@@ -0,0 +1,116 @@
+//go:build !nochart
+
+package fission
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/png"
+	"io"
+	"math"
+	"slices"
+
+	"github.com/wcharczuk/go-chart/v2"
+
+	"physics/isotope"
+)
+
+// Saves animation of convergence of probabilities of elements to .gif file
+func (sr *Series) SaveAnimation(out isotope.OutputConfig, opts isotope.ChartOptions) error {
+	return out.Save("convergence.gif", func(w io.Writer) error {
+		return sr.RenderAnimation(w, opts)
+	})
+}
+
+// RenderAnimation renders animated gif to w with a frame of bar chart of probabilities of
+// elements of each snapshot, so it shows how distribution converges as events are
+// simulated. Frames share elements and range of value axis, and the last one is held longer.
+func (sr *Series) RenderAnimation(w io.Writer, opts isotope.ChartOptions) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", isotope.ErrChartFailed, r)
+		}
+	}()
+
+	if len(sr.Snapshots) == 0 {
+		return fmt.Errorf("animation: no snapshots")
+	}
+	var symbols []string
+	top := 0.0
+	for _, snap := range sr.Snapshots {
+		for s, p := range snap.Probabilities {
+			if !slices.Contains(symbols, s) {
+				symbols = append(symbols, s)
+			}
+			top = max(top, p)
+		}
+	}
+	slices.SortFunc(symbols, func(a, b string) int {
+		za, _ := isotope.SymbolNumber(a)
+		zb, _ := isotope.SymbolNumber(b)
+		return cmp.Or(cmp.Compare(za, zb), cmp.Compare(a, b))
+	})
+	if opts.YMax <= opts.YMin {
+		opts.YMin, opts.YMax = 0, max(1, math.Ceil(1.1*top))
+	}
+
+	anim := &gif.GIF{}
+	indices := make(map[color.Color]uint8)
+	for i, snap := range sr.Snapshots {
+		var values []chart.Value
+		for _, s := range symbols {
+			values = append(values, chart.Value{Label: s, Value: snap.Probabilities[s]})
+		}
+		graph := chart.BarChart{
+			Title: fmt.Sprintf("Probability of elements after %d events", snap.Events),
+			Background: chart.Style{
+				Padding: chart.Box{Top: 50},
+			},
+			Width:    1600,
+			Height:   512,
+			BarWidth: 10,
+			Bars:     values,
+			YAxis:    chart.YAxis{Name: "probability [%]"},
+		}
+		opts.Chart(&graph)
+		var buf bytes.Buffer
+		if err := graph.Render(chart.PNG, &buf); err != nil {
+			return err
+		}
+		img, err := png.Decode(&buf)
+		if err != nil {
+			return err
+		}
+		delay := 50
+		if i == len(sr.Snapshots)-1 {
+			delay = 300
+		}
+		anim.Image = append(anim.Image, paletted(img, indices))
+		anim.Delay = append(anim.Delay, delay)
+	}
+	return gif.EncodeAll(w, anim)
+}
+
+// paletted converts image to Plan 9 palette. Charts have few colors, so indices of nearest
+// colors of palette are cached between pixels and frames.
+func paletted(img image.Image, indices map[color.Color]uint8) *image.Paletted {
+	b := img.Bounds()
+	p := image.NewPaletted(b, palette.Plan9)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := img.At(x, y)
+			i, ok := indices[c]
+			if !ok {
+				i = uint8(p.Palette.Index(c))
+				indices[c] = i
+			}
+			p.SetColorIndex(x, y, i)
+		}
+	}
+	return p
+}
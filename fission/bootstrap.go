@@ -0,0 +1,82 @@
+package fission
+
+import (
+	"math"
+	"sort"
+
+	"physics/random"
+)
+
+// Bootstrap are replicas of results, each of them merges as many batches as results have,
+// drawn from batches of results with replacement. Spread of a quantity over replicas is
+// estimate of its uncertainty, also of quantities like ratios whose error isn't known.
+type Bootstrap struct {
+	Replicas []Batch
+}
+
+// Bootstrap resamples batches of results n times drawing from rng, so it's the same for the
+// same results and rng of the same seed. Results with fewer batches give coarser estimates,
+// see Simulation.BatchSize.
+func (r *Results) Bootstrap(n int, rng random.Rand) *Bootstrap {
+	b := &Bootstrap{}
+	if len(r.Batches) == 0 {
+		return b
+	}
+	for range n {
+		replica := Batch{Symbols: make(map[string]int)}
+		for range r.Batches {
+			batch := r.Batches[rng.Intn(len(r.Batches))]
+			replica.Fissions += batch.Fissions
+			for s, c := range batch.Symbols {
+				replica.Symbols[s] += c
+			}
+		}
+		b.Replicas = append(b.Replicas, replica)
+	}
+	return b
+}
+
+// Estimate returns statistics of quantity over replicas. StdDev and StdErr are both standard
+// deviation of replicas, which is standard error of quantity, and bounds of confidence interval
+// are 2.5th and 97.5th percentiles of replicas. Replicas of which quantity isn't finite, e.g. of
+// ratio with zero yield, are skipped.
+func (b *Bootstrap) Estimate(quantity func(Batch) float64) Stat {
+	var values []float64
+	for _, r := range b.Replicas {
+		if v := quantity(r); !math.IsNaN(v) && !math.IsInf(v, 0) {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		return Stat{}
+	}
+	s := NewStat(values)
+	s.StdErr = s.StdDev
+	sort.Float64s(values)
+	s.Lower, s.Upper = percentile(values, 0.025), percentile(values, 0.975)
+	return s
+}
+
+// percentile returns percentile p of sorted values, interpolated between values.
+func percentile(sorted []float64, p float64) float64 {
+	x := p * float64(len(sorted)-1)
+	i := int(x)
+	if i+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (x-float64(i))*(sorted[i+1]-sorted[i])
+}
+
+// ElementYield returns yield of element per fission of batch in percent, e.g. of "Cs".
+func ElementYield(symbol string) func(Batch) float64 {
+	return func(b Batch) float64 {
+		return 100 * float64(b.Symbols[symbol]) / float64(b.Fissions)
+	}
+}
+
+// Ratio returns ratio of yields of two elements of batch, e.g. of "Cs" and "Sr".
+func Ratio(numerator, denominator string) func(Batch) float64 {
+	return func(b Batch) float64 {
+		return float64(b.Symbols[numerator]) / float64(b.Symbols[denominator])
+	}
+}
@@ -0,0 +1,150 @@
+package fission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"physics/count"
+	"physics/isotope"
+)
+
+// BundleFile is name of run bundle file in output directory.
+const BundleFile = "run.json"
+
+// Bundle holds configuration, seed and data checksum of a run with its tallies,
+// so the run can be re-executed and checked.
+type Bundle struct {
+	// Config of run with seed which was used.
+	Config Config `json:"config" yaml:"config"`
+
+	// DataChecksum is checksum of isotopes table used by run.
+	DataChecksum string `json:"data_checksum" yaml:"data_checksum"`
+
+	Fissions int            `json:"fissions" yaml:"fissions"`
+	Failures int            `json:"failures" yaml:"failures"`
+	Symbols  map[string]int `json:"symbols" yaml:"symbols"`
+	Neutrons map[int]int    `json:"neutrons" yaml:"neutrons"`
+}
+
+// NewBundle creates bundle of results of a run of config.
+func NewBundle(c Config, r *Results) (Bundle, error) {
+	sum, err := isotope.Checksum()
+	if err != nil {
+		return Bundle{}, err
+	}
+	c.Seed = r.Seed
+	ns := r.NeutronStats()
+	return Bundle{
+		Config:       c,
+		DataChecksum: sum,
+		Fissions:     ns.Fissions,
+		Failures:     r.Failures,
+		Symbols:      r.Tally.CountSymbols(),
+		Neutrons:     ns.Histogram,
+	}, nil
+}
+
+// Save saves bundle to BundleFile in directory of out. Bundle is never compressed, prefixed
+// or renamed, so LoadBundle finds it; a later run saved to the same directory replaces it.
+func (b Bundle) Save(out isotope.OutputConfig) error {
+	out.Prefix = ""
+	out.Overwrite = isotope.OverwriteReplace
+	out.Compression = isotope.NoCompression
+	return out.Save(BundleFile, b.WriteJSON)
+}
+
+// WriteJSON writes indented json to w
+func (b Bundle) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(b, "", " ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// LoadBundle reads bundle from directory of a run.
+func LoadBundle(dir string) (Bundle, error) {
+	var b Bundle
+	data, err := os.ReadFile(filepath.Join(dir, BundleFile))
+	if err != nil {
+		return b, err
+	}
+	err = json.Unmarshal(data, &b)
+	return b, err
+}
+
+// Mismatch is tally which differs between stored and re-executed run.
+type Mismatch struct {
+	Tally    string `json:"tally" yaml:"tally"`
+	Stored   int    `json:"stored" yaml:"stored"`
+	Verified int    `json:"verified" yaml:"verified"`
+}
+
+func (m Mismatch) String() string {
+	return fmt.Sprintf("%s: stored %d, verified %d", m.Tally, m.Stored, m.Verified)
+}
+
+// Verify re-executes run of bundle, with as many workers as it had, and returns tallies which
+// differ by more than tolerance, relative to stored value. Zero tolerance requires bit-for-bit
+// identical tallies.
+func (b Bundle) Verify(ctx context.Context, tolerance float64) ([]Mismatch, error) {
+	sum, err := isotope.Checksum()
+	if err != nil {
+		return nil, err
+	}
+	if sum != b.DataChecksum {
+		return nil, fmt.Errorf("isotope data checksum %s differs from checksum %s of the run", sum, b.DataChecksum)
+	}
+	results, err := b.Config.Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+	got, err := NewBundle(b.Config, results)
+	if err != nil {
+		return nil, err
+	}
+
+	var ms []Mismatch
+	cmp := func(tally string, stored, verified int) {
+		if stored != verified && math.Abs(float64(verified-stored)) > tolerance*math.Abs(float64(stored)) {
+			ms = append(ms, Mismatch{Tally: tally, Stored: stored, Verified: verified})
+		}
+	}
+	cmp("fissions", b.Fissions, got.Fissions)
+	cmp("failures", b.Failures, got.Failures)
+	for _, s := range union(b.Symbols, got.Symbols) {
+		cmp("symbol "+s, b.Symbols[s], got.Symbols[s])
+	}
+	for n := range mergeKeys(b.Neutrons, got.Neutrons) {
+		cmp(fmt.Sprintf("neutrons %d", n), b.Neutrons[n], got.Neutrons[n])
+	}
+	sort.Slice(ms, func(i, j int) bool { return ms[i].Tally < ms[j].Tally })
+	return ms, nil
+}
+
+func union(a, b map[string]int) []string {
+	return count.SortedKeys(mergeKeys(a, b))
+}
+
+func mergeKeys[K comparable](a, b map[K]int) map[K]bool {
+	keys := make(map[K]bool, len(a))
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	return keys
+}
+
+// NeutronStats returns statistics of neutrons from stored histogram.
+func (b Bundle) NeutronStats() NeutronStats {
+	return HistogramStats(b.Neutrons)
+}
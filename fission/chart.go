@@ -0,0 +1,146 @@
+//go:build !nochart
+
+package fission
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/wcharczuk/go-chart/v2"
+
+	"physics/isotope"
+)
+
+// Saves neutron population over time to image file
+func (p *Population) SaveChart(out isotope.OutputConfig, opts isotope.ChartOptions) error {
+	return out.Save("population"+out.Image.Ext(), func(w io.Writer) error {
+		return p.RenderChart(w, out.Image, opts)
+	})
+}
+
+// RenderChart renders neutron population over time in format to w
+func (p *Population) RenderChart(w io.Writer, format isotope.ImageFormat, opts isotope.ChartOptions) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", isotope.ErrChartFailed, r)
+		}
+	}()
+
+	xs := make([]float64, len(p.Generations))
+	ys := make([]float64, len(p.Generations))
+	for i, g := range p.Generations {
+		xs[i], ys[i] = 1e6*g.Time, g.Population
+	}
+	graph := chart.Chart{
+		Title: "Neutron population",
+		Background: chart.Style{
+			Padding: chart.Box{Top: 50, Left: 20, Right: 20},
+		},
+		Width:  1200,
+		Height: 500,
+		XAxis:  chart.XAxis{Name: "time [µs]"},
+		YAxis:  chart.YAxis{Name: "neutrons"},
+		Series: []chart.Series{chart.ContinuousSeries{
+			Name:    "population",
+			XValues: xs,
+			YValues: ys,
+			Style: chart.Style{
+				StrokeColor: chart.GetDefaultColor(0),
+				StrokeWidth: 2,
+			},
+		}},
+	}
+	opts.Line(&graph)
+	return graph.Render(format.Renderer(), w)
+}
+
+// Saves multiplicity histogram to image file
+func (ns NeutronStats) SaveChart(out isotope.OutputConfig, opts isotope.ChartOptions) error {
+	return out.Save("neutrons"+out.Image.Ext(), func(w io.Writer) error {
+		return ns.RenderChart(w, out.Image, opts)
+	})
+}
+
+// RenderChart renders multiplicity histogram in format to w
+func (ns NeutronStats) RenderChart(w io.Writer, format isotope.ImageFormat, opts isotope.ChartOptions) (err error) {
+	if opts.Renderer != nil {
+		return opts.Renderer.Bars(w, format, ns.Plot(opts), opts)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", isotope.ErrChartFailed, r)
+		}
+	}()
+
+	var values []chart.Value
+	for _, m := range ns.Multiplicities() {
+		values = append(values, chart.Value{Label: fmt.Sprintf("%d (%.1f%%)", m, 100*ns.Probability(m)), Value: float64(ns.Histogram[m])})
+	}
+
+	graph := chart.BarChart{
+		Title: fmt.Sprintf("Neutron multiplicity (mean %.3f)", ns.Mean),
+		Background: chart.Style{
+			Padding: chart.Box{
+				Top: 50,
+			},
+		},
+		Width:    720,
+		Height:   512,
+		BarWidth: 60,
+		Bars:     values,
+	}
+	opts.Chart(&graph)
+	return graph.Render(format.Renderer(), w)
+}
+
+// Saves overlay of simulated and reference yields of elements to image file
+func (c *Comparison) SaveChart(out isotope.OutputConfig, opts isotope.ChartOptions) error {
+	return out.Save("reference"+out.Image.Ext(), func(w io.Writer) error {
+		return c.RenderChart(w, out.Image, opts)
+	})
+}
+
+// RenderChart renders simulated and reference yields of elements by atomic number in format to w
+func (c *Comparison) RenderChart(w io.Writer, format isotope.ImageFormat, opts isotope.ChartOptions) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", isotope.ErrChartFailed, r)
+		}
+	}()
+
+	xs := make([]float64, len(c.Elements))
+	refs := make([]float64, len(c.Elements))
+	sims := make([]float64, len(c.Elements))
+	var ticks []chart.Tick
+	for i, d := range c.Elements {
+		xs[i], refs[i], sims[i] = float64(d.Number), d.Reference, d.Simulated
+		ticks = append(ticks, chart.Tick{Value: xs[i], Label: d.Symbol})
+	}
+	series := func(name string, ys []float64, color int) chart.Series {
+		return chart.ContinuousSeries{
+			Name:    name,
+			XValues: xs,
+			YValues: ys,
+			Style: chart.Style{
+				StrokeColor: chart.GetDefaultColor(color),
+				StrokeWidth: 2,
+				DotColor:    chart.GetDefaultColor(color),
+				DotWidth:    3,
+			},
+		}
+	}
+	graph := chart.Chart{
+		Title: fmt.Sprintf("Yields of elements of U-235 (chi-square %.4g for %d degrees of freedom)", c.ChiSquare, c.DegreesOfFreedom),
+		Background: chart.Style{
+			Padding: chart.Box{Top: 50, Left: 20, Right: 20},
+		},
+		Width:  1200,
+		Height: 500,
+		XAxis:  chart.XAxis{Name: "element", Ticks: ticks},
+		YAxis:  chart.YAxis{Name: "cumulative yield [%]"},
+		Series: []chart.Series{series("reference", refs, 0), series("simulated", sims, 1)},
+	}
+	graph.Elements = []chart.Renderable{chart.Legend(&graph)}
+	opts.Line(&graph)
+	return graph.Render(format.Renderer(), w)
+}
@@ -0,0 +1,49 @@
+//go:build nochart
+
+package fission
+
+import (
+	"io"
+
+	"physics/isotope"
+)
+
+// Saves neutron population over time to image file
+func (p *Population) SaveChart(out isotope.OutputConfig, opts isotope.ChartOptions) error {
+	return isotope.ErrChartsDisabled
+}
+
+// RenderChart renders neutron population over time in format to w
+func (p *Population) RenderChart(w io.Writer, format isotope.ImageFormat, opts isotope.ChartOptions) error {
+	return isotope.ErrChartsDisabled
+}
+
+// Saves multiplicity histogram to image file
+func (ns NeutronStats) SaveChart(out isotope.OutputConfig, opts isotope.ChartOptions) error {
+	return isotope.ErrChartsDisabled
+}
+
+// RenderChart renders multiplicity histogram in format to w
+func (ns NeutronStats) RenderChart(w io.Writer, format isotope.ImageFormat, opts isotope.ChartOptions) error {
+	return isotope.ErrChartsDisabled
+}
+
+// Saves overlay of simulated and reference yields of elements to image file
+func (c *Comparison) SaveChart(out isotope.OutputConfig, opts isotope.ChartOptions) error {
+	return isotope.ErrChartsDisabled
+}
+
+// RenderChart renders simulated and reference yields of elements by atomic number in format to w
+func (c *Comparison) RenderChart(w io.Writer, format isotope.ImageFormat, opts isotope.ChartOptions) error {
+	return isotope.ErrChartsDisabled
+}
+
+// Saves animation of convergence of probabilities of elements to .gif file
+func (sr *Series) SaveAnimation(out isotope.OutputConfig, opts isotope.ChartOptions) error {
+	return isotope.ErrChartsDisabled
+}
+
+// RenderAnimation renders animated gif of probabilities of elements of snapshots to w
+func (sr *Series) RenderAnimation(w io.Writer, opts isotope.ChartOptions) error {
+	return isotope.ErrChartsDisabled
+}
@@ -0,0 +1,305 @@
+package fission
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"time"
+
+	"physics/count"
+	"physics/isotope"
+	"physics/units"
+)
+
+// Checkpoint is state of a running simulation: position of its random source and tallies
+// of events done. Simulation resumed from checkpoint produces the same results as if it
+// wasn't interrupted.
+type Checkpoint struct {
+	// Config of simulation with seed which is used.
+	Config Config `json:"config"`
+
+	// Done is number of events simulated.
+	Done int `json:"done"`
+
+	// Draws is number of values drawn from random source, resumed simulation skips them.
+	Draws uint64 `json:"draws"`
+
+	Failures   int               `json:"failures"`
+	Parents    map[string]int    `json:"parents,omitempty"`
+	Captures   map[string]int    `json:"captures,omitempty"`
+	Activation []NuclideCount    `json:"activation,omitempty"`
+	Unmatched  isotope.Unmatched `json:"unmatched"`
+	Energy     float64           `json:"energy"`
+
+	// KineticEnergy is total kinetic energy of products and TKE its histogram.
+	KineticEnergy float64     `json:"kinetic_energy"`
+	TKE           map[int]int `json:"tke"`
+
+	Elapsed time.Duration `json:"elapsed"`
+
+	// Isotopes are counts of products and Neutrons histogram of multiplicity.
+	Isotopes []NuclideCount `json:"isotopes"`
+	Neutrons map[int]int    `json:"neutrons"`
+
+	Observables map[string]float64 `json:"observables,omitempty"`
+	Batches     []Batch            `json:"batches,omitempty"`
+
+	// Batch is tally of batch which hasn't ended yet.
+	Batch *Batch `json:"batch,omitempty"`
+}
+
+// NuclideCount is count of nuclide identified by atomic and mass number, so fragments kept
+// by FragmentKeep, which aren't in isotopes table, are restored too.
+type NuclideCount struct {
+	Number int    `json:"z"`
+	Mass   int    `json:"a"`
+	Symbol string `json:"symbol,omitempty"`
+	Count  int    `json:"count"`
+}
+
+func newNuclideCounts(a *isotope.Accumulator) []NuclideCount {
+	counts := a.Counts()
+	ncs := make([]NuclideCount, len(counts))
+	for i, c := range counts {
+		ncs[i] = NuclideCount{Number: c.Isotope.Number, Mass: c.Isotope.Mass, Symbol: c.Isotope.Symbol, Count: c.Count}
+	}
+	return ncs
+}
+
+// Isotope returns isotope of nuclide from isotopes table, or fragment with symbol of nuclide
+// if table hasn't it.
+func (nc NuclideCount) Isotope() *isotope.Isotope {
+	if iso, ok := isotope.Lookup(nc.Number, nc.Mass); ok {
+		return iso
+	}
+	f := isotope.Fragment(nc.Number, nc.Mass)
+	f.Symbol = nc.Symbol
+	return f
+}
+
+// LoadCheckpoint reads checkpoint file.
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &cp, nil
+}
+
+// Save writes checkpoint to path. File is replaced atomically, so previous checkpoint
+// survives crash while saving.
+func (cp *Checkpoint) Save(path string) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// OnCheckpoint calls f with checkpoint of simulation after every n events, and once more
+// when simulation is cancelled. Checkpoints aren't taken if n isn't positive.
+func (s *Simulation) OnCheckpoint(n int, f func(*Checkpoint)) {
+	s.checkpointEvery = n
+	s.checkpoint = f
+}
+
+// Resume continues simulation from checkpoint cp, which must be checkpoint of simulation
+// of the same isotope, number of events, batch size and seed. Events done before checkpoint
+// aren't published again.
+func (s *Simulation) Resume(cp *Checkpoint) error {
+	c := cp.Config
+	switch {
+	case s.transport != nil:
+		return fmt.Errorf("simulation with transport can't be resumed from checkpoint")
+	case s.Weighting != nil:
+		return fmt.Errorf("simulation with weighting can't be resumed from checkpoint")
+	case !s.RNG.Reproducible():
+		return fmt.Errorf("simulation with %s random engine can't be resumed from checkpoint", s.RNG)
+	case c.RNG != s.RNG:
+		return fmt.Errorf("checkpoint random engine %s differs from simulation engine %s", c.RNG, s.RNG)
+	case c.Isotope != s.Isotope.Name():
+		return fmt.Errorf("checkpoint of %s can't resume simulation of %s", c.Isotope, s.Isotope.Name())
+	case c.FragmentPolicy != s.FragmentPolicy:
+		return fmt.Errorf("checkpoint fragment policy %s differs from simulation policy %s", c.FragmentPolicy, s.FragmentPolicy)
+	case c.NeutronModel != s.NeutronModel:
+		return fmt.Errorf("checkpoint neutron model %s differs from simulation model %s", c.NeutronModel, s.NeutronModel)
+	case c.Yields != s.yields:
+		return fmt.Errorf("checkpoint yields %q differ from simulation yields %q", c.Yields, s.yields)
+	case c.ChargeModel != s.chargeModel():
+		return fmt.Errorf("checkpoint charge model %s differs from simulation model %s", c.ChargeModel, s.chargeModel())
+	case c.Directions != s.Directions || c.Emission != s.Emission:
+		return fmt.Errorf("checkpoint directions %t with %s emission differ from simulation directions %t with %s emission",
+			c.Directions, c.Emission, s.Directions, s.Emission)
+	case !reflect.DeepEqual(c.Fuel, s.fuel()) || (s.Fuel != nil && c.Spectrum != s.Fuel.Spectrum):
+		return fmt.Errorf("checkpoint fuel differs from simulation fuel")
+	case !reflect.DeepEqual(c.Structures, s.structures()):
+		return fmt.Errorf("checkpoint structures differ from simulation structures")
+	case !slices.Equal(c.Multiplicity, s.Isotope.Multiplicity):
+		return fmt.Errorf("checkpoint multiplicity %s differs from simulation multiplicity %s", c.Multiplicity, s.Isotope.Multiplicity)
+	case int(c.Events) != s.Events || c.BatchSize != s.BatchSize:
+		return fmt.Errorf("checkpoint of %d events in batches of %d can't resume simulation of %d events in batches of %d",
+			c.Events, c.BatchSize, s.Events, s.BatchSize)
+	case c.Seed != s.Seed:
+		return fmt.Errorf("checkpoint seed %d differs from simulation seed %d", c.Seed, s.Seed)
+	case cp.Done < 0 || cp.Done > s.Events:
+		return fmt.Errorf("checkpoint has %d of %d events done", cp.Done, s.Events)
+	}
+
+	r := s.results
+	r.Tally = isotope.Accumulator{}
+	r.Products = r.Products[:0]
+	for _, nc := range cp.Isotopes {
+		iso := nc.Isotope()
+		r.Tally.AddN(iso, nc.Count)
+		for i := 0; s.KeepProducts && i < nc.Count; i++ {
+			r.Products = append(r.Products, iso)
+		}
+	}
+	r.multiplicity = count.New[int]()
+	r.multiplicity.Merge(cp.Neutrons)
+	r.Fissions = r.multiplicity.Total()
+	r.Neutrons = r.Neutrons[:0]
+	for _, n := range count.SortedKeys(cp.Neutrons) {
+		for i := 0; s.KeepProducts && i < cp.Neutrons[n]; i++ {
+			r.Neutrons = append(r.Neutrons, n)
+		}
+	}
+	r.Failures = cp.Failures
+	r.Parents = count.New[string]()
+	r.Parents.Merge(cp.Parents)
+	r.Captures = count.New[string]()
+	r.Captures.Merge(cp.Captures)
+	r.Activation = isotope.Accumulator{}
+	for _, nc := range cp.Activation {
+		r.Activation.AddN(nc.Isotope(), nc.Count)
+	}
+	r.Unmatched = cp.Unmatched
+	r.Energy = cp.Energy
+	r.KineticEnergy = cp.KineticEnergy
+	r.TKE = count.New[int]()
+	r.TKE.Merge(cp.TKE)
+	for k, v := range cp.Observables {
+		r.Observables[k] = v
+	}
+	r.Batches = slices.Clone(cp.Batches)
+	r.batch = nil
+	if cp.Batch != nil {
+		r.batch = &Batch{Fissions: cp.Batch.Fissions, Symbols: maps.Clone(cp.Batch.Symbols)}
+	}
+	s.resume = cp
+	return nil
+}
+
+// takeCheckpoint returns checkpoint after done events with draws from random source.
+func (s *Simulation) takeCheckpoint(done int, draws uint64, elapsed time.Duration) *Checkpoint {
+	r := s.results
+	cp := &Checkpoint{
+		Config: Config{Isotope: s.Isotope.Name(), Events: units.Count(s.Events), BatchSize: s.BatchSize, Seed: s.Seed, RNG: s.RNG,
+			FragmentPolicy: s.FragmentPolicy, NeutronModel: s.NeutronModel, ChargeModel: s.chargeModel(), Yields: s.yields,
+			Multiplicity: s.Isotope.Multiplicity, Directions: s.Directions, Emission: s.Emission, Fuel: s.fuel(), Structures: s.structures(), Convergence: s.Convergence},
+		Done:          done,
+		Draws:         draws,
+		Failures:      r.Failures,
+		Parents:       maps.Clone(r.Parents),
+		Captures:      maps.Clone(r.Captures),
+		Unmatched:     r.Unmatched,
+		Energy:        r.Energy,
+		KineticEnergy: r.KineticEnergy,
+		TKE:           maps.Clone(r.TKE),
+		Elapsed:       elapsed,
+		Isotopes:      newNuclideCounts(&r.Tally),
+		Neutrons:      maps.Clone(r.multiplicity),
+		Observables:   maps.Clone(r.Observables),
+		Batches:       slices.Clone(r.Batches),
+	}
+	if s.Fuel != nil {
+		cp.Config.Spectrum = s.Fuel.Spectrum
+	}
+	if r.batch != nil {
+		cp.Batch = &Batch{Fissions: r.batch.Fissions, Symbols: maps.Clone(r.batch.Symbols)}
+	}
+	if r.Activation.Len() > 0 {
+		cp.Activation = newNuclideCounts(&r.Activation)
+	}
+	return cp
+}
+
+// chargeModel returns charge model of fission model of simulation, proportional for models
+// other than WahlModel.
+func (s *Simulation) chargeModel() isotope.ChargeModel {
+	if _, ok := s.Model.(isotope.WahlModel); ok {
+		return isotope.ChargeWahl
+	}
+	return isotope.ChargeProportional
+}
+
+// fuel returns nuclides of fuel of simulation, nil if it has none.
+func (s *Simulation) fuel() []Nuclide {
+	if s.Fuel == nil {
+		return nil
+	}
+	return s.Fuel.Nuclides
+}
+
+// structures returns structures of simulation, nil if it has none.
+func (s *Simulation) structures() []Structure {
+	if s.Activation == nil {
+		return nil
+	}
+	return s.Activation.Structures
+}
+
+// countingSource counts values drawn from source, so its position can be restored
+// by drawing the same number of values from source of the same seed.
+type countingSource struct {
+	src   rand.Source64
+	draws uint64
+}
+
+func newCountingSource(src rand.Source64) *countingSource {
+	return &countingSource{src: src}
+}
+
+func (c *countingSource) Int63() int64 {
+	c.draws++
+	return c.src.Int63()
+}
+
+func (c *countingSource) Uint64() uint64 {
+	c.draws++
+	return c.src.Uint64()
+}
+
+func (c *countingSource) Seed(seed int64) {
+	c.src.Seed(seed)
+	c.draws = 0
+}
+
+// skip draws n values.
+func (c *countingSource) skip(n uint64) {
+	for ; c.draws < n; c.draws++ {
+		c.src.Int63()
+	}
+}
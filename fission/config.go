@@ -0,0 +1,208 @@
+package fission
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"physics/isotope"
+	"physics/kinematics"
+	"physics/random"
+	"physics/units"
+	"physics/yield"
+)
+
+// Config describes a simulation, so that it can be stored in a file and shared.
+type Config struct {
+	// Isotope to fission, e.g. "U-235".
+	Isotope string `json:"isotope" yaml:"isotope" toml:"isotope"`
+
+	// Fuel, if it's set, replaces isotope by nuclides which absorb neutron of each event
+	// by their atoms and cross sections, so events are fissions or captures of any of them.
+	Fuel []Nuclide `json:"fuel,omitempty" yaml:"fuel,omitempty" toml:"fuel,omitempty"`
+
+	// Spectrum of neutrons absorbed in fuel, "thermal" or "fast", chooses cross sections of nuclides.
+	Spectrum isotope.Spectrum `json:"spectrum,omitempty" yaml:"spectrum,omitempty" toml:"spectrum,omitempty"`
+
+	// Structures are materials which capture fractions of neutrons released in fission,
+	// e.g. "Fe" of 0.05, activating their nuclides.
+	Structures []Structure `json:"structures,omitempty" yaml:"structures,omitempty" toml:"structures,omitempty"`
+
+	// Events is number of fissions, e.g. 10000 or "10k".
+	Events units.Count `json:"events" yaml:"events" toml:"events"`
+
+	// BatchSize is number of events in a batch, tenth of events if zero.
+	BatchSize int `json:"batch_size,omitempty" yaml:"batch_size,omitempty" toml:"batch_size,omitempty"`
+
+	// Weighting, if it's set, weights events by implicit capture or stratified masses of fragments.
+	Weighting *Weighting `json:"weighting,omitempty" yaml:"weighting,omitempty" toml:"weighting,omitempty"`
+
+	// Convergence, if it's set, stops simulation before Events once yields converged.
+	Convergence *Convergence `json:"convergence,omitempty" yaml:"convergence,omitempty" toml:"convergence,omitempty"`
+
+	// Seed of random numbers, random seed is used if zero.
+	Seed int64 `json:"seed,omitempty" yaml:"seed,omitempty" toml:"seed,omitempty"`
+
+	// RNG is engine of random numbers: "go", "pcg", "chacha8", "xoshiro" or "crypto", which
+	// isn't reproducible by seed.
+	RNG random.Engine `json:"rng,omitempty" yaml:"rng,omitempty" toml:"rng,omitempty"`
+
+	// Workers, if there are more than one, split events with seeds derived from Seed, see RunParallel.
+	Workers int `json:"workers,omitempty" yaml:"workers,omitempty" toml:"workers,omitempty"`
+
+	// FragmentPolicy handles fragments which have no equivalent isotope: "reject", "retry", "keep" or "nearest".
+	FragmentPolicy isotope.FragmentPolicy `json:"fragment_policy,omitempty" yaml:"fragment_policy,omitempty" toml:"fragment_policy,omitempty"`
+
+	// Multiplicity is relative probability of 0, 1, 2... neutrons released in fission of
+	// isotope, or of the first nuclide of fuel, evaluated distribution is used if it's empty.
+	Multiplicity isotope.Multiplicity `json:"multiplicity,omitempty" yaml:"multiplicity,omitempty" toml:"multiplicity,omitempty"`
+
+	// NeutronModel is how neutrons are drawn: "independent" of fragments or by "sawtooth" of their masses.
+	NeutronModel isotope.NeutronModel `json:"neutron_model,omitempty" yaml:"neutron_model,omitempty" toml:"neutron_model,omitempty"`
+
+	// ChargeModel is how charges of fragments are assigned: "proportional" to their masses or by "wahl" Zp systematics.
+	ChargeModel isotope.ChargeModel `json:"charge_model,omitempty" yaml:"charge_model,omitempty" toml:"charge_model,omitempty"`
+
+	// Yields is path of ENDF-6 file of independent yields, fragments of parents with yields are drawn from them.
+	Yields string `json:"yields,omitempty" yaml:"yields,omitempty" toml:"yields,omitempty"`
+
+	// Directions samples velocities of products and neutrons, with neutrons emitted
+	// "isotropic" in laboratory or in frame of "fragments" by Emission.
+	Directions bool                `json:"directions,omitempty" yaml:"directions,omitempty" toml:"directions,omitempty"`
+	Emission   kinematics.Emission `json:"emission,omitempty" yaml:"emission,omitempty" toml:"emission,omitempty"`
+
+	// Transport follows neutrons through geometry to estimate k_eff and leakage, if it's set.
+	Transport *TransportConfig `json:"transport,omitempty" yaml:"transport,omitempty" toml:"transport,omitempty"`
+
+	// Expect are expectations checked against results at the end of run, which fails if any
+	// of them isn't met.
+	Expect *Expectations `json:"expect,omitempty" yaml:"expect,omitempty" toml:"expect,omitempty"`
+
+	// KeepProducts keeps products of every fission in results, not only their counts.
+	KeepProducts bool `json:"keep_products,omitempty" yaml:"keep_products,omitempty" toml:"keep_products,omitempty"`
+}
+
+// DefaultConfig is configuration of 10000 fissions of U-235.
+func DefaultConfig() Config {
+	return Config{Isotope: "U-235", Events: 10000}
+}
+
+// LoadConfig reads JSON, YAML or TOML config file. Missing fields have default values.
+func LoadConfig(path string) (Config, error) {
+	c := DefaultConfig()
+	err := LoadFile(path, &c)
+	return c, err
+}
+
+// LoadFile decodes file into v, format is chosen by extension: .yaml, .yml, .toml or JSON otherwise.
+func LoadFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, v)
+	case ".toml":
+		err = toml.Unmarshal(data, v)
+	default:
+		err = json.Unmarshal(data, v)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// yieldModel returns model of yields of config, which must have yields of iso.
+func (c Config) yieldModel(iso *isotope.Isotope) (*yield.Model, error) {
+	if c.ChargeModel != isotope.ChargeProportional {
+		return nil, fmt.Errorf("charge model %s can't be used with yields", c.ChargeModel)
+	}
+	tables, err := yield.Open(c.Yields)
+	if err != nil {
+		return nil, err
+	}
+	energy := yield.Thermal
+	if c.Spectrum == isotope.SpectrumFast {
+		energy = yield.Fast
+	}
+	m, err := yield.NewModel(tables, energy)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", c.Yields, err)
+	}
+	if !m.Has(iso.ZA()) {
+		return nil, fmt.Errorf("%s: no independent yields of %s", c.Yields, iso.Name())
+	}
+	return m, nil
+}
+
+// Simulation creates simulation described by config.
+func (c Config) Simulation() (*Simulation, error) {
+	var fuel *Fuel
+	iso, err := isotope.Parse(c.Isotope)
+	if len(c.Fuel) > 0 {
+		if fuel, err = NewFuel(c.Fuel, c.Spectrum); err == nil {
+			iso = fuel.Isotopes[0]
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(c.Multiplicity) > 0 {
+		if err := c.Multiplicity.Validate(); err != nil {
+			return nil, err
+		}
+		iso.Multiplicity = c.Multiplicity
+	}
+	s := New(iso, int(c.Events))
+	s.results.Config = &c
+	s.Fuel = fuel
+	if len(c.Structures) > 0 {
+		if s.Activation, err = NewActivation(c.Structures); err != nil {
+			return nil, err
+		}
+	}
+	s.BatchSize = c.BatchSize
+	if c.Weighting != nil {
+		if err := c.Weighting.Validate(); err != nil {
+			return nil, err
+		}
+		s.Weighting = c.Weighting
+	}
+	if c.Convergence != nil {
+		if err := c.Convergence.Validate(); err != nil {
+			return nil, err
+		}
+		s.Convergence = c.Convergence
+	}
+	s.KeepProducts = c.KeepProducts
+	s.FragmentPolicy = c.FragmentPolicy
+	s.NeutronModel = c.NeutronModel
+	s.Model = c.ChargeModel.FissionModel()
+	if c.Yields != "" {
+		if s.Model, err = c.yieldModel(iso); err != nil {
+			return nil, err
+		}
+		s.yields = c.Yields
+	}
+	s.Directions = c.Directions
+	s.Emission = c.Emission
+	if c.Seed != 0 {
+		s.Seed = c.Seed
+	}
+	s.RNG = c.RNG
+	if c.Transport != nil {
+		// transport has its own random source, so it doesn't change fissions of the seed
+		t, err := c.Transport.transport(s.RNG.Source(s.Seed + 1))
+		if err != nil {
+			return nil, err
+		}
+		s.AttachTransport(t, c.Transport.Chains)
+	}
+	return s, nil
+}
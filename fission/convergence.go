@@ -0,0 +1,90 @@
+package fission
+
+import (
+	"errors"
+	"math"
+
+	"physics/count"
+)
+
+// Convergence stops simulation before all of its events, once probabilities of the most
+// probable elements among products converged. All criteria which are set must be met.
+type Convergence struct {
+	// Window is number of events between checks, thousand if zero.
+	Window int `json:"window,omitempty" yaml:"window,omitempty" toml:"window,omitempty"`
+
+	// Top is number of the most probable elements checked, 20 if zero.
+	Top int `json:"top,omitempty" yaml:"top,omitempty" toml:"top,omitempty"`
+
+	// Change is largest change of probability of each element over window in percent, e.g. 0.1.
+	Change float64 `json:"change,omitempty" yaml:"change,omitempty" toml:"change,omitempty"`
+
+	// RelativeError is largest relative standard error of probability of each element, e.g. 0.01.
+	RelativeError float64 `json:"relative_error,omitempty" yaml:"relative_error,omitempty" toml:"relative_error,omitempty"`
+}
+
+// Validate returns error if convergence has no criterion or invalid values.
+func (c Convergence) Validate() error {
+	switch {
+	case c.Window < 0 || c.Top < 0 || c.Change < 0 || c.RelativeError < 0:
+		return errors.New("convergence: window, top, change and relative error can't be negative")
+	case c.Change == 0 && c.RelativeError == 0:
+		return errors.New("convergence: change or relative error must be set")
+	}
+	return nil
+}
+
+func (c Convergence) window() int {
+	if c.Window > 0 {
+		return c.Window
+	}
+	return 1000
+}
+
+func (c Convergence) top() int {
+	if c.Top > 0 {
+		return c.Top
+	}
+	return 20
+}
+
+// convergence checks results against criteria every window of events.
+type convergence struct {
+	Convergence
+
+	// previous are probabilities of elements at previous check
+	previous map[string]float64
+}
+
+// converged reports whether results after events met criteria, checked every window.
+func (c *convergence) converged(r *Results, events int) bool {
+	if events%c.window() != 0 {
+		return false
+	}
+	counts := r.Tally.CountSymbols()
+	total := float64(r.Tally.Len())
+	if total == 0 {
+		return false
+	}
+	probs := make(map[string]float64, len(counts))
+	for s, n := range counts {
+		probs[s] = 100 * float64(n) / total
+	}
+
+	previous := c.previous
+	c.previous = probs
+	ok := true
+	for _, e := range count.Counter[string](counts).TopN(c.top()) {
+		s := e.Key
+		if c.Change > 0 {
+			p, seen := previous[s]
+			ok = ok && seen && math.Abs(probs[s]-p) < c.Change
+		}
+		if c.RelativeError > 0 {
+			// binomial standard error of fraction p of n products is sqrt(p(1-p)/n)
+			p := probs[s] / 100
+			ok = ok && math.Sqrt((1-p)/(p*total)) < c.RelativeError
+		}
+	}
+	return ok
+}
@@ -0,0 +1,30 @@
+package fission
+
+import (
+	"github.com/go-gota/gota/dataframe"
+	"github.com/go-gota/gota/series"
+)
+
+// ToDataFrame returns dataframe of products with a row of each nuclide, from the most common,
+// with its name, symbol, atomic and mass numbers, count and yield per fission in percent.
+func (r *Results) ToDataFrame() dataframe.DataFrame {
+	var names, symbols []string
+	var numbers, masses, counts []int
+	var yields []float64
+	for _, c := range r.Tally.Counts() {
+		names = append(names, c.Isotope.Name())
+		symbols = append(symbols, c.Isotope.Symbol)
+		numbers = append(numbers, c.Isotope.Number)
+		masses = append(masses, c.Isotope.Mass)
+		counts = append(counts, c.Count)
+		yields = append(yields, 100*float64(c.Count)/float64(max(r.Fissions, 1)))
+	}
+	return dataframe.New(
+		series.New(names, series.String, "nuclide"),
+		series.New(symbols, series.String, "symbol"),
+		series.New(numbers, series.Int, "atomic_number"),
+		series.New(masses, series.Int, "mass_number"),
+		series.New(counts, series.Int, "count"),
+		series.New(yields, series.Float, "yield"),
+	)
+}
@@ -0,0 +1,58 @@
+package fission
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// Document is results of a run as single JSON object, for callers which can't read output files.
+type Document struct {
+	Config   Config         `json:"config"`
+	Summary  Summary        `json:"summary"`
+	Symbols  map[string]int `json:"symbols"`
+	Neutrons NeutronStats   `json:"neutrons"`
+
+	// Probabilities of elements among products in percent.
+	Probabilities map[string]float64 `json:"probabilities"`
+}
+
+// NewDocument creates document of results of run of config.
+func NewDocument(c Config, r *Results) Document {
+	c.Seed = r.Seed
+	symbols := r.Tally.CountSymbols()
+	return Document{
+		Config:        c,
+		Summary:       r.Summary(),
+		Symbols:       symbols,
+		Neutrons:      r.NeutronStats(),
+		Probabilities: symbols.Probabilities().Percent(),
+	}
+}
+
+// RunJSON runs simulation of JSON config, split among its workers if it has more than one,
+// and returns JSON document of results. Missing fields of config keep values of DefaultConfig.
+// Files aren't written.
+func RunJSON(config []byte) ([]byte, error) {
+	c := DefaultConfig()
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &c); err != nil {
+			return nil, err
+		}
+	}
+	results, err := c.Run(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(NewDocument(c, results))
+}
+
+// WriteJSON writes indented json to w
+func (d Document) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(d, "", " ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,134 @@
+package fission
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"text/tabwriter"
+
+	"physics/decay/bateman"
+	"physics/isotope"
+)
+
+// Expectation is expected value of an observable with absolute tolerance.
+type Expectation struct {
+	Expected  float64 `json:"expected" yaml:"expected" toml:"expected"`
+	Tolerance float64 `json:"tolerance" yaml:"tolerance" toml:"tolerance"`
+}
+
+// Expectations are textbook values checked at the end of a run, e.g. to grade
+// student runs or to validate physics in CI.
+type Expectations struct {
+	// Nu is mean number of neutrons per fission.
+	Nu *Expectation `json:"nu,omitempty" yaml:"nu,omitempty" toml:"nu,omitempty"`
+
+	// Yields are independent yields of isotopes per fission in percent, of products as they
+	// are produced by fission, keyed by isotope name, e.g. "Cs-137".
+	Yields map[string]Expectation `json:"yields,omitempty" yaml:"yields,omitempty" toml:"yields,omitempty"`
+
+	// CumulativeYields are cumulative yields of isotopes per fission in percent, of products
+	// and of their precursors which decay to them, e.g. 6.2 of "Cs-137" of U-235.
+	CumulativeYields map[string]Expectation `json:"cumulative_yields,omitempty" yaml:"cumulative_yields,omitempty" toml:"cumulative_yields,omitempty"`
+
+	// KEff is effective multiplication factor, recorded as "k_eff" observable.
+	KEff *Expectation `json:"k_eff,omitempty" yaml:"k_eff,omitempty" toml:"k_eff,omitempty"`
+}
+
+// Check is result of checking single expectation.
+type Check struct {
+	Name      string  `json:"name" yaml:"name" toml:"name"`
+	Expected  float64 `json:"expected" yaml:"expected" toml:"expected"`
+	Tolerance float64 `json:"tolerance" yaml:"tolerance" toml:"tolerance"`
+	Observed  float64 `json:"observed" yaml:"observed" toml:"observed"`
+
+	// Missing is true if run didn't produce the observable.
+	Missing bool `json:"missing,omitempty" yaml:"missing,omitempty" toml:"missing,omitempty"`
+	Passed  bool `json:"passed" yaml:"passed" toml:"passed"`
+}
+
+// Check checks expectations against results, checks are sorted by name.
+func (e Expectations) Check(r *Results) []Check {
+	var checks []Check
+	if e.Nu != nil {
+		ns := r.NeutronStats()
+		checks = append(checks, check("nu", *e.Nu, ns.Mean, ns.Fissions > 0))
+	}
+	if e.KEff != nil {
+		k, ok := r.Observables["k_eff"]
+		checks = append(checks, check("k_eff", *e.KEff, k, ok))
+	}
+	for name, exp := range e.Yields {
+		y, ok := r.yield(name)
+		checks = append(checks, check("independent yield "+name, exp, y, ok))
+	}
+	for name, exp := range e.CumulativeYields {
+		y, ok := r.cumulativeYield(name)
+		checks = append(checks, check("cumulative yield "+name, exp, y, ok))
+	}
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Name < checks[j].Name })
+	return checks
+}
+
+// Passed reports whether all checks passed.
+func Passed(checks []Check) bool {
+	for _, c := range checks {
+		if !c.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Report writes table of checks and returns true if all of them passed.
+func Report(w io.Writer, checks []Check) (bool, error) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tEXPECTED\tOBSERVED\tRESULT")
+	for _, c := range checks {
+		observed, result := fmt.Sprintf("%.4g", c.Observed), "FAIL"
+		if c.Missing {
+			observed = "-"
+		}
+		if c.Passed {
+			result = "PASS"
+		}
+		fmt.Fprintf(tw, "%s\t%.4g ± %.2g\t%s\t%s\n", c.Name, c.Expected, c.Tolerance, observed, result)
+	}
+	if err := tw.Flush(); err != nil {
+		return false, err
+	}
+	return Passed(checks), nil
+}
+
+func check(name string, e Expectation, observed float64, ok bool) Check {
+	c := Check{Name: name, Expected: e.Expected, Tolerance: e.Tolerance, Observed: observed, Missing: !ok}
+	c.Passed = ok && math.Abs(observed-e.Expected) <= e.Tolerance
+	return c
+}
+
+// yield returns independent yield of isotope per fission in percent.
+func (r *Results) yield(name string) (float64, bool) {
+	if r.Fissions == 0 {
+		return 0, false
+	}
+	return 100 * float64(r.Tally.Count(name)) / float64(r.Fissions), true
+}
+
+// cumulativeYield returns cumulative yield of isotope per fission in percent, of products whose
+// chains of decays, see bateman.Follow, pass through it.
+func (r *Results) cumulativeYield(name string) (float64, bool) {
+	iso, err := isotope.Parse(name)
+	if r.Fissions == 0 || err != nil {
+		return 0, false
+	}
+	target := iso.ZA()
+	n := 0
+	for _, c := range r.Tally.Counts() {
+		za := c.Isotope.ZA()
+		if za.Mass == target.Mass && (za == target || slices.Contains(bateman.Follow(za).Nuclides, target)) {
+			n += c.Count
+		}
+	}
+	return 100 * float64(n) / float64(r.Fissions), true
+}
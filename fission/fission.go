@@ -0,0 +1,471 @@
+// Package fission runs Monte Carlo simulations of fission of a fissile isotope.
+package fission
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"physics/count"
+	"physics/internal/bus"
+	"physics/isotope"
+	"physics/kinematics"
+	"physics/random"
+	"physics/transport"
+	"physics/units"
+)
+
+// Event is a single fission of a nucleus.
+type Event struct {
+	// Index of event among events of simulation, which include captures and failures,
+	// or index of its record in replayed event log.
+	Index int
+
+	Parent *isotope.Isotope
+
+	// Products are shared by events of simulation and must not be modified. They are copies
+	// of isotopes table, which other simulations share.
+	Products isotope.Products
+	Neutrons int
+
+	// FragmentNeutrons are neutrons evaporated by each product, in order of products,
+	// with sawtooth neutron model. They are zero with independent model.
+	FragmentNeutrons [2]int
+
+	// KineticEnergy is kinetic energy of each product in MeV.
+	KineticEnergy [2]float64
+
+	// Velocities of products and neutrons in m/s are set if simulation samples directions.
+	// NeutronVelocities are shared between events and must not be modified.
+	Velocities        [2]kinematics.Vector
+	NeutronVelocities []kinematics.Vector
+
+	// Unmatched is what fragment policy did to produce products.
+	Unmatched isotope.Unmatched
+
+	// Weight of event in estimates of Weighted, one unless simulation has Weighting.
+	Weight float64
+}
+
+// TKE returns total kinetic energy of products in MeV.
+func (e Event) TKE() float64 {
+	return e.KineticEnergy[0] + e.KineticEnergy[1]
+}
+
+// Failure is a fission which didn't produce known isotopes.
+type Failure struct {
+	Parent *isotope.Isotope
+
+	// Err matches isotope.ErrNoMatchingFragment when a fragment is missing in isotopes table,
+	// or isotope.ErrIsotopeDataUnavailable when the table can't be loaded.
+	Err error
+
+	// Unmatched is what fragment policy did before fission failed.
+	Unmatched isotope.Unmatched
+}
+
+// Capture is neutron captured by nuclide of fuel without fission.
+type Capture struct {
+	Parent *isotope.Isotope
+
+	// Weight of capture in estimates of Weighted. Implicit capture is weight of neutron which
+	// fissions too, it's published before its event and it isn't counted in Captures.
+	Weight   float64
+	Implicit bool
+}
+
+// Activated is neutron released in fission which was captured by structural material.
+type Activated struct {
+	Material string
+
+	// Product of capture, it's shared by events of simulation like products of Event.
+	Product *isotope.Isotope
+}
+
+// BatchEnd is published after every batch of events.
+type BatchEnd struct {
+	Batch int
+}
+
+// Finished is published once simulation has ended.
+type Finished struct {
+	Results *Results
+}
+
+// Results of a simulation.
+type Results struct {
+	// Tally counts products of all fissions.
+	Tally isotope.Accumulator
+
+	// Fissions is number of fissions which produced known isotopes.
+	Fissions int
+
+	// Products and Neutrons released in every fission are kept only if simulation
+	// has KeepProducts set, otherwise they are summarized in Tally and NeutronStats.
+	Products isotope.Products
+	Neutrons []int
+
+	// Failures is number of fissions which didn't produce known isotopes.
+	Failures int
+
+	// Parents are numbers of fissions of each nuclide and Captures numbers of neutrons
+	// captured by each nuclide, by isotope name. They're tallied only if simulation has fuel.
+	Parents  count.Counter[string]
+	Captures count.Counter[string]
+
+	// Activation counts products of captures of neutrons by structural materials.
+	Activation isotope.Accumulator
+
+	// Weighted are estimates of weighted events if simulation has Weighting, while other
+	// tallies count events regardless of their weights.
+	Weighted *Weighted
+
+	// Unmatched counts fragments without equivalent isotope handled by fragment policy.
+	Unmatched isotope.Unmatched
+
+	// Observables are derived quantities recorded by subsystems, e.g. "k_eff".
+	Observables map[string]float64
+
+	// Batches are tallies of consecutive groups of events, used to estimate uncertainties.
+	Batches []Batch
+
+	// Seed of simulation which produced results.
+	Seed int64
+
+	// Config is config of simulation if it was created by Config.Simulation, its events
+	// are those of a partial run if simulation stopped.
+	Config *Config
+
+	// Parent is fissioned isotope, the first nuclide of fuel if simulation has fuel.
+	Parent *isotope.Isotope
+
+	// Energy is total energy released in fissions in MeV.
+	Energy float64
+
+	// KineticEnergy is total kinetic energy of products of all fissions in MeV,
+	// TKE is histogram of number of fissions by their TKE in whole MeV.
+	KineticEnergy float64
+	TKE           count.Counter[int]
+
+	// Elapsed is wall time of simulation.
+	Elapsed time.Duration
+
+	// Converged is true if simulation stopped early because yields converged.
+	Converged bool
+
+	batch        *Batch // batch being tallied
+	multiplicity count.Counter[int]
+	keep         bool
+	parents      bool // tally Parents
+}
+
+// Batch is tally of a group of events.
+type Batch struct {
+	Fissions int
+	Symbols  map[string]int
+}
+
+// Simulation of fissions of an isotope, or of nuclides of fuel. Subsystems communicate through the bus,
+// so they can be added without changing the simulation loop.
+type Simulation struct {
+	Isotope *isotope.Isotope
+
+	// Events is number of fissions to simulate, or of neutrons absorbed by fuel.
+	Events int
+
+	// Fuel, if it's set, draws nuclide which absorbs neutron of every event, which
+	// fissions or captures it. Isotope must be the first nuclide of fuel.
+	Fuel *Fuel
+
+	// Activation, if it's set, draws structural material which captures each neutron
+	// released in fission, if any.
+	Activation *Activation
+
+	// BatchSize is number of events in a batch, tenth of events or window of convergence if zero.
+	BatchSize int
+
+	// Convergence, if it's set, stops simulation once yields converged, Events is then the
+	// largest number of events.
+	Convergence *Convergence
+
+	// Weighting, if it's set, weights events to reduce variance of estimates of Weighted.
+	Weighting *Weighting
+
+	// Seed of random numbers, simulations with the same seed produce the same results.
+	Seed int64
+
+	// RNG is engine of random numbers, of math/rand by default.
+	RNG random.Engine
+
+	// FragmentPolicy handles fragments which have no equivalent isotope, they are rejected by default.
+	FragmentPolicy isotope.FragmentPolicy
+
+	// NeutronModel is how neutrons are drawn, independently of fragments by default.
+	NeutronModel isotope.NeutronModel
+
+	// Model of physics of fission, isotope.SimpleModel if it's nil.
+	Model isotope.FissionModel
+
+	// Directions samples velocities of products and neutrons of events, with neutrons
+	// emitted by Emission.
+	Directions bool
+	Emission   kinematics.Emission
+
+	// KeepProducts keeps products and neutrons of every fission in Results, which needs
+	// memory proportional to number of events.
+	KeepProducts bool
+
+	bus       *bus.Bus
+	results   *Results
+	transport *transport.Transport
+	yields    string // path of yields of Model
+
+	// nuclides are copies of isotopes of isotopes table which are published in events
+	nuclides map[isotope.ZA]*isotope.Isotope
+
+	checkpointEvery int
+	checkpoint      func(*Checkpoint)
+	resume          *Checkpoint
+}
+
+// New creates simulation of events fissions of isotope, tallying products and neutrons to Results.
+func New(iso *isotope.Isotope, events int) *Simulation {
+	s := &Simulation{
+		Isotope:  iso,
+		Events:   events,
+		Seed:     time.Now().UnixNano(),
+		bus:      bus.New(),
+		nuclides: make(map[isotope.ZA]*isotope.Isotope),
+		results: &Results{Observables: make(map[string]float64), TKE: count.New[int](), multiplicity: count.New[int](),
+			Parents: count.New[string](), Captures: count.New[string]()},
+	}
+	bus.Subscribe(s.bus, s.results.tally)
+	bus.Subscribe(s.bus, s.results.fail)
+	bus.Subscribe(s.bus, s.results.capture)
+	bus.Subscribe(s.bus, s.results.activate)
+	bus.Subscribe(s.bus, s.results.endBatch)
+	return s
+}
+
+// Bus returns bus through which simulation publishes Event, Failure, Capture, Activated, BatchEnd, Progress and Finished messages.
+func (s *Simulation) Bus() *bus.Bus {
+	return s.bus
+}
+
+// Run simulates events and returns results. When ctx is cancelled or its deadline passes,
+// simulation stops, results of events simulated so far are finished as usual and returned
+// with error of ctx.
+func (s *Simulation) Run(ctx context.Context) (*Results, error) {
+	start := time.Now()
+	s.results.Seed = s.Seed
+	s.results.Parent = s.Isotope
+	s.results.keep = s.KeepProducts
+	s.results.parents = s.Fuel != nil
+	src := newCountingSource(s.RNG.Source(s.Seed))
+	rng := rand.New(src)
+	i := 0
+	if cp := s.resume; cp != nil {
+		src.skip(cp.Draws)
+		i = cp.Done
+		start = start.Add(-cp.Elapsed)
+	}
+
+	var conv *convergence
+	if s.Convergence != nil {
+		conv = &convergence{Convergence: *s.Convergence}
+	}
+	size := s.batchSize()
+	step := s.progressStep()
+	done := ctx.Done()
+	var err error
+	var slab isotope.Products
+	var velocities []kinematics.Vector
+	opts := isotope.Options{Fragments: s.FragmentPolicy, Neutrons: s.NeutronModel, Model: s.Model, KineticEnergy: true}
+	implicit := false
+	if w := s.Weighting; w != nil {
+		s.attachWeighted()
+		opts.Strata = w.MassBins
+		implicit = w.ImplicitCapture && s.Fuel != nil
+	}
+	for ; i < s.Events; i++ {
+		select {
+		case <-done:
+			err = ctx.Err()
+		default:
+		}
+		if err != nil {
+			break
+		}
+
+		parent, captured, weight := s.Isotope, false, 1.0
+		switch {
+		case implicit:
+			k, p := s.Fuel.AbsorbImplicit(rng)
+			parent, captured, weight = s.Fuel.Isotopes[k], p == 0, p
+			if !captured && p < 1 {
+				bus.Publish(s.bus, Capture{Parent: parent, Weight: 1 - p, Implicit: true})
+			}
+		case s.Fuel != nil:
+			k, fissions := s.Fuel.Absorb(rng)
+			parent, captured = s.Fuel.Isotopes[k], !fissions
+		}
+		if captured {
+			bus.Publish(s.bus, Capture{Parent: parent, Weight: 1})
+		} else {
+			opts.Stratum = i
+			slab, velocities = s.fission(rng, i, parent, weight, opts, slab, velocities)
+		}
+
+		if (i+1)%size == 0 || i == s.Events-1 {
+			bus.Publish(s.bus, BatchEnd{Batch: i / size})
+		}
+		if (i+1)%step == 0 || i == s.Events-1 {
+			bus.Publish(s.bus, Progress{Done: i + 1, Total: s.Events, Elapsed: time.Since(start)})
+		}
+		if s.checkpoint != nil && s.checkpointEvery > 0 && (i+1)%s.checkpointEvery == 0 {
+			s.checkpoint(s.takeCheckpoint(i+1, src.draws, time.Since(start)))
+		}
+		if conv != nil && i < s.Events-1 && conv.converged(s.results, i+1) {
+			s.results.Converged = true
+			i++
+			break
+		}
+	}
+	if err != nil && s.checkpoint != nil && s.checkpointEvery > 0 {
+		s.checkpoint(s.takeCheckpoint(i, src.draws, time.Since(start)))
+	}
+	if (err != nil || s.results.Converged) && i > 0 {
+		// flush partial batch and report where simulation stopped
+		if i%size != 0 {
+			bus.Publish(s.bus, BatchEnd{Batch: i / size})
+		}
+		if i%step != 0 {
+			bus.Publish(s.bus, Progress{Done: i, Total: s.Events, Elapsed: time.Since(start)})
+		}
+	}
+	if err != nil && s.results.Config != nil {
+		// partial run is reproduced by simulating only the events done
+		s.results.Config.Events = units.Count(i)
+	}
+	s.results.Elapsed = time.Since(start)
+	if w := s.results.Weighted; w != nil {
+		w.record(s.results.Observables)
+	}
+	bus.Publish(s.bus, Finished{Results: s.results})
+	return s.results, err
+}
+
+// slabSize is capacity of products shared by events.
+const slabSize = 2048
+
+// fission fissions parent of weight in event i and publishes its event or failure. Products of many events share
+// slab and velocities of their neutrons share velocities, so there is one allocation per
+// slabSize events, fission returns them with space taken by event.
+func (s *Simulation) fission(rng random.Rand, i int, parent *isotope.Isotope, weight float64, opts isotope.Options, slab isotope.Products, velocities []kinematics.Vector) (isotope.Products, []kinematics.Vector) {
+	if cap(slab)-len(slab) < 2 {
+		slab = make(isotope.Products, 0, slabSize)
+	}
+	f, err := parent.DestabilizeAppend(slab[len(slab):], rng, opts)
+	prods := f.Products[:len(f.Products):len(f.Products)]
+	slab = slab[:len(slab)+len(prods)]
+	if err != nil {
+		bus.Publish(s.bus, Failure{Parent: parent, Err: err, Unmatched: f.Unmatched})
+		return slab, velocities
+	}
+	for k, p := range prods {
+		prods[k] = s.nuclide(p)
+	}
+	e := Event{Index: i, Parent: parent, Products: prods, Neutrons: f.Neutrons,
+		FragmentNeutrons: f.FragmentNeutrons, KineticEnergy: f.KineticEnergy, Unmatched: f.Unmatched, Weight: weight * f.Weight}
+	if s.Directions {
+		if cap(velocities)-len(velocities) < f.Neutrons {
+			velocities = make([]kinematics.Vector, 0, max(slabSize, f.Neutrons))
+		}
+		var vs []kinematics.Vector
+		e.Velocities, vs = s.velocities(rng, f, velocities[len(velocities):])
+		e.NeutronVelocities = vs[:len(vs):len(vs)]
+		velocities = velocities[:len(velocities)+len(vs)]
+	}
+	bus.Publish(s.bus, e)
+	if s.Activation != nil {
+		for range e.Neutrons {
+			if i, p, ok := s.Activation.Capture(rng); ok {
+				bus.Publish(s.bus, Activated{Material: s.Activation.Structures[i].Material, Product: s.nuclide(p)})
+			}
+		}
+	}
+	return slab, velocities
+}
+
+// nuclide returns copy of isotope which simulation publishes instead of iso, so subscribers
+// can't modify isotopes table. Isotopes of the same nuclide share copy.
+func (s *Simulation) nuclide(iso *isotope.Isotope) *isotope.Isotope {
+	c, ok := s.nuclides[iso.ZA()]
+	if !ok {
+		cp := *iso
+		c = &cp
+		s.nuclides[iso.ZA()] = c
+	}
+	return c
+}
+
+func (s *Simulation) batchSize() int {
+	if s.BatchSize > 0 {
+		return s.BatchSize
+	}
+	if s.Convergence != nil {
+		return s.Convergence.window()
+	}
+	if s.Events < 10 {
+		return 1
+	}
+	return s.Events / 10
+}
+
+func (r *Results) tally(e Event) {
+	r.Tally.Add(e.Products...)
+	r.Unmatched.Add(e.Unmatched)
+	r.Fissions++
+	r.multiplicity.Add(e.Neutrons, 1)
+	if r.parents {
+		r.Parents.Add(e.Parent.Name(), 1)
+	}
+	if r.keep {
+		r.Products = append(r.Products, e.Products...)
+		r.Neutrons = append(r.Neutrons, e.Neutrons)
+	}
+	r.Energy += isotope.QValue(e.Parent, e.Products)
+	tke := e.TKE()
+	r.KineticEnergy += tke
+	r.TKE.Add(int(tke), 1)
+
+	if r.batch == nil {
+		r.batch = &Batch{Symbols: make(map[string]int)}
+	}
+	r.batch.Fissions++
+	for _, p := range e.Products {
+		r.batch.Symbols[p.Symbol]++
+	}
+}
+
+func (r *Results) endBatch(BatchEnd) {
+	if r.batch != nil {
+		r.Batches = append(r.Batches, *r.batch)
+		r.batch = nil
+	}
+}
+
+func (r *Results) fail(f Failure) {
+	r.Failures++
+	r.Unmatched.Add(f.Unmatched)
+}
+
+func (r *Results) capture(c Capture) {
+	if !c.Implicit {
+		r.Captures.Add(c.Parent.Name(), 1)
+	}
+}
+
+func (r *Results) activate(a Activated) {
+	r.Activation.Add(a.Product)
+}
@@ -0,0 +1,21 @@
+package fission
+
+import (
+	"context"
+	"testing"
+
+	"physics/units"
+)
+
+// BenchmarkSimulationRun reports time and allocations per event.
+func BenchmarkSimulationRun(b *testing.B) {
+	sim, err := Config{Isotope: "U-235", Events: units.Count(b.N), Seed: 1}.Simulation()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	if _, err := sim.Run(context.Background()); err != nil {
+		b.Fatal(err)
+	}
+}
@@ -0,0 +1,125 @@
+package fission
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"physics/isotope"
+	"physics/random"
+)
+
+// Nuclide is isotope of fuel with its relative number of atoms.
+type Nuclide struct {
+	Isotope string  `json:"isotope" yaml:"isotope" toml:"isotope"`
+	Atoms   float64 `json:"atoms" yaml:"atoms" toml:"atoms"`
+
+	// CrossSections replace evaluated cross sections of isotope, they're needed for
+	// isotopes which have none.
+	CrossSections *isotope.CrossSections `json:"cross_sections,omitempty" yaml:"cross_sections,omitempty" toml:"cross_sections,omitempty"`
+}
+
+// ParseFuel parses comma separated isotopes with relative numbers of atoms, e.g.
+// "U-235:0.03,U-238:0.97". Isotope without number has one atom.
+func ParseFuel(s string) ([]Nuclide, error) {
+	var nuclides []Nuclide
+	for _, f := range strings.Split(s, ",") {
+		name, atoms, ok := strings.Cut(strings.TrimSpace(f), ":")
+		if name == "" {
+			continue
+		}
+		n := Nuclide{Isotope: name, Atoms: 1}
+		if ok {
+			v, err := strconv.ParseFloat(strings.TrimSpace(atoms), 64)
+			if err != nil {
+				return nil, fmt.Errorf("fuel %q: invalid atoms %q", s, atoms)
+			}
+			n.Atoms = v
+		}
+		nuclides = append(nuclides, n)
+	}
+	return nuclides, nil
+}
+
+// Fuel is mixture of nuclides, which absorb neutrons in proportion to their atoms and
+// absorption cross sections.
+type Fuel struct {
+	Nuclides      []Nuclide
+	Spectrum      isotope.Spectrum
+	Isotopes      []*isotope.Isotope
+	CrossSections []isotope.CrossSections
+
+	// rates are cumulative rates of fission and capture of each nuclide, in this order
+	rates []float64
+}
+
+// NewFuel creates fuel of nuclides, with evaluated cross sections of spectrum.
+func NewFuel(nuclides []Nuclide, spectrum isotope.Spectrum) (*Fuel, error) {
+	if len(nuclides) == 0 {
+		return nil, errors.New("fuel: no nuclides")
+	}
+	f := &Fuel{Nuclides: nuclides, Spectrum: spectrum}
+	total := 0.0
+	for _, n := range nuclides {
+		iso, err := isotope.Parse(n.Isotope)
+		if err != nil {
+			return nil, fmt.Errorf("fuel: %w", err)
+		}
+		cs, ok := iso.CrossSections(spectrum)
+		if n.CrossSections != nil {
+			cs, ok = *n.CrossSections, true
+		}
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("fuel: %s has no cross sections", iso.Name())
+		case n.Atoms < 0 || cs.Fission < 0 || cs.Capture < 0:
+			return nil, fmt.Errorf("fuel: %s has negative atoms or cross sections", iso.Name())
+		}
+		f.Isotopes = append(f.Isotopes, iso)
+		f.CrossSections = append(f.CrossSections, cs)
+		total += n.Atoms * cs.Fission
+		f.rates = append(f.rates, total)
+		total += n.Atoms * cs.Capture
+		f.rates = append(f.rates, total)
+	}
+	if total == 0 {
+		return nil, errors.New("fuel: no nuclide absorbs neutrons")
+	}
+	return f, nil
+}
+
+// Absorb draws index of nuclide which absorbs neutron and whether it fissions.
+func (f *Fuel) Absorb(rng random.Rand) (int, bool) {
+	u := rng.Float64() * f.rates[len(f.rates)-1]
+	for i, r := range f.rates {
+		if u < r {
+			return i / 2, i%2 == 0
+		}
+	}
+	// rounding of u can leave it at the total, which is absorbed by the last absorbing nuclide
+	for i := len(f.rates) - 1; i > 0; i-- {
+		if f.rates[i] > f.rates[i-1] {
+			return i / 2, i%2 == 0
+		}
+	}
+	return 0, true
+}
+
+// Absorption returns fraction of neutrons absorbed by nuclide i.
+func (f *Fuel) Absorption(i int) float64 {
+	lo := 0.0
+	if i > 0 {
+		lo = f.rates[2*i-1]
+	}
+	return (f.rates[2*i+1] - lo) / f.rates[len(f.rates)-1]
+}
+
+// FissionFraction returns fraction of absorbed neutrons which fission nuclide i.
+func (f *Fuel) FissionFraction(i int) float64 {
+	lo := 0.0
+	if i > 0 {
+		lo = f.rates[2*i-1]
+	}
+	return (f.rates[2*i] - lo) / f.rates[len(f.rates)-1]
+}
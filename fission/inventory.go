@@ -0,0 +1,30 @@
+package fission
+
+import (
+	"physics/decay"
+	"physics/decay/bateman"
+	"physics/isotope"
+)
+
+// Atoms returns numbers of atoms of products of fissions and of activation of structures,
+// scaled to given number of fissions, to fissions of simulation if it isn't positive.
+func (r *Results) Atoms(fissions float64) map[isotope.ZA]float64 {
+	scale := 1.0
+	if fissions > 0 && r.Fissions > 0 {
+		scale = fissions / float64(r.Fissions)
+	}
+	atoms := make(map[isotope.ZA]float64)
+	for _, a := range []*isotope.Accumulator{&r.Tally, &r.Activation} {
+		for _, c := range a.Counts() {
+			atoms[c.Isotope.ZA()] += scale * float64(c.Count)
+		}
+	}
+	return atoms
+}
+
+// Inventory returns radionuclides of Atoms of fissions cooling seconds after end of
+// simulation, as if its fissions happened at once. Products decay by their chains, so
+// short lived precursors already decayed at end of simulation.
+func (r *Results) Inventory(fissions, cooling float64) *decay.Inventory {
+	return decay.NewInventory(bateman.Inventory(r.Atoms(fissions), cooling))
+}
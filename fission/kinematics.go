@@ -0,0 +1,40 @@
+package fission
+
+import (
+	"physics/isotope"
+	"physics/kinematics"
+	"physics/random"
+)
+
+// velocities samples velocities of products of fission f, which fly apart back to back, and
+// appends velocities of its neutrons to vs.
+func (s *Simulation) velocities(rng random.Rand, f isotope.Fission, vs []kinematics.Vector) ([2]kinematics.Vector, []kinematics.Vector) {
+	var products [2]kinematics.Vector
+	u := kinematics.Isotropic(rng)
+	for i, p := range f.Products {
+		mass := float64(p.Mass) * kinematics.AtomicMassUnit
+		if p.AtomicMass > 0 {
+			mass = p.AtomicMass * kinematics.AtomicMassUnit
+		}
+		products[i] = u.Scale(kinematics.Speed(f.KineticEnergy[i], mass))
+		u = u.Scale(-1)
+	}
+
+	// neutrons evaporated by the first and the second fragment come first,
+	// then neutrons which aren't attributed to a fragment
+	first, second := f.FragmentNeutrons[0], f.FragmentNeutrons[0]+f.FragmentNeutrons[1]
+	for i := 0; i < f.Neutrons; i++ {
+		e := kinematics.Maxwell(rng, kinematics.NeutronTemperature)
+		v := kinematics.Isotropic(rng).Scale(kinematics.Speed(e, kinematics.NeutronMass))
+		if s.Emission == kinematics.EmissionFragments {
+			switch {
+			case i < first:
+				v = v.Add(products[0])
+			case i < second:
+				v = v.Add(products[1])
+			}
+		}
+		vs = append(vs, v)
+	}
+	return products, vs
+}
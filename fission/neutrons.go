@@ -0,0 +1,96 @@
+package fission
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+
+	"physics/count"
+	"physics/isotope"
+)
+
+// NeutronStats is distribution of number of neutrons released per fission.
+type NeutronStats struct {
+	// Histogram maps multiplicity to number of fissions.
+	Histogram map[int]int `json:"histogram" yaml:"histogram"`
+
+	Fissions int     `json:"fissions" yaml:"fissions"`
+	Mean     float64 `json:"mean" yaml:"mean"`
+	Variance float64 `json:"variance" yaml:"variance"`
+}
+
+// NewNeutronStats returns statistics of neutrons released in each fission.
+func NewNeutronStats(neutrons []int) NeutronStats {
+	h := make(map[int]int)
+	for _, n := range neutrons {
+		h[n]++
+	}
+	return HistogramStats(h)
+}
+
+// HistogramStats returns statistics of histogram of multiplicity to number of fissions.
+func HistogramStats(h map[int]int) NeutronStats {
+	ns := NeutronStats{Histogram: h}
+	sum := 0
+	for n, c := range h {
+		ns.Fissions += c
+		sum += n * c
+	}
+	if ns.Fissions == 0 {
+		return ns
+	}
+	ns.Mean = float64(sum) / float64(ns.Fissions)
+	// summed in order of multiplicity, so variance doesn't depend on map order
+	for _, n := range ns.Multiplicities() {
+		d := float64(n) - ns.Mean
+		ns.Variance += float64(h[n]) * d * d
+	}
+	ns.Variance /= float64(ns.Fissions)
+	return ns
+}
+
+// NeutronStats returns statistics of neutrons released in successful fissions.
+func (r *Results) NeutronStats() NeutronStats {
+	return HistogramStats(maps.Clone(r.multiplicity))
+}
+
+// Probability returns fraction of fissions which released n neutrons.
+func (ns NeutronStats) Probability(n int) float64 {
+	if ns.Fissions == 0 {
+		return 0
+	}
+	return float64(ns.Histogram[n]) / float64(ns.Fissions)
+}
+
+// Multiplicities returns multiplicities which occured, in ascending order.
+func (ns NeutronStats) Multiplicities() []int {
+	return count.SortedKeys(ns.Histogram)
+}
+
+// Saves to .json file
+func (ns NeutronStats) SaveJson(out isotope.OutputConfig) error {
+	return out.Save("neutrons.json", ns.WriteJSON)
+}
+
+// WriteJSON writes indented json to w
+func (ns NeutronStats) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(ns, "", " ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Plot returns data of bar chart of multiplicity histogram.
+func (ns NeutronStats) Plot(opts isotope.ChartOptions) isotope.Plot {
+	p := isotope.Plot{Title: fmt.Sprintf("Neutron multiplicity (mean %.3f)", ns.Mean), XLabel: "neutrons", YLabel: "fissions"}
+	if opts.Title != "" {
+		p.Title = opts.Title
+	}
+	for _, m := range ns.Multiplicities() {
+		p.Values = append(p.Values, isotope.ChartValue{Label: fmt.Sprint(m), Value: float64(ns.Histogram[m])})
+	}
+	return p
+}
@@ -0,0 +1,28 @@
+package fission
+
+import "physics/internal/bus"
+
+// Observer is notified of every event of simulation and of tally of every batch, e.g. for
+// custom tallies, live metrics or sinks.
+type Observer interface {
+	OnEvent(Event)
+
+	// OnBatchEnd is called with tally of events of batch observed since previous batch.
+	OnBatchEnd(Batch)
+}
+
+// Observe registers observer of simulation, it's notified in order of registration.
+func (s *Simulation) Observe(o Observer) {
+	batch := Batch{Symbols: make(map[string]int)}
+	bus.Subscribe(s.bus, func(e Event) {
+		batch.Fissions++
+		for _, p := range e.Products {
+			batch.Symbols[p.Symbol]++
+		}
+		o.OnEvent(e)
+	})
+	bus.Subscribe(s.bus, func(BatchEnd) {
+		o.OnBatchEnd(batch)
+		batch = Batch{Symbols: make(map[string]int)}
+	})
+}
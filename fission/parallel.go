@@ -0,0 +1,105 @@
+package fission
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"physics/random"
+	"physics/units"
+)
+
+// RunParallel runs simulation of config split among its workers, each simulating its share of
+// events with its own seed derived from seed of config by random.Derive, and merges their
+// results in order of workers, so results of the same seed and workers don't depend on
+// scheduling. Results have seed of config, random if it's zero. Transport, weighting and
+// convergence need all events of one simulation, so they aren't supported. When ctx is
+// cancelled, results of events done are returned with its error.
+func RunParallel(ctx context.Context, c Config) (*Results, error) {
+	switch {
+	case c.Transport != nil:
+		return nil, errors.New("parallel run can't follow neutrons through transport")
+	case c.Weighting != nil:
+		return nil, errors.New("parallel run can't weight events")
+	case c.Convergence != nil:
+		return nil, errors.New("parallel run can't stop once yields converge")
+	}
+	workers := max(1, min(c.Workers, int(c.Events)))
+	seed := c.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	start := time.Now()
+	results := make([]*Results, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wc := c
+		wc.Workers = 0
+		wc.Events = c.Events / units.Count(workers)
+		if i < int(c.Events)%workers {
+			wc.Events++
+		}
+		sim, err := wc.Simulation()
+		if err != nil {
+			return nil, err
+		}
+		sim.Seed = random.Derive(seed, i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = sim.Run(ctx)
+		}()
+	}
+	wg.Wait()
+
+	r := results[0]
+	for _, w := range results[1:] {
+		r.merge(w)
+	}
+	err := errors.Join(errs...)
+	if err != nil {
+		c.Events = units.Count(r.Summary().Events)
+	}
+	r.Seed = seed
+	r.Config = &c
+	r.Elapsed = time.Since(start)
+	return r, err
+}
+
+// Run runs simulation of config, split among its workers by RunParallel if there are more
+// than one.
+func (c Config) Run(ctx context.Context) (*Results, error) {
+	if c.Workers > 1 {
+		return RunParallel(ctx, c)
+	}
+	sim, err := c.Simulation()
+	if err != nil {
+		return nil, err
+	}
+	return sim.Run(ctx)
+}
+
+// merge adds tallies of results of other simulation of the same config.
+func (r *Results) merge(o *Results) {
+	for _, c := range o.Tally.Counts() {
+		r.Tally.AddN(c.Isotope, c.Count)
+	}
+	for _, c := range o.Activation.Counts() {
+		r.Activation.AddN(c.Isotope, c.Count)
+	}
+	r.Fissions += o.Fissions
+	r.Products = append(r.Products, o.Products...)
+	r.Neutrons = append(r.Neutrons, o.Neutrons...)
+	r.Failures += o.Failures
+	r.Parents.Merge(o.Parents)
+	r.Captures.Merge(o.Captures)
+	r.Unmatched.Add(o.Unmatched)
+	r.Batches = append(r.Batches, o.Batches...)
+	r.Energy += o.Energy
+	r.KineticEnergy += o.KineticEnergy
+	r.TKE.Merge(o.TKE)
+	r.multiplicity.Merge(o.multiplicity)
+}
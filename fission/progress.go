@@ -0,0 +1,49 @@
+package fission
+
+import (
+	"time"
+
+	"physics/internal/bus"
+)
+
+// Progress is published after every hundredth of events.
+type Progress struct {
+	Done  int
+	Total int
+
+	// Elapsed is wall time since simulation started.
+	Elapsed time.Duration
+}
+
+// Fraction returns fraction of events done.
+func (p Progress) Fraction() float64 {
+	if p.Total == 0 {
+		return 1
+	}
+	return float64(p.Done) / float64(p.Total)
+}
+
+// Rate returns number of events per second.
+func (p Progress) Rate() float64 {
+	if p.Elapsed <= 0 {
+		return 0
+	}
+	return float64(p.Done) / p.Elapsed.Seconds()
+}
+
+// Remaining estimates wall time until simulation ends.
+func (p Progress) Remaining() time.Duration {
+	if p.Done == 0 {
+		return 0
+	}
+	return time.Duration(float64(p.Elapsed) * float64(p.Total-p.Done) / float64(p.Done))
+}
+
+// OnProgress calls f with progress of simulation while it runs.
+func (s *Simulation) OnProgress(f func(Progress)) {
+	bus.Subscribe(s.bus, f)
+}
+
+func (s *Simulation) progressStep() int {
+	return max(s.Events/100, 1)
+}
@@ -0,0 +1,130 @@
+package fission
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"physics/isotope"
+	"physics/scenario"
+	"physics/transport"
+)
+
+// Reaction creates chain reaction in transport of config, infinite fast uranium if config
+// has none, of fissions of isotope of config generation time apart. It starts with fissions
+// fissions and follows at most that many in a generation. Doppler feedback of transport
+// changes U-238 capture of its material.
+func (c Config) Reaction(fissions int, generationTime time.Duration) (*transport.Reaction, error) {
+	s, err := c.Simulation()
+	if err != nil {
+		return nil, err
+	}
+	tc := TransportConfig{}
+	if c.Transport != nil {
+		tc = *c.Transport
+	}
+	t, err := tc.transport(s.RNG.Source(s.Seed + 1))
+	if err != nil {
+		return nil, err
+	}
+	r, err := t.NewReaction(fissions, s.Isotope.NeutronMultiplicity().Sample, generationTime)
+	if err != nil || tc.Doppler == nil {
+		return r, err
+	}
+	if tc.Medium != nil {
+		return nil, fmt.Errorf("transport: doppler feedback needs material, not medium")
+	}
+	m := transport.Uranium(0.94)
+	if tc.Material != nil {
+		m = *tc.Material
+	}
+	if err := r.AttachDoppler(*tc.Doppler, m.Partial(transport.U238.Name).Capture); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Population is neutron population of chain reaction over generations.
+type Population struct {
+	Generations []transport.Generation `json:"generations" yaml:"generations"`
+
+	// Flux is track length per neutron in cm and Rates are reactions per neutron of medium.
+	Flux  transport.Estimate `json:"flux" yaml:"flux"`
+	Rates []transport.Rate   `json:"rates" yaml:"rates"`
+}
+
+// RunReaction runs chain reaction r for duration with actions of script.
+func RunReaction(r *transport.Reaction, script scenario.Script, duration time.Duration) (*Population, error) {
+	if err := script.Check(r); err != nil {
+		return nil, err
+	}
+	p := &Population{Generations: r.Run(script, duration)}
+	p.Flux, p.Rates = r.Flux.Flux(), r.Flux.Rates()
+	return p, nil
+}
+
+// Saves to .json file
+func (p *Population) SaveJson(out isotope.OutputConfig) error {
+	return out.Save("population.json", p.WriteJSON)
+}
+
+// WriteJSON writes indented json to w
+func (p *Population) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(p, "", " ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Saves to .yaml file
+func (p *Population) SaveYAML(out isotope.OutputConfig) error {
+	return out.Save("population.yaml", p.WriteYAML)
+}
+
+// WriteYAML writes yaml to w
+func (p *Population) WriteYAML(w io.Writer) error {
+	return isotope.WriteYAML(w, p)
+}
+
+// Saves to .csv file
+func (p *Population) SaveCSV(out isotope.OutputConfig) error {
+	return out.Save("population.csv", p.WriteCSV)
+}
+
+// WriteCSV writes a row of each generation to w
+func (p *Population) WriteCSV(w io.Writer) error {
+	format := func(v float64) string { return strconv.FormatFloat(v, 'g', -1, 64) }
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"generation", "time", "population", "multiplication", "reactivity", "absorber", "temperature"})
+	for _, g := range p.Generations {
+		cw.Write([]string{
+			strconv.Itoa(g.Generation),
+			format(g.Time),
+			format(g.Population),
+			format(g.Multiplication),
+			format(g.Reactivity),
+			format(g.Absorber),
+			format(g.Temperature),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Save saves population in format
+func (p *Population) Save(out isotope.OutputConfig, format isotope.Format) error {
+	switch format {
+	case isotope.JSON:
+		return p.SaveJson(out)
+	case isotope.YAML:
+		return p.SaveYAML(out)
+	case isotope.CSV:
+		return p.SaveCSV(out)
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
@@ -0,0 +1,201 @@
+package fission
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"physics/isotope"
+)
+
+// chainYield is cumulative yield in percent per fission of mass chain of thermal fission of
+// U-235 and atomic number of its stable end nuclide, which chain decays to.
+type chainYield struct {
+	Mass, Number int
+	Yield        float64
+}
+
+// chainYields are cumulative chain yields of thermal fission of U-235 of ENDF/B-VII.1, rounded.
+var chainYields = []chainYield{
+	{77, 34, 0.0083}, {78, 34, 0.021}, {79, 35, 0.045}, {80, 34, 0.128}, {81, 35, 0.2},
+	{82, 34, 0.33}, {83, 36, 0.536}, {84, 36, 1}, {85, 37, 1.32}, {86, 36, 1.96},
+	{87, 37, 2.56}, {88, 38, 3.58}, {89, 39, 4.75}, {90, 40, 5.78}, {91, 40, 5.83},
+	{92, 40, 5.99}, {93, 41, 6.37}, {94, 40, 6.43}, {95, 42, 6.5}, {96, 40, 6.28},
+	{97, 42, 5.99}, {98, 42, 5.78}, {99, 44, 6.11}, {100, 42, 6.29}, {101, 44, 5.06},
+	{102, 44, 4.29}, {103, 45, 3.03}, {104, 44, 1.88}, {105, 46, 0.963}, {106, 46, 0.402},
+	{107, 47, 0.146}, {108, 46, 0.0535}, {109, 47, 0.0314}, {110, 46, 0.0252}, {111, 48, 0.018},
+	{112, 48, 0.0129}, {113, 48, 0.0143}, {114, 48, 0.0118}, {115, 49, 0.0108}, {116, 48, 0.0118},
+	{117, 50, 0.0116}, {118, 50, 0.0118}, {119, 50, 0.0126}, {120, 50, 0.0131}, {121, 51, 0.013},
+	{122, 50, 0.0156}, {123, 51, 0.0158}, {124, 50, 0.0226}, {125, 52, 0.034}, {126, 52, 0.0594},
+	{127, 53, 0.157}, {128, 52, 0.35}, {129, 54, 0.706}, {130, 52, 1.81}, {131, 54, 2.89},
+	{132, 54, 4.31}, {133, 55, 6.7}, {134, 54, 7.87}, {135, 56, 6.54}, {136, 54, 6.31},
+	{137, 56, 6.19}, {138, 56, 6.77}, {139, 57, 6.41}, {140, 58, 6.22}, {141, 59, 5.85},
+	{142, 58, 5.85}, {143, 60, 5.96}, {144, 60, 5.5}, {145, 60, 3.93}, {146, 60, 2.99},
+	{147, 62, 2.25}, {148, 60, 1.67}, {149, 62, 1.08}, {150, 60, 0.653}, {151, 63, 0.419},
+	{152, 62, 0.267}, {153, 63, 0.158}, {154, 62, 0.0739}, {155, 64, 0.0321}, {156, 64, 0.0148},
+	{157, 64, 0.00616}, {158, 64, 0.00328},
+}
+
+// elementSymbols are symbols of elements from germanium, by atomic number.
+var elementSymbols = []string{"Ge", "As", "Se", "Br", "Kr", "Rb", "Sr", "Y", "Zr", "Nb", "Mo", "Tc", "Ru", "Rh",
+	"Pd", "Ag", "Cd", "In", "Sn", "Sb", "Te", "I", "Xe", "Cs", "Ba", "La", "Ce", "Pr", "Nd", "Pm", "Sm", "Eu", "Gd"}
+
+// ErrNoReference is returned when results have no reference yields to be compared with.
+var ErrNoReference = errors.New("reference yields are of thermal fission of U-235 only")
+
+// Deviation is yield of element of simulated products and of reference in percent per fission.
+type Deviation struct {
+	Symbol    string  `json:"symbol" yaml:"symbol"`
+	Number    int     `json:"number" yaml:"number"`
+	Reference float64 `json:"reference" yaml:"reference"`
+	Simulated float64 `json:"simulated" yaml:"simulated"`
+
+	// Deviation is simulated minus reference yield and Relative is its fraction of reference.
+	Deviation float64 `json:"deviation" yaml:"deviation"`
+	Relative  float64 `json:"relative" yaml:"relative"`
+
+	// ChiSquare is contribution of element to chi-square of comparison.
+	ChiSquare float64 `json:"chi_square" yaml:"chi_square"`
+}
+
+// Comparison is comparison of yields of elements of simulated products with reference
+// cumulative yields, by atomic number.
+type Comparison struct {
+	Fissions int         `json:"fissions" yaml:"fissions"`
+	Elements []Deviation `json:"elements" yaml:"elements"`
+
+	// ChiSquare is Pearson's chi-square of counts of elements with expected counts of reference
+	// yields, DegreesOfFreedom is number of elements less one.
+	ChiSquare        float64 `json:"chi_square" yaml:"chi_square"`
+	DegreesOfFreedom int     `json:"degrees_of_freedom" yaml:"degrees_of_freedom"`
+
+	// Other is yield in percent of products of masses without reference yield, which aren't compared.
+	Other float64 `json:"other" yaml:"other"`
+}
+
+// CompareWithReference compares products with reference cumulative yields of thermal fission of
+// U-235. Cumulative yields are of products after they decay, so each product is counted as
+// stable end nuclide of its mass chain, whose mass is the same. It returns ErrNoReference if
+// results are of another nuclide.
+func (r *Results) CompareWithReference() (*Comparison, error) {
+	if r.Parent == nil || r.Parent.ZA() != (isotope.ZA{Number: 92, Mass: 235}) {
+		return nil, ErrNoReference
+	}
+	for name := range r.Parents {
+		if name != r.Parent.Name() {
+			return nil, fmt.Errorf("%w, results have fissions of %s", ErrNoReference, name)
+		}
+	}
+	if r.Fissions == 0 {
+		return nil, errors.New("compare: results have no fissions")
+	}
+
+	chains := make(map[int]chainYield, len(chainYields))
+	reference := make(map[int]float64)
+	for _, c := range chainYields {
+		chains[c.Mass] = c
+		reference[c.Number] += c.Yield
+	}
+	counts := make(map[int]int)
+	other := 0
+	for _, c := range r.Tally.Counts() {
+		if chain, ok := chains[c.Isotope.Mass]; ok {
+			counts[chain.Number] += c.Count
+		} else {
+			other += c.Count
+		}
+	}
+
+	n := float64(r.Fissions)
+	cmp := &Comparison{Fissions: r.Fissions, Other: 100 * float64(other) / n}
+	for z, ref := range reference {
+		d := Deviation{Symbol: elementSymbols[z-32], Number: z, Reference: ref, Simulated: 100 * float64(counts[z]) / n}
+		d.Deviation = d.Simulated - d.Reference
+		d.Relative = d.Deviation / d.Reference
+		expected := n * ref / 100
+		d.ChiSquare = (float64(counts[z]) - expected) * (float64(counts[z]) - expected) / expected
+		cmp.ChiSquare += d.ChiSquare
+		cmp.Elements = append(cmp.Elements, d)
+	}
+	sort.Slice(cmp.Elements, func(i, j int) bool { return cmp.Elements[i].Number < cmp.Elements[j].Number })
+	cmp.DegreesOfFreedom = len(cmp.Elements) - 1
+	return cmp, nil
+}
+
+// Largest returns n elements of the largest absolute deviation, the largest first.
+func (c *Comparison) Largest(n int) []Deviation {
+	ds := append([]Deviation(nil), c.Elements...)
+	sort.SliceStable(ds, func(i, j int) bool { return math.Abs(ds[i].Deviation) > math.Abs(ds[j].Deviation) })
+	if n > 0 && n < len(ds) {
+		ds = ds[:n]
+	}
+	return ds
+}
+
+// Saves to .json file
+func (c *Comparison) SaveJson(out isotope.OutputConfig) error {
+	return out.Save("reference.json", c.WriteJSON)
+}
+
+// WriteJSON writes indented json to w
+func (c *Comparison) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(c, "", " ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Saves to .yaml file
+func (c *Comparison) SaveYAML(out isotope.OutputConfig) error {
+	return out.Save("reference.yaml", c.WriteYAML)
+}
+
+// WriteYAML writes yaml to w
+func (c *Comparison) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Saves to .csv file
+func (c *Comparison) SaveCSV(out isotope.OutputConfig) error {
+	return out.Save("reference.csv", c.WriteCSV)
+}
+
+// WriteCSV writes a row of each element to w, by atomic number
+func (c *Comparison) WriteCSV(w io.Writer) error {
+	format := func(v float64) string { return strconv.FormatFloat(v, 'g', -1, 64) }
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"symbol", "number", "reference", "simulated", "deviation", "relative", "chi_square"})
+	for _, d := range c.Elements {
+		cw.Write([]string{d.Symbol, strconv.Itoa(d.Number), format(d.Reference), format(d.Simulated),
+			format(d.Deviation), format(d.Relative), format(d.ChiSquare)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Save saves comparison in format
+func (c *Comparison) Save(out isotope.OutputConfig, format isotope.Format) error {
+	switch format {
+	case isotope.JSON:
+		return c.SaveJson(out)
+	case isotope.YAML:
+		return c.SaveYAML(out)
+	case isotope.CSV:
+		return c.SaveCSV(out)
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
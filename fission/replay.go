@@ -0,0 +1,84 @@
+package fission
+
+import (
+	"context"
+	"time"
+
+	"physics/eventlog"
+	"physics/internal/bus"
+	"physics/isotope"
+)
+
+// Replay publishes fissions recorded in event log as events of simulation instead of
+// simulating them, so results and tallies of subscribers are recomputed from recorded
+// data. Log has only fissions, so captures, activation and failures aren't replayed, neither
+// are velocities and weights. Events is set to number of records, and ctx stops replay like Run.
+// Results have no Config, so no run bundle is saved for them: config with events of the log
+// would re-execute a run of other events than the recorded one, whose failures are lost.
+func (s *Simulation) Replay(ctx context.Context, log *eventlog.Reader) (*Results, error) {
+	start := time.Now()
+	s.Events = log.Len()
+	s.results.Config = nil
+	s.results.Seed = s.Seed
+	s.results.Parent = s.Isotope
+	s.results.keep = s.KeepProducts
+	s.results.parents = s.Fuel != nil
+
+	nuclide := func(za isotope.ZA) *isotope.Isotope {
+		if iso, ok := s.nuclides[za]; ok {
+			return iso
+		}
+		iso, ok := isotope.Lookup(za.Number, za.Mass)
+		if !ok {
+			iso = isotope.Fragment(za.Number, za.Mass)
+		}
+		return s.nuclide(iso)
+	}
+	size := s.batchSize()
+	step := s.progressStep()
+	done := ctx.Done()
+	var slab isotope.Products
+	i := 0
+	err := log.Range(0, s.Events, func(k int, r eventlog.Record) bool {
+		select {
+		case <-done:
+			return false
+		default:
+		}
+		if cap(slab)-len(slab) < 2 {
+			slab = make(isotope.Products, 0, slabSize)
+		}
+		n := len(slab)
+		for _, za := range []isotope.ZA{r.First, r.Second} {
+			if za != (isotope.ZA{}) {
+				slab = append(slab, nuclide(za))
+			}
+		}
+		bus.Publish(s.bus, Event{Index: k, Parent: nuclide(r.Parent), Products: slab[n:len(slab):len(slab)],
+			Neutrons: r.Neutrons, KineticEnergy: r.KineticEnergy, Weight: 1})
+
+		i++
+		if i%size == 0 || i == s.Events {
+			bus.Publish(s.bus, BatchEnd{Batch: (i - 1) / size})
+		}
+		if i%step == 0 || i == s.Events {
+			bus.Publish(s.bus, Progress{Done: i, Total: s.Events, Elapsed: time.Since(start)})
+		}
+		return true
+	})
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil && i > 0 && i < s.Events {
+		// flush partial batch and report where replay stopped
+		if i%size != 0 {
+			bus.Publish(s.bus, BatchEnd{Batch: i / size})
+		}
+		if i%step != 0 {
+			bus.Publish(s.bus, Progress{Done: i, Total: s.Events, Elapsed: time.Since(start)})
+		}
+	}
+	s.results.Elapsed = time.Since(start)
+	bus.Publish(s.bus, Finished{Results: s.results})
+	return s.results, err
+}
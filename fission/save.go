@@ -0,0 +1,62 @@
+package fission
+
+import (
+	"errors"
+
+	"physics/isotope"
+)
+
+// protobuf saves results as Protobuf message. It is registered by package pb, which
+// encodes results but imports this package.
+var protobuf func(*Results, isotope.OutputConfig) error
+
+// RegisterProtobuf sets how Save writes results in Protobuf format. Package pb registers
+// its encoding when it's imported.
+func RegisterProtobuf(save func(*Results, isotope.OutputConfig) error) {
+	protobuf = save
+}
+
+// Save saves results to directory of out: bundle of run if results have Config, and in
+// each format counts of elements and isotopes, their probabilities, neutron statistics,
+// summary and weighted yields if there are any. CSV has no neutron statistics and summary,
+// Protobuf is a single message of aggregated results.
+func (r *Results) Save(out isotope.OutputConfig, formats ...isotope.Format) error {
+	if r.Config != nil {
+		b, err := NewBundle(*r.Config, r)
+		if err == nil {
+			err = b.Save(out)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	savers := []func(isotope.OutputConfig, isotope.Format) error{r.Tally.Save}
+	if r.Weighted != nil {
+		savers = append(savers, r.Weighted.Save)
+	}
+	for _, f := range formats {
+		if f == isotope.Protobuf {
+			if protobuf == nil {
+				return errors.New("fission: protobuf format needs package pb imported")
+			}
+			if err := protobuf(r, out); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, save := range savers {
+			if err := save(out, f); err != nil {
+				return err
+			}
+		}
+		if f != isotope.CSV {
+			if err := r.NeutronStats().Save(out, f); err != nil {
+				return err
+			}
+			if err := r.Summary().Save(out, f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package fission
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"physics/count"
+	"physics/internal/bus"
+	"physics/isotope"
+)
+
+// Snapshot is aggregate of events simulated so far.
+type Snapshot struct {
+	// Events is number of attempted fissions, and of captures by fuel.
+	Events   int     `json:"events" yaml:"events"`
+	Fissions int     `json:"fissions" yaml:"fissions"`
+	Failures int     `json:"failures" yaml:"failures"`
+	Nu       float64 `json:"nu" yaml:"nu"`
+
+	Symbols map[string]int `json:"symbols" yaml:"symbols"`
+
+	// Probabilities of elements among products in percent.
+	Probabilities map[string]float64 `json:"probabilities" yaml:"probabilities"`
+}
+
+// Series are snapshots in order of events, showing how aggregates converge.
+type Series struct {
+	Snapshots []Snapshot `json:"snapshots" yaml:"snapshots"`
+}
+
+// Snapshots records snapshot of aggregates after every n events and at the end of simulation.
+func (s *Simulation) Snapshots(n int) *Series {
+	series := &Series{}
+	var (
+		current  Snapshot
+		neutrons int
+	)
+	current.Symbols = make(map[string]int)
+	take := func() {
+		snap := current
+		snap.Symbols = make(map[string]int, len(current.Symbols))
+		for sym, c := range current.Symbols {
+			snap.Symbols[sym] = c
+		}
+		snap.Probabilities = isotope.SymbolCounts(snap.Symbols).Probabilities().Percent()
+		if snap.Fissions > 0 {
+			snap.Nu = float64(neutrons) / float64(snap.Fissions)
+		}
+		series.Snapshots = append(series.Snapshots, snap)
+	}
+	step := func() {
+		current.Events++
+		if n > 0 && current.Events%n == 0 {
+			take()
+		}
+	}
+
+	bus.Subscribe(s.bus, func(e Event) {
+		current.Fissions++
+		neutrons += e.Neutrons
+		for _, p := range e.Products {
+			current.Symbols[p.Symbol]++
+		}
+		step()
+	})
+	bus.Subscribe(s.bus, func(Failure) {
+		current.Failures++
+		step()
+	})
+	bus.Subscribe(s.bus, func(c Capture) {
+		// implicit capture is part of event of fission which follows it
+		if c.Implicit {
+			return
+		}
+		step()
+	})
+	bus.Subscribe(s.bus, func(Finished) {
+		if last := len(series.Snapshots) - 1; last < 0 || series.Snapshots[last].Events != current.Events {
+			take()
+		}
+	})
+	return series
+}
+
+// Saves to .json file
+func (sr *Series) SaveJson(out isotope.OutputConfig) error {
+	return out.Save("series.json", sr.WriteJSON)
+}
+
+// WriteJSON writes indented json to w
+func (sr *Series) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(sr, "", " ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Saves to .yaml file
+func (sr *Series) SaveYAML(out isotope.OutputConfig) error {
+	return out.Save("series.yaml", sr.WriteYAML)
+}
+
+// WriteYAML writes yaml to w
+func (sr *Series) WriteYAML(w io.Writer) error {
+	return isotope.WriteYAML(w, sr)
+}
+
+// Saves to .csv file
+func (sr *Series) SaveCSV(out isotope.OutputConfig) error {
+	return out.Save("series.csv", sr.WriteCSV)
+}
+
+// WriteCSV writes a row of each snapshot to w, with probability in percent of each element
+// in columns after events, fissions, failures and nu
+func (sr *Series) WriteCSV(w io.Writer) error {
+	elements := make(map[string]bool)
+	for _, snap := range sr.Snapshots {
+		for s := range snap.Symbols {
+			elements[s] = true
+		}
+	}
+	symbols := count.SortedKeys(elements)
+
+	cw := csv.NewWriter(w)
+	cw.Write(append([]string{"events", "fissions", "failures", "nu"}, symbols...))
+	for _, snap := range sr.Snapshots {
+		row := []string{
+			strconv.Itoa(snap.Events),
+			strconv.Itoa(snap.Fissions),
+			strconv.Itoa(snap.Failures),
+			strconv.FormatFloat(snap.Nu, 'g', -1, 64),
+		}
+		for _, s := range symbols {
+			row = append(row, strconv.FormatFloat(snap.Probabilities[s], 'g', -1, 64))
+		}
+		cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Save saves series in format
+func (sr *Series) Save(out isotope.OutputConfig, format isotope.Format) error {
+	switch format {
+	case isotope.JSON:
+		return sr.SaveJson(out)
+	case isotope.YAML:
+		return sr.SaveYAML(out)
+	case isotope.CSV:
+		return sr.SaveCSV(out)
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
@@ -0,0 +1,83 @@
+package fission
+
+import "math"
+
+// Stat is statistical summary of a yield estimated from batches.
+type Stat struct {
+	// Mean yield per fission in percent.
+	Mean float64 `json:"mean" yaml:"mean"`
+
+	// StdDev is standard deviation of batch yields.
+	StdDev float64 `json:"std_dev" yaml:"std_dev"`
+
+	// StdErr is standard error of the mean.
+	StdErr float64 `json:"std_err" yaml:"std_err"`
+
+	// Lower and Upper bounds of 95% confidence interval of the mean.
+	Lower float64 `json:"lower" yaml:"lower"`
+	Upper float64 `json:"upper" yaml:"upper"`
+}
+
+// Stats returns yield statistics of each element symbol computed across batches.
+// Confidence intervals use Student's t distribution, so they're valid for few batches too.
+func (r *Results) Stats() map[string]Stat {
+	stats := make(map[string]Stat)
+	if len(r.Batches) == 0 {
+		return stats
+	}
+	symbols := make(map[string]bool)
+	for _, b := range r.Batches {
+		for s := range b.Symbols {
+			symbols[s] = true
+		}
+	}
+	for s := range symbols {
+		yields := make([]float64, len(r.Batches))
+		for i, b := range r.Batches {
+			if b.Fissions > 0 {
+				yields[i] = 100 * float64(b.Symbols[s]) / float64(b.Fissions)
+			}
+		}
+		stats[s] = NewStat(yields)
+	}
+	return stats
+}
+
+// NewStat returns statistics of independent samples.
+func NewStat(samples []float64) Stat {
+	n := float64(len(samples))
+	if n == 0 {
+		return Stat{}
+	}
+	var sum float64
+	for _, v := range samples {
+		sum += v
+	}
+	mean := sum / n
+	if n < 2 {
+		return Stat{Mean: mean, Lower: mean, Upper: mean}
+	}
+	var sq float64
+	for _, v := range samples {
+		sq += (v - mean) * (v - mean)
+	}
+	sd := math.Sqrt(sq / (n - 1))
+	se := sd / math.Sqrt(n)
+	t := tQuantile(len(samples) - 1)
+	return Stat{Mean: mean, StdDev: sd, StdErr: se, Lower: mean - t*se, Upper: mean + t*se}
+}
+
+// Two-sided 95% quantiles of Student's t distribution by degrees of freedom.
+var tTable = []float64{
+	1: 12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+func tQuantile(dof int) float64 {
+	if dof < len(tTable) {
+		return tTable[dof]
+	}
+	// converges to normal distribution
+	return 1.96 + 2.4/float64(dof)
+}
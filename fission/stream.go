@@ -0,0 +1,32 @@
+package fission
+
+import (
+	"context"
+	"iter"
+
+	"physics/internal/bus"
+)
+
+// Stream runs simulation as its events are iterated, so that they're processed as they're
+// produced without keeping them, e.g.
+//
+//	for e := range sim.Stream(ctx) {
+//		...
+//	}
+//
+// Simulation stops when loop breaks or ctx is cancelled. Results of events simulated are
+// published in Finished message as usual. Simulation can be iterated only once.
+func (s *Simulation) Stream(ctx context.Context) iter.Seq[Event] {
+	return func(yield func(Event) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stopped := false
+		bus.Subscribe(s.bus, func(e Event) {
+			if !stopped && !yield(e) {
+				stopped = true
+				cancel()
+			}
+		})
+		s.Run(ctx)
+	}
+}
@@ -0,0 +1,182 @@
+package fission
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"strings"
+
+	"physics/count"
+	"physics/isotope"
+)
+
+// SummaryVersion is version of Summary format. Fields are only added within a version,
+// so scripts reading a summary keep working.
+const SummaryVersion = 1
+
+// Summary is a compact overview of results, e.g. for automated grading.
+type Summary struct {
+	Version int    `json:"version" yaml:"version"`
+	Isotope string `json:"isotope" yaml:"isotope"`
+	Seed    int64  `json:"seed" yaml:"seed"`
+
+	// Events is number of attempted fissions, Failures of them didn't produce known isotopes.
+	// With fuel Events also include Captures of neutrons which didn't fission.
+	Events      int     `json:"events" yaml:"events"`
+	Failures    int     `json:"failures" yaml:"failures"`
+	FailureRate float64 `json:"failure_rate" yaml:"failure_rate"`
+	Captures    int     `json:"captures,omitempty" yaml:"captures,omitempty"`
+
+	// Converged is true if simulation stopped before its events because yields converged.
+	Converged bool `json:"converged,omitempty" yaml:"converged,omitempty"`
+
+	// Parents are numbers of fissions of each nuclide of fuel.
+	Parents map[string]int `json:"parents,omitempty" yaml:"parents,omitempty"`
+
+	// Activation are numbers of products of captures by structural materials.
+	Activation map[string]int `json:"activation,omitempty" yaml:"activation,omitempty"`
+
+	// Unmatched counts fragments without equivalent isotope by what fragment policy did with them.
+	Unmatched isotope.Unmatched `json:"unmatched" yaml:"unmatched"`
+
+	// Nu is mean number of neutrons per fission and NuStdDev its standard deviation.
+	Nu       float64 `json:"nu" yaml:"nu"`
+	NuStdDev float64 `json:"nu_std_dev" yaml:"nu_std_dev"`
+
+	// EnergyPerFission is mean energy released per fission in MeV.
+	EnergyPerFission float64 `json:"energy_per_fission_mev" yaml:"energy_per_fission_mev"`
+
+	// TKE is mean total kinetic energy of products in MeV.
+	TKE float64 `json:"tke_mev" yaml:"tke_mev"`
+
+	// Observables are derived quantities recorded by subsystems, e.g. "k_eff".
+	Observables map[string]float64 `json:"observables,omitempty" yaml:"observables,omitempty"`
+
+	// Top are the most common products.
+	Top []Yield `json:"top" yaml:"top"`
+
+	// WallTime of simulation in seconds.
+	WallTime float64 `json:"wall_time_s" yaml:"wall_time_s"`
+}
+
+// Yield is number and yield per fission in percent of a product.
+type Yield struct {
+	Isotope string  `json:"isotope" yaml:"isotope"`
+	Count   int     `json:"count" yaml:"count"`
+	Yield   float64 `json:"yield" yaml:"yield"`
+}
+
+// Summary returns summary of results with ten most common products.
+func (r *Results) Summary() Summary {
+	const top = 10
+
+	ns := r.NeutronStats()
+	s := Summary{
+		Version:   SummaryVersion,
+		Seed:      r.Seed,
+		Events:    ns.Fissions + r.Failures + r.Captures.Total(),
+		Failures:  r.Failures,
+		Captures:  r.Captures.Total(),
+		Converged: r.Converged,
+		Unmatched: r.Unmatched,
+		Nu:        ns.Mean,
+		NuStdDev:  math.Sqrt(ns.Variance),
+		WallTime:  r.Elapsed.Seconds(),
+		Top:       []Yield{},
+	}
+	if len(r.Observables) > 0 {
+		s.Observables = maps.Clone(r.Observables)
+	}
+	if len(r.Parents) > 0 {
+		s.Parents = maps.Clone(r.Parents)
+	}
+	if r.Activation.Len() > 0 {
+		s.Activation = make(map[string]int)
+		for _, c := range r.Activation.Counts() {
+			s.Activation[c.Isotope.Name()] = c.Count
+		}
+	}
+	if r.Parent != nil {
+		s.Isotope = r.Parent.Name()
+	}
+	if s.Events > 0 {
+		s.FailureRate = float64(r.Failures) / float64(s.Events)
+	}
+	if ns.Fissions > 0 {
+		s.EnergyPerFission = r.Energy / float64(ns.Fissions)
+		s.TKE = r.KineticEnergy / float64(ns.Fissions)
+		for _, c := range r.Tally.TopN(top) {
+			s.Top = append(s.Top, Yield{
+				Isotope: c.Isotope.Name(),
+				Count:   c.Count,
+				Yield:   100 * float64(c.Count) / float64(ns.Fissions),
+			})
+		}
+	}
+	return s
+}
+
+// String formats summary for terminal.
+func (s Summary) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s, seed %d: %d events, %d failed (%.2f%%) in %.3fs\n", s.Isotope, s.Seed, s.Events, s.Failures, 100*s.FailureRate, s.WallTime)
+	if s.Converged {
+		fmt.Fprintf(&b, "yields converged, simulation stopped after %d events\n", s.Events)
+	}
+	// rejected fragments are already counted as failures
+	if u := s.Unmatched; u.Retries+u.Kept+u.Remapped > 0 {
+		fmt.Fprintf(&b, "unmatched fragments: %d rejected, %d retries, %d kept, %d remapped\n", u.Rejected, u.Retries, u.Kept, u.Remapped)
+	}
+	if len(s.Parents) > 0 || s.Captures > 0 {
+		fmt.Fprintf(&b, "%d captures, fissions by parent:", s.Captures)
+		parents := count.Counter[string](s.Parents)
+		for _, e := range parents.TopN(0) {
+			fmt.Fprintf(&b, " %s %d (%.2f%%)", e.Key, e.Count, 100*float64(e.Count)/float64(max(parents.Total(), 1)))
+		}
+		fmt.Fprintln(&b)
+	}
+	if len(s.Activation) > 0 {
+		activation := count.Counter[string](s.Activation)
+		fmt.Fprintf(&b, "%d neutrons activated structures:", activation.Total())
+		for _, e := range activation.TopN(0) {
+			fmt.Fprintf(&b, " %s %d", e.Key, e.Count)
+		}
+		fmt.Fprintln(&b)
+	}
+	fmt.Fprintf(&b, "nu = %.4f ± %.4f, energy per fission = %.1f MeV, TKE = %.1f MeV\n", s.Nu, s.NuStdDev, s.EnergyPerFission, s.TKE)
+	for _, name := range count.SortedKeys(s.Observables) {
+		fmt.Fprintf(&b, "%s = %.4f\n", name, s.Observables[name])
+	}
+	for _, y := range s.Top {
+		fmt.Fprintf(&b, "  %-8s %6d %7.3f%%\n", y.Isotope, y.Count, y.Yield)
+	}
+	return b.String()
+}
+
+// Saves to .json file
+func (s Summary) SaveJson(out isotope.OutputConfig) error {
+	return out.Save("summary.json", s.WriteJSON)
+}
+
+// WriteJSON writes indented json to w
+func (s Summary) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(s, "", " ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Save saves summary in json or yaml format
+func (s Summary) Save(out isotope.OutputConfig, format isotope.Format) error {
+	switch format {
+	case isotope.JSON:
+		return s.SaveJson(out)
+	case isotope.YAML:
+		return s.SaveYAML(out)
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
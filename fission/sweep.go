@@ -0,0 +1,108 @@
+package fission
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// SweepResults are results of the same simulation run with different seeds.
+type SweepResults struct {
+	Config Config  `json:"config" yaml:"config"`
+	Seeds  []int64 `json:"seeds" yaml:"seeds"`
+
+	// Fissions and Failures summed over all runs.
+	Fissions int `json:"fissions" yaml:"fissions"`
+	Failures int `json:"failures" yaml:"failures"`
+
+	// Symbols are element symbol counts summed over all runs.
+	Symbols map[string]int `json:"symbols" yaml:"symbols"`
+
+	// Yields are element yields per fission in percent, each run being one sample.
+	Yields map[string]Stat `json:"yields" yaml:"yields"`
+
+	// Nu is mean number of neutrons per fission, each run being one sample.
+	Nu Stat `json:"nu" yaml:"nu"`
+}
+
+// SweepSeeds runs simulation of config runs times with consecutive seeds, at most parallel
+// runs at a time, and aggregates their tallies. Config seed is the first seed, random if zero.
+// Sweep stops with error of ctx when it's cancelled.
+func SweepSeeds(ctx context.Context, c Config, runs, parallel int) (*SweepResults, error) {
+	if parallel < 1 {
+		parallel = 1
+	}
+	base := c.Seed
+	if base == 0 {
+		base = time.Now().UnixNano()
+	}
+
+	results := make([]*Results, runs)
+	errs := make([]error, runs)
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i := 0; i < runs; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			rc := c
+			rc.Seed = base + int64(i)
+			sim, err := rc.Simulation()
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			results[i], errs[i] = sim.Run(ctx)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return aggregate(c, results), nil
+}
+
+func aggregate(c Config, results []*Results) *SweepResults {
+	sr := &SweepResults{Config: c, Symbols: make(map[string]int), Yields: make(map[string]Stat)}
+
+	var nus []float64
+	counts := make([]map[string]int, len(results))
+	for i, r := range results {
+		sr.Seeds = append(sr.Seeds, r.Seed)
+		sr.Fissions += r.Fissions
+		sr.Failures += r.Failures
+		counts[i] = r.Tally.CountSymbols()
+		for s, n := range counts[i] {
+			sr.Symbols[s] += n
+		}
+		nus = append(nus, r.NeutronStats().Mean)
+	}
+	sr.Nu = NewStat(nus)
+
+	for s := range sr.Symbols {
+		yields := make([]float64, len(results))
+		for i, r := range results {
+			if fissions := r.Fissions; fissions > 0 {
+				yields[i] = 100 * float64(counts[i][s]) / float64(fissions)
+			}
+		}
+		sr.Yields[s] = NewStat(yields)
+	}
+	return sr
+}
+
+// SaveJson saves merged results to .json file at path.
+func (sr *SweepResults) SaveJson(path string) error {
+	data, err := json.MarshalIndent(sr, "", " ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0777)
+}
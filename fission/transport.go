@@ -0,0 +1,111 @@
+package fission
+
+import (
+	"fmt"
+	"math/rand"
+
+	"physics/internal/bus"
+	"physics/transport"
+)
+
+// TransportConfig describes geometry and medium through which neutrons are followed.
+type TransportConfig struct {
+	// Geometry is "infinite", "sphere" or "slab".
+	Geometry string `json:"geometry" yaml:"geometry" toml:"geometry"`
+
+	// Size is radius of sphere or thickness of slab in cm.
+	Size float64 `json:"size,omitempty" yaml:"size,omitempty" toml:"size,omitempty"`
+
+	// Material, or Medium of macroscopic cross sections, fills geometry. It's fast uranium
+	// metal if neither is set.
+	Material *transport.Material `json:"material,omitempty" yaml:"material,omitempty" toml:"material,omitempty"`
+	Medium   *transport.Medium   `json:"medium,omitempty" yaml:"medium,omitempty" toml:"medium,omitempty"`
+
+	// Albedo is fraction of escaping neutrons which reflector returns back, there is no reflector if it's zero.
+	Albedo float64 `json:"albedo,omitempty" yaml:"albedo,omitempty" toml:"albedo,omitempty"`
+
+	// Chains is number of fission chains followed when simulation finishes.
+	Chains int `json:"chains,omitempty" yaml:"chains,omitempty" toml:"chains,omitempty"`
+
+	// Doppler is fuel temperature feedback of chain reaction, it needs Material rather than Medium.
+	Doppler *transport.Doppler `json:"doppler,omitempty" yaml:"doppler,omitempty" toml:"doppler,omitempty"`
+}
+
+// transport creates transport of config, drawing random numbers from src.
+func (c TransportConfig) transport(src rand.Source) (*transport.Transport, error) {
+	g, err := transport.ParseGeometry(c.Geometry, c.Size)
+	if err != nil {
+		return nil, err
+	}
+	material := transport.Uranium(0.94)
+	m := transport.FastUranium
+	switch {
+	case c.Material != nil && c.Medium != nil:
+		return nil, fmt.Errorf("transport: both material and medium are set")
+	case c.Material != nil:
+		if err := c.Material.Validate(); err != nil {
+			return nil, err
+		}
+		material = *c.Material
+		m = c.Material.Medium()
+	case c.Medium != nil:
+		m = *c.Medium
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	if c.Albedo < 0 || c.Albedo > 1 {
+		return nil, fmt.Errorf("transport: albedo %g isn't between 0 and 1", c.Albedo)
+	}
+	t := transport.New(g, m, rand.New(src))
+	t.Albedo = c.Albedo
+	if c.Medium == nil {
+		t.Nuclides = make(map[string]transport.Medium)
+		for _, n := range material.Constituents {
+			t.Nuclides[n.Nuclide.Name] = material.Partial(n.Nuclide.Name)
+		}
+	}
+	return t, nil
+}
+
+// AttachTransport follows neutrons of every fission through t. When simulation finishes,
+// k_eff and fractions of neutrons which leak, are captured or induce fission are recorded
+// as observables "k_eff", "leakage_fraction", "capture_fraction" and "fission_fraction",
+// with "reflections" per neutron if t has reflector. Track length per neutron is recorded as
+// "flux" and capture and fission rates per neutron of medium and each of its nuclides as
+// e.g. "fission_rate" and "U-235_capture_rate", each with relative error, e.g. "flux_error". Then chains fission chains are followed,
+// with neutrons of multiplicity of isotope, and their mean number of generations and
+// fissions, and fraction of divergent chains are recorded as "chain_generations",
+// "chain_fissions" and "divergent_chains".
+// Transport isn't part of checkpoints, so simulation with transport can't be resumed.
+func (s *Simulation) AttachTransport(t *transport.Transport, chains int) {
+	s.transport = t
+	bus.Subscribe(s.bus, func(e Event) {
+		t.Fission(e.Neutrons, e.NeutronVelocities)
+	})
+	bus.Subscribe(s.bus, func(f Finished) {
+		obs := f.Results.Observables
+		obs["k_eff"] = t.Tally.KEff()
+		obs["leakage_fraction"] = t.Tally.Leakage()
+		obs["capture_fraction"] = t.Tally.CaptureFraction()
+		obs["fission_fraction"] = t.Tally.FissionFraction()
+		if t.Albedo > 0 {
+			obs["reflections"] = t.Tally.Reflections()
+		}
+		flux := t.Flux.Flux()
+		obs["flux"], obs["flux_error"] = flux.Mean, flux.RelativeError
+		for _, r := range t.Flux.Rates() {
+			name := r.Reaction + "_rate"
+			if r.Nuclide != transport.Total {
+				name = r.Nuclide + "_" + name
+			}
+			obs[name], obs[name+"_error"] = r.Mean, r.RelativeError
+		}
+		if chains > 0 {
+			cs := t.Chains(chains, s.Isotope.NeutronMultiplicity().Sample)
+			obs["chain_generations"] = cs.MeanGenerations
+			obs["chain_fissions"] = cs.MeanFissions
+			obs["divergent_chains"] = cs.DivergentFraction()
+		}
+	})
+}
@@ -0,0 +1,220 @@
+package fission
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"physics/internal/bus"
+	"physics/isotope"
+	"physics/random"
+	"physics/transport"
+)
+
+// Weighting is variance reduction of simulation. Events carry weights, so that estimates
+// of Weighted are unbiased while rare outcomes are drawn more often.
+type Weighting struct {
+	// ImplicitCapture fissions every neutron absorbed by fuel with weight of probability of
+	// fission of absorbing nuclide, capture is scored with the rest of its weight.
+	ImplicitCapture bool `json:"implicit_capture,omitempty" yaml:"implicit_capture,omitempty" toml:"implicit_capture,omitempty"`
+
+	// MassBins stratifies masses of heavier fragment into bins of equal width, which events
+	// draw from in turn. Each event is weighted by probability of its bin.
+	MassBins int `json:"mass_bins,omitempty" yaml:"mass_bins,omitempty" toml:"mass_bins,omitempty"`
+}
+
+// Validate returns error if weighting has invalid values.
+func (w Weighting) Validate() error {
+	if w.MassBins < 0 {
+		return errors.New("weighting: mass bins can't be negative")
+	}
+	return nil
+}
+
+// AbsorbImplicit draws index of nuclide which absorbs neutron by absorption cross sections
+// and returns it with probability that it fissions.
+func (f *Fuel) AbsorbImplicit(rng random.Rand) (int, float64) {
+	u := rng.Float64() * f.rates[len(f.rates)-1]
+	k := len(f.Isotopes) - 1
+	for i := 1; i < len(f.rates); i += 2 {
+		if u < f.rates[i] {
+			k = i / 2
+			break
+		}
+	}
+	if a := f.Absorption(k); a > 0 {
+		return k, f.FissionFraction(k) / a
+	}
+	return k, 0
+}
+
+// WeightedYield is estimated number of products of isotope per event in percent.
+type WeightedYield struct {
+	Isotope            string `json:"isotope" yaml:"isotope"`
+	transport.Estimate `yaml:",inline"`
+}
+
+// Weighted are estimates of weighted events per event, which is neutron absorbed by fuel or
+// fission of isotope. Every event is a history, also if it failed or its neutron was captured.
+type Weighted struct {
+	Histories int
+
+	fissions, captures, neutrons transport.Score
+	yields                       map[isotope.ZA]*transport.Score
+	names                        map[isotope.ZA]string
+}
+
+// attachWeighted estimates weighted events in Results.Weighted.
+func (s *Simulation) attachWeighted() {
+	w := &Weighted{}
+	s.results.Weighted = w
+	bus.Subscribe(s.bus, w.event)
+	bus.Subscribe(s.bus, w.fail)
+	bus.Subscribe(s.bus, w.capture)
+}
+
+// record records fissions, captures and neutrons per event as "weighted_fissions",
+// "weighted_captures" and "weighted_neutrons" observables, each with relative error,
+// e.g. "weighted_fissions_error".
+func (w *Weighted) record(obs map[string]float64) {
+	for name, e := range map[string]transport.Estimate{"fissions": w.Fissions(), "captures": w.Captures(), "neutrons": w.Neutrons()} {
+		obs["weighted_"+name], obs["weighted_"+name+"_error"] = e.Mean, e.RelativeError
+	}
+}
+
+func (w *Weighted) event(e Event) {
+	if w.yields == nil {
+		w.yields = make(map[isotope.ZA]*transport.Score)
+		w.names = make(map[isotope.ZA]string)
+	}
+	w.Histories++
+	w.fissions.Add(e.Weight)
+	w.neutrons.Add(e.Weight * float64(e.Neutrons))
+	for i, p := range e.Products {
+		za := p.ZA()
+		if i > 0 && za == e.Products[0].ZA() {
+			continue
+		}
+		// both products of the same isotope are one score of history
+		n := 1.0
+		if i == 0 && len(e.Products) > 1 && za == e.Products[1].ZA() {
+			n = 2
+		}
+		s, ok := w.yields[za]
+		if !ok {
+			s = &transport.Score{}
+			w.yields[za], w.names[za] = s, p.Name()
+		}
+		s.Add(n * e.Weight)
+	}
+}
+
+func (w *Weighted) fail(Failure) {
+	w.Histories++
+}
+
+func (w *Weighted) capture(c Capture) {
+	if !c.Implicit {
+		w.Histories++
+	}
+	w.captures.Add(c.Weight)
+}
+
+// Fissions returns fissions per event, which is less than one if neutrons are captured or
+// fissions fail.
+func (w *Weighted) Fissions() transport.Estimate {
+	return w.fissions.Estimate(w.Histories)
+}
+
+// Captures returns captures of neutrons by fuel per event.
+func (w *Weighted) Captures() transport.Estimate {
+	return w.captures.Estimate(w.Histories)
+}
+
+// Neutrons returns neutrons released in fissions per event.
+func (w *Weighted) Neutrons() transport.Estimate {
+	return w.neutrons.Estimate(w.Histories)
+}
+
+// Yields returns yields of isotopes, the largest first.
+func (w *Weighted) Yields() []WeightedYield {
+	yields := make([]WeightedYield, 0, len(w.yields))
+	for za, s := range w.yields {
+		e := s.Estimate(w.Histories)
+		e.Mean *= 100
+		yields = append(yields, WeightedYield{Isotope: w.names[za], Estimate: e})
+	}
+	sort.Slice(yields, func(i, j int) bool {
+		if yields[i].Mean != yields[j].Mean {
+			return yields[i].Mean > yields[j].Mean
+		}
+		return yields[i].Isotope < yields[j].Isotope
+	})
+	return yields
+}
+
+// Saves to .json file
+func (w *Weighted) SaveJson(out isotope.OutputConfig) error {
+	return out.Save("weighted.json", w.WriteJSON)
+}
+
+// WriteJSON writes indented json of yields to w
+func (w *Weighted) WriteJSON(wr io.Writer) error {
+	data, err := json.MarshalIndent(w.Yields(), "", " ")
+	if err != nil {
+		return err
+	}
+	_, err = wr.Write(data)
+	return err
+}
+
+// Saves to .yaml file
+func (w *Weighted) SaveYAML(out isotope.OutputConfig) error {
+	return out.Save("weighted.yaml", w.WriteYAML)
+}
+
+// WriteYAML writes yaml of yields to w
+func (w *Weighted) WriteYAML(wr io.Writer) error {
+	enc := yaml.NewEncoder(wr)
+	enc.SetIndent(2)
+	if err := enc.Encode(w.Yields()); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Saves to .csv file
+func (w *Weighted) SaveCSV(out isotope.OutputConfig) error {
+	return out.Save("weighted.csv", w.WriteCSV)
+}
+
+// WriteCSV writes a row of each isotope to w, the largest yield first
+func (w *Weighted) WriteCSV(wr io.Writer) error {
+	format := func(v float64) string { return strconv.FormatFloat(v, 'g', -1, 64) }
+	cw := csv.NewWriter(wr)
+	cw.Write([]string{"isotope", "yield", "relative_error"})
+	for _, y := range w.Yields() {
+		cw.Write([]string{y.Isotope, format(y.Mean), format(y.RelativeError)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Save saves weighted yields in format
+func (w *Weighted) Save(out isotope.OutputConfig, format isotope.Format) error {
+	switch format {
+	case isotope.JSON:
+		return w.SaveJson(out)
+	case isotope.YAML:
+		return w.SaveYAML(out)
+	case isotope.CSV:
+		return w.SaveCSV(out)
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
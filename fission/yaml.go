@@ -0,0 +1,53 @@
+package fission
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"physics/isotope"
+)
+
+// Saves to .yaml file
+func (ns NeutronStats) SaveYAML(out isotope.OutputConfig) error {
+	return out.Save("neutrons.yaml", ns.WriteYAML)
+}
+
+// WriteYAML writes yaml to w
+func (ns NeutronStats) WriteYAML(w io.Writer) error {
+	return isotope.WriteYAML(w, ns)
+}
+
+// Save saves neutron statistics in json or yaml format
+func (ns NeutronStats) Save(out isotope.OutputConfig, format isotope.Format) error {
+	switch format {
+	case isotope.JSON:
+		return ns.SaveJson(out)
+	case isotope.YAML:
+		return ns.SaveYAML(out)
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
+
+// WriteYAML writes yaml to w
+func (s Summary) WriteYAML(w io.Writer) error {
+	return isotope.WriteYAML(w, s)
+}
+
+// Saves to .yaml file
+func (s Summary) SaveYAML(out isotope.OutputConfig) error {
+	return out.Save("summary.yaml", s.WriteYAML)
+}
+
+// SaveYAML saves merged results to .yaml file at path.
+func (sr *SweepResults) SaveYAML(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := isotope.WriteYAML(f, sr); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
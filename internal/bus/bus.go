@@ -0,0 +1,45 @@
+// Package bus is a synchronous event bus through which simulation subsystems
+// exchange typed messages without knowing about each other.
+package bus
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Bus delivers published messages to handlers subscribed to a message type.
+// Handlers are called in order of subscription, in publishing goroutine.
+type Bus struct {
+	mu sync.RWMutex
+
+	// handlers of type T are []func(T), so messages aren't boxed when published
+	handlers map[reflect.Type]any
+}
+
+// New creates empty bus.
+func New() *Bus {
+	return &Bus{handlers: make(map[reflect.Type]any)}
+}
+
+// Subscribe registers handler of messages of type T.
+func Subscribe[T any](b *Bus, handler func(T)) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	hs, _ := b.handlers[t].([]func(T))
+	// copy, so that publishing doesn't see slice being appended to
+	b.handlers[t] = append(hs[:len(hs):len(hs)], handler)
+}
+
+// Publish delivers message to all handlers of its type.
+func Publish[T any](b *Bus, msg T) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	b.mu.RLock()
+	handlers, _ := b.handlers[t].([]func(T))
+	b.mu.RUnlock()
+	for _, h := range handlers {
+		h(msg)
+	}
+}
@@ -0,0 +1,59 @@
+// Package enum parses and formats enumerations of named values, e.g. fragment policies or
+// random engines, so that each enumeration only lists names of its values.
+package enum
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Enum is names of values of E, in order of values from zero. Zero value is the default,
+// which empty name parses to.
+type Enum[E ~int] struct {
+	// kind is what values are, e.g. "fragment policy", in parsing errors
+	kind  string
+	names []string
+}
+
+// New returns enumeration of kind with names of its values.
+func New[E ~int](kind string, names ...string) Enum[E] {
+	return Enum[E]{kind: kind, names: names}
+}
+
+// Names returns names of values.
+func (e Enum[E]) Names() []string {
+	return e.names
+}
+
+// Parse parses name of value, regardless of case. Empty name is zero value.
+func (e Enum[E]) Parse(s string) (E, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if name == "" {
+		return 0, nil
+	}
+	for i, n := range e.names {
+		if n == name {
+			return E(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown %s %q, expected one of %s", e.kind, s, strings.Join(e.names, ", "))
+}
+
+// String returns name of v, or its type and number if it has no name.
+func (e Enum[E]) String(v E) string {
+	if v >= 0 && int(v) < len(e.names) {
+		return e.names[v]
+	}
+	return fmt.Sprintf("%s(%d)", reflect.TypeFor[E]().Name(), int(v))
+}
+
+// Set parses s into v, for Set of flag.Value and UnmarshalText of encoding.TextUnmarshaler.
+func (e Enum[E]) Set(v *E, s string) error {
+	parsed, err := e.Parse(s)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
@@ -0,0 +1,111 @@
+package isotope
+
+import (
+	"maps"
+
+	"physics/count"
+)
+
+// Accumulator counts products as they are added, so that large simulations don't need
+// to keep every product in memory. It has the same counting methods as Products.
+// Zero value is ready to use.
+type Accumulator struct {
+	total    int
+	symbols  count.Counter[string]
+	counts   count.Counter[ZA]
+	isotopes map[ZA]*Isotope
+}
+
+// Add counts products.
+func (a *Accumulator) Add(prods ...*Isotope) {
+	for _, p := range prods {
+		a.AddN(p, 1)
+	}
+}
+
+// AddN counts n occurences of isotope.
+func (a *Accumulator) AddN(iso *Isotope, n int) {
+	if a.isotopes == nil {
+		a.symbols = count.New[string]()
+		a.counts = count.New[ZA]()
+		a.isotopes = make(map[ZA]*Isotope)
+	}
+	za := iso.ZA()
+	if _, ok := a.isotopes[za]; !ok {
+		cp := *iso
+		a.isotopes[za] = &cp
+	}
+	a.counts.Add(za, n)
+	a.symbols.Add(iso.Symbol, n)
+	a.total += n
+}
+
+// Len returns number of products counted.
+func (a *Accumulator) Len() int {
+	return a.total
+}
+
+// Count returns number of occurences of isotope name, e.g. "Xe-140".
+func (a *Accumulator) Count(name string) int {
+	za, err := ParseZA(name)
+	if err != nil {
+		return 0
+	}
+	return a.CountZA(za)
+}
+
+// CountZA returns number of occurences of nuclide za.
+func (a *Accumulator) CountZA(za ZA) int {
+	return a.counts[za]
+}
+
+// CountSymbols returns map of how many times each chemical element occured.
+func (a *Accumulator) CountSymbols() symbols {
+	sc := make(symbols, len(a.symbols))
+	maps.Copy(sc, a.symbols)
+	return sc
+}
+
+// CountIsotopes returns counts of isotopes grouped by element symbol.
+func (a *Accumulator) CountIsotopes() groups {
+	ic := make(groups)
+	for za, iso := range a.isotopes {
+		if ic[iso.Symbol] == nil {
+			ic[iso.Symbol] = make(map[string]int)
+		}
+		ic[iso.Symbol][iso.Name()] = a.counts[za]
+	}
+	return ic
+}
+
+// CountProbabilities returns occurence of each element symbol in percent.
+func (a *Accumulator) CountProbabilities() probabilities {
+	return newProbabilities(a.symbols)
+}
+
+// Save saves counts of elements and isotopes and probabilities of elements in format.
+func (a *Accumulator) Save(out OutputConfig, format Format) error {
+	if err := saveData(out, "symbols-count", format, a.CountSymbols()); err != nil {
+		return err
+	}
+	if err := saveData(out, "isotopes-count", format, a.CountIsotopes()); err != nil {
+		return err
+	}
+	return saveData(out, "probs", format, a.CountProbabilities())
+}
+
+// Counts returns number of occurences of each isotope, the most common first.
+// Isotopes with the same count are ordered by atomic and mass number.
+func (a *Accumulator) Counts() []Count {
+	return a.TopN(0)
+}
+
+// TopN returns k most common isotopes, all isotopes if k isn't positive.
+func (a *Accumulator) TopN(k int) []Count {
+	entries := a.counts.TopN(k)
+	counts := make([]Count, len(entries))
+	for i, e := range entries {
+		counts[i] = Count{Isotope: a.isotopes[e.Key], Count: e.Count}
+	}
+	return counts
+}
@@ -0,0 +1,264 @@
+//go:build !nochart
+
+package isotope
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/wcharczuk/go-chart/v2"
+	"github.com/wcharczuk/go-chart/v2/drawing"
+)
+
+// Saves bar chart of elements to image file
+func (sc symbols) SaveChart(out OutputConfig, opts ChartOptions) error {
+	return out.Save("products"+out.Image.Ext(), func(w io.Writer) error {
+		return sc.RenderChart(w, out.Image, opts)
+	})
+}
+
+// RenderChart renders bar chart of elements in format to w
+func (sc symbols) RenderChart(w io.Writer, format ImageFormat, opts ChartOptions) (err error) {
+	if opts.Renderer != nil {
+		return opts.Renderer.Bars(w, format, sc.Plot(opts), opts)
+	}
+	defer recoverChart(&err)
+
+	var values []chart.Value
+	for s, c := range sc {
+		values = append(values, chart.Value{Label: s, Value: float64(c)})
+	}
+
+	min, max := opts.yRange(0, 1000)
+	width, height := opts.size(2560, 1080)
+	graph := chart.BarChart{
+		Title: opts.title("Fission products"),
+		Background: chart.Style{
+			Padding: chart.Box{
+				Top:   50,
+				Right: -15,
+			},
+		},
+		Canvas: chart.Style{
+			FontSize: opts.fontSize(1),
+		},
+		YAxis: chart.YAxis{
+			Range: &chart.ContinuousRange{
+				Min: min,
+				Max: max,
+			},
+		},
+		Width:    width,
+		Height:   height,
+		BarWidth: opts.barWidth(10),
+		Bars:     opts.colorize(values),
+	}
+	return graph.Render(format.Renderer(), w)
+}
+
+// Saves each element symbol map to image file
+func (ic groups) SaveChart(out OutputConfig, opts ChartOptions) error {
+	for symbol := range ic {
+		err := out.Save(fmt.Sprintf("charts/%s%s", symbol, out.Image.Ext()), func(w io.Writer) error {
+			return ic.RenderChart(w, symbol, out.Image, opts)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderChart renders bar chart of isotopes of element symbol in format to w.
+// Bars are ordered by mass number and image is wide enough to label each of them.
+func (ic groups) RenderChart(w io.Writer, symbol string, format ImageFormat, opts ChartOptions) (err error) {
+	isotopes := ic[symbol]
+	if len(isotopes) == 0 {
+		return fmt.Errorf("no isotopes of element %q", symbol)
+	}
+	if opts.Renderer != nil {
+		return opts.Renderer.Bars(w, format, ic.Plot(symbol, opts), opts)
+	}
+	defer recoverChart(&err)
+
+	names := ic.names(symbol)
+
+	var values []chart.Value
+	var top float64
+	for _, name := range names {
+		v := float64(isotopes[name])
+		top = math.Max(top, v)
+		values = append(values, chart.Value{Label: name, Value: v})
+	}
+
+	const barWidth, spacing = 40, 20
+	bars := opts.barWidth(barWidth)
+	lo, hi := opts.yRange(0, math.Ceil(1.1*top))
+	width, height := opts.size(max(360, 120+len(values)*(bars+spacing)), 512)
+	graph := chart.BarChart{
+		Title: opts.title(symbol),
+		Background: chart.Style{
+			Padding: chart.Box{
+				Top: 50,
+			},
+		},
+		Canvas: chart.Style{
+			FontSize: opts.FontSize,
+		},
+		YAxis: chart.YAxis{
+			Range: &chart.ContinuousRange{
+				Min: lo,
+				Max: hi,
+			},
+		},
+		Width:      width,
+		Height:     height,
+		BarWidth:   bars,
+		BarSpacing: spacing,
+		Bars:       opts.colorize(values),
+	}
+	return graph.Render(format.Renderer(), w)
+}
+
+// Saves to image file
+func (probs probabilities) SaveChart(out OutputConfig, opts ChartOptions) error {
+	return out.Save("probs"+out.Image.Ext(), func(w io.Writer) error {
+		return probs.RenderChart(w, out.Image, opts)
+	})
+}
+
+// RenderChart renders donut chart of probabilities in format to w
+func (probs probabilities) RenderChart(w io.Writer, format ImageFormat, opts ChartOptions) (err error) {
+	if opts.Renderer != nil {
+		return opts.Renderer.Shares(w, format, probs.Plot(opts), opts)
+	}
+	defer recoverChart(&err)
+
+	var values []chart.Value
+	for k, v := range probs {
+		label := fmt.Sprintf("%s (%.3f ± %.3f)", k, v.Probability, v.StdErr) + "%"
+		values = append(values, chart.Value{Label: label, Value: v.Probability})
+	}
+
+	width, height := opts.size(3200, 1800)
+	pie := chart.DonutChart{
+		Title:  opts.title("Probability of occurence"),
+		Width:  width,
+		Height: height,
+		Values: opts.colorize(values),
+		Background: chart.Style{
+			FontSize:        opts.fontSize(0.1),
+			TextLineSpacing: 1,
+		},
+		Canvas: chart.Style{
+			FontSize:        opts.fontSize(0.1),
+			TextLineSpacing: 1,
+		},
+	}
+	return pie.Render(format.Renderer(), w)
+}
+
+// GoChart is Renderer of go-chart, which draws charts like the default ones.
+type GoChart struct{}
+
+// Bars renders bar chart of values in format to w
+func (GoChart) Bars(w io.Writer, format ImageFormat, p Plot, opts ChartOptions) (err error) {
+	defer recoverChart(&err)
+
+	var values []chart.Value
+	for _, v := range p.Values {
+		values = append(values, chart.Value{Label: v.Label, Value: v.Value})
+	}
+	graph := chart.BarChart{
+		Title:      p.Title,
+		Background: chart.Style{Padding: chart.Box{Top: 50}},
+		YAxis:      chart.YAxis{Name: p.YLabel},
+		Width:      max(360, 120+len(values)*30),
+		Height:     512,
+		BarWidth:   20,
+		Bars:       values,
+	}
+	opts.Chart(&graph)
+	return graph.Render(format.Renderer(), w)
+}
+
+// Shares renders donut chart of values in format to w
+func (GoChart) Shares(w io.Writer, format ImageFormat, p Plot, opts ChartOptions) (err error) {
+	defer recoverChart(&err)
+
+	var values []chart.Value
+	for _, v := range p.Values {
+		values = append(values, chart.Value{Label: v.Label, Value: v.Value})
+	}
+	width, height := opts.size(1600, 900)
+	pie := chart.DonutChart{
+		Title:  opts.title(p.Title),
+		Width:  width,
+		Height: height,
+		Values: opts.colorize(values),
+		Canvas: chart.Style{FontSize: opts.FontSize},
+	}
+	return pie.Render(format.Renderer(), w)
+}
+
+// Renderer returns go-chart renderer of format.
+func (f ImageFormat) Renderer() chart.RendererProvider {
+	if f == SVG {
+		return chart.SVG
+	}
+	return chart.PNG
+}
+
+// Chart applies options to go-chart bar chart, so charts of other packages follow them too.
+func (o ChartOptions) Chart(c *chart.BarChart) {
+	c.Title = o.title(c.Title)
+	c.Width, c.Height = o.size(c.Width, c.Height)
+	c.BarWidth = o.barWidth(c.BarWidth)
+	c.Canvas.FontSize = o.fontSize(c.Canvas.FontSize)
+	if o.YMax > o.YMin {
+		c.YAxis.Range = &chart.ContinuousRange{Min: o.YMin, Max: o.YMax}
+	}
+	c.Bars = o.colorize(c.Bars)
+}
+
+// Line applies options to go-chart line chart, colors are used for strokes of continuous series.
+func (o ChartOptions) Line(c *chart.Chart) {
+	c.Title = o.title(c.Title)
+	c.Width, c.Height = o.size(c.Width, c.Height)
+	c.Canvas.FontSize = o.fontSize(c.Canvas.FontSize)
+	if o.YMax > o.YMin {
+		c.YAxis.Range = &chart.ContinuousRange{Min: o.YMin, Max: o.YMax}
+	}
+	if len(o.Colors) == 0 {
+		return
+	}
+	for i, s := range c.Series {
+		if cs, ok := s.(chart.ContinuousSeries); ok {
+			color := drawing.ColorFromHex(strings.TrimPrefix(o.Colors[i%len(o.Colors)], "#"))
+			cs.Style.StrokeColor = color
+			c.Series[i] = cs
+		}
+	}
+}
+
+// colorize sets fill color of values from Colors.
+func (o ChartOptions) colorize(values []chart.Value) []chart.Value {
+	if len(o.Colors) == 0 {
+		return values
+	}
+	for i := range values {
+		c := drawing.ColorFromHex(strings.TrimPrefix(o.Colors[i%len(o.Colors)], "#"))
+		values[i].Style.FillColor = c
+		values[i].Style.StrokeColor = c
+	}
+	return values
+}
+
+// recoverChart turns panic of chart backend, e.g. because of missing fonts, into error.
+func recoverChart(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("%w: %v", ErrChartFailed, r)
+	}
+}
@@ -0,0 +1,50 @@
+//go:build nochart
+
+package isotope
+
+import "io"
+
+// Charts are excluded from builds with nochart tag, data outputs are still available.
+
+// GoChart is Renderer of go-chart, which draws charts like the default ones.
+type GoChart struct{}
+
+// Bars renders bar chart of values in format to w
+func (GoChart) Bars(w io.Writer, format ImageFormat, p Plot, opts ChartOptions) error {
+	return ErrChartsDisabled
+}
+
+// Shares renders donut chart of values in format to w
+func (GoChart) Shares(w io.Writer, format ImageFormat, p Plot, opts ChartOptions) error {
+	return ErrChartsDisabled
+}
+
+// Saves bar chart of elements to image file
+func (sc symbols) SaveChart(out OutputConfig, opts ChartOptions) error {
+	return ErrChartsDisabled
+}
+
+// RenderChart renders bar chart of elements in format to w
+func (sc symbols) RenderChart(w io.Writer, format ImageFormat, opts ChartOptions) error {
+	return ErrChartsDisabled
+}
+
+// Saves each element symbol map to image file
+func (ic groups) SaveChart(out OutputConfig, opts ChartOptions) error {
+	return ErrChartsDisabled
+}
+
+// RenderChart renders bar chart of isotopes of element symbol in format to w
+func (ic groups) RenderChart(w io.Writer, symbol string, format ImageFormat, opts ChartOptions) error {
+	return ErrChartsDisabled
+}
+
+// Saves to image file
+func (probs probabilities) SaveChart(out OutputConfig, opts ChartOptions) error {
+	return ErrChartsDisabled
+}
+
+// RenderChart renders donut chart of probabilities in format to w
+func (probs probabilities) RenderChart(w io.Writer, format ImageFormat, opts ChartOptions) error {
+	return ErrChartsDisabled
+}
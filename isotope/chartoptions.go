@@ -0,0 +1,63 @@
+package isotope
+
+// ChartOptions change appearance of charts. Zero fields keep defaults of each chart.
+type ChartOptions struct {
+	Title string
+
+	// Size of image in pixels.
+	Width  int
+	Height int
+
+	// BarWidth of bar charts in pixels.
+	BarWidth int
+
+	FontSize float64
+
+	// Range of value axis, used when YMax is greater than YMin.
+	YMin float64
+	YMax float64
+
+	// Colors of bars or slices as hex "#rrggbb", repeated when there are more values.
+	Colors []string
+
+	// Renderer renders charts instead of go-chart if it's set.
+	Renderer Renderer
+}
+
+func (o ChartOptions) title(def string) string {
+	if o.Title != "" {
+		return o.Title
+	}
+	return def
+}
+
+func (o ChartOptions) size(width, height int) (int, int) {
+	if o.Width > 0 {
+		width = o.Width
+	}
+	if o.Height > 0 {
+		height = o.Height
+	}
+	return width, height
+}
+
+func (o ChartOptions) barWidth(def int) int {
+	if o.BarWidth > 0 {
+		return o.BarWidth
+	}
+	return def
+}
+
+func (o ChartOptions) fontSize(def float64) float64 {
+	if o.FontSize > 0 {
+		return o.FontSize
+	}
+	return def
+}
+
+func (o ChartOptions) yRange(min, max float64) (float64, float64) {
+	if o.YMax > o.YMin {
+		return o.YMin, o.YMax
+	}
+	return min, max
+}
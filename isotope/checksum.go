@@ -0,0 +1,21 @@
+package isotope
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Checksum returns sha256 checksum of isotopes table, which changes when table is merged with other data.
+func Checksum() (string, error) {
+	isos, err := Isotopes()
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(isos)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,73 @@
+package isotope
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression of output files.
+type Compression string
+
+const (
+	NoCompression Compression = ""
+	Gzip          Compression = "gzip"
+	Zstd          Compression = "zstd"
+)
+
+// ParseCompression parses compression name "none", "gzip" or "zstd".
+func ParseCompression(s string) (Compression, error) {
+	switch c := Compression(strings.ToLower(s)); c {
+	case "none":
+		return NoCompression, nil
+	case NoCompression, Gzip, Zstd:
+		return c, nil
+	}
+	return "", fmt.Errorf("unknown compression %q", s)
+}
+
+// CompressionOf returns compression implied by extension of path, e.g. ".gz".
+func CompressionOf(path string) Compression {
+	switch filepath.Ext(path) {
+	case ".gz":
+		return Gzip
+	case ".zst":
+		return Zstd
+	}
+	return NoCompression
+}
+
+// Ext returns file name extension of compression.
+func (c Compression) Ext() string {
+	switch c {
+	case Gzip:
+		return ".gz"
+	case Zstd:
+		return ".zst"
+	}
+	return ""
+}
+
+// Writer returns writer compressing to w. It must be closed to flush compressed data,
+// w is not closed.
+func (c Compression) Writer(w io.Writer) (io.WriteCloser, error) {
+	switch c {
+	case NoCompression:
+		return nopCloser{w}, nil
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	case Zstd:
+		return zstd.NewWriter(w)
+	}
+	return nil, fmt.Errorf("unknown compression %q", c)
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
@@ -0,0 +1,95 @@
+package isotope
+
+import "physics/internal/enum"
+
+// CrossSections are microscopic cross sections of neutron absorption in barns.
+type CrossSections struct {
+	Fission float64 `json:"fission" yaml:"fission" toml:"fission"`
+	Capture float64 `json:"capture" yaml:"capture" toml:"capture"`
+}
+
+// Absorption returns cross section of absorption, fission and capture.
+func (cs CrossSections) Absorption() float64 {
+	return cs.Fission + cs.Capture
+}
+
+// Spectrum is energy spectrum of neutrons absorbed in fuel.
+type Spectrum int
+
+const (
+	// SpectrumThermal are neutrons of 0.0253 eV, of moderated reactor.
+	SpectrumThermal Spectrum = iota
+
+	// SpectrumFast are prompt fission neutrons, which are energetic enough to fission
+	// fertile U-238 and Th-232.
+	SpectrumFast
+)
+
+var spectra = enum.New[Spectrum]("spectrum", "thermal", "fast")
+
+// thermalCrossSections are evaluated cross sections at 0.0253 eV of ENDF/B-VIII.0,
+// fastCrossSections are averaged over spectrum of prompt fission neutrons.
+var (
+	thermalCrossSections = map[ZA]CrossSections{
+		{Number: 90, Mass: 232}: {Fission: 0, Capture: 7.35},
+		{Number: 91, Mass: 233}: {Fission: 0, Capture: 39.5},
+		{Number: 92, Mass: 233}: {Fission: 531.2, Capture: 45.5},
+		{Number: 92, Mass: 235}: {Fission: 585.1, Capture: 98.7},
+		{Number: 92, Mass: 238}: {Fission: 1.7e-5, Capture: 2.68},
+		{Number: 94, Mass: 239}: {Fission: 747.4, Capture: 270.3},
+		{Number: 94, Mass: 240}: {Fission: 0.06, Capture: 289.5},
+		{Number: 94, Mass: 241}: {Fission: 1012.3, Capture: 363.0},
+		{Number: 95, Mass: 241}: {Fission: 3.2, Capture: 684.0},
+	}
+	fastCrossSections = map[ZA]CrossSections{
+		{Number: 90, Mass: 232}: {Fission: 0.078, Capture: 0.09},
+		{Number: 91, Mass: 233}: {Fission: 0.03, Capture: 0.40},
+		{Number: 92, Mass: 233}: {Fission: 1.95, Capture: 0.07},
+		{Number: 92, Mass: 235}: {Fission: 1.28, Capture: 0.12},
+		{Number: 92, Mass: 238}: {Fission: 0.31, Capture: 0.07},
+		{Number: 94, Mass: 239}: {Fission: 1.80, Capture: 0.05},
+		{Number: 94, Mass: 240}: {Fission: 1.36, Capture: 0.15},
+		{Number: 94, Mass: 241}: {Fission: 1.65, Capture: 0.20},
+		{Number: 95, Mass: 241}: {Fission: 1.38, Capture: 0.40},
+	}
+)
+
+// CrossSections returns evaluated cross sections of isotope in spectrum and whether
+// isotope has them.
+func (iso Isotope) CrossSections(s Spectrum) (CrossSections, bool) {
+	table := thermalCrossSections
+	if s == SpectrumFast {
+		table = fastCrossSections
+	}
+	cs, ok := table[iso.ZA()]
+	return cs, ok
+}
+
+// ParseSpectrum parses spectrum name "thermal" or "fast".
+func ParseSpectrum(s string) (Spectrum, error) {
+	return spectra.Parse(s)
+}
+
+func (s Spectrum) String() string {
+	return spectra.String(s)
+}
+
+// Set implements flag.Value.
+func (s *Spectrum) Set(str string) error {
+	return spectra.Set(s, str)
+}
+
+// Type implements pflag.Value.
+func (s *Spectrum) Type() string {
+	return "spectrum"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Spectrum) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Spectrum) UnmarshalText(text []byte) error {
+	return s.Set(string(text))
+}
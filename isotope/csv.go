@@ -0,0 +1,47 @@
+package isotope
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"physics/count"
+)
+
+// WriteCSV writes rows of symbol and count sorted by symbol to w
+func (sc symbols) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"symbol", "count"})
+	for _, s := range count.SortedKeys(sc) {
+		cw.Write([]string{s, strconv.Itoa(sc[s])})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteCSV writes rows of symbol, isotope and count sorted by symbol and isotope to w
+func (ic groups) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"symbol", "isotope", "count"})
+	for _, s := range count.SortedKeys(ic) {
+		for _, name := range count.SortedKeys(ic[s]) {
+			cw.Write([]string{s, name, strconv.Itoa(ic[s][name])})
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteCSV writes rows of symbol and probability in percent with its standard error and
+// confidence interval sorted by symbol to w
+func (probs probabilities) WriteCSV(w io.Writer) error {
+	format := func(v float64) string { return strconv.FormatFloat(v, 'g', -1, 64) }
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"symbol", "probability", "std_err", "lower", "upper"})
+	for _, s := range count.SortedKeys(probs) {
+		p := probs[s]
+		cw.Write([]string{s, format(p.Probability), format(p.StdErr), format(p.Lower), format(p.Upper)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
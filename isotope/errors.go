@@ -0,0 +1,36 @@
+package isotope
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrChartsDisabled is returned by chart functions of builds with nochart tag.
+	ErrChartsDisabled = errors.New("charts are disabled in this build")
+
+	// ErrChartFailed is returned when chart backend fails to render a chart.
+	ErrChartFailed = errors.New("chart rendering failed")
+
+	// ErrIsotopeDataUnavailable is returned when isotopes table can't be loaded.
+	ErrIsotopeDataUnavailable = errors.New("isotope data unavailable")
+
+	// ErrNoMatchingFragment is returned when fission fragment has no equivalent in isotopes table.
+	// It's a gap in data, not a failure of fission model. Errors matching it are FragmentError.
+	ErrNoMatchingFragment = errors.New("fission fragment has no matching isotope")
+)
+
+// FragmentError is fission fragment of atomic number Z and mass number A, which has
+// no equivalent in isotopes table.
+type FragmentError struct {
+	Z, A int
+}
+
+func (e *FragmentError) Error() string {
+	return fmt.Sprintf("fission fragment Z=%d A=%d has no matching isotope", e.Z, e.A)
+}
+
+// Is reports whether target is ErrNoMatchingFragment.
+func (e *FragmentError) Is(target error) bool {
+	return target == ErrNoMatchingFragment
+}
@@ -0,0 +1,24 @@
+package isotope
+
+import (
+	"fmt"
+	"strings"
+)
+
+// String formats isotope like "U-235 (Z=92, A=235)".
+func (iso Isotope) String() string {
+	return fmt.Sprintf("%s (Z=%d, A=%d)", iso.Name(), iso.Number, iso.Mass)
+}
+
+// Summary returns multi-line description of products with number of elements and isotopes
+// and the most common isotopes.
+func (prods Products) Summary() string {
+	const top = 5
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d products, %d elements, %d isotopes", len(prods), len(prods.CountSymbols()), len(prods.Counts()))
+	for _, c := range prods.TopN(top) {
+		fmt.Fprintf(&b, "\n  %-8s %6d (%.2f%%)", c.Isotope.Name(), c.Count, 100*float64(c.Count)/float64(len(prods)))
+	}
+	return b.String()
+}
@@ -0,0 +1,33 @@
+package isotope
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ImageFormat of rendered charts.
+type ImageFormat string
+
+const (
+	PNG ImageFormat = "png"
+	SVG ImageFormat = "svg"
+)
+
+// ParseImageFormat parses image format name "png" or "svg".
+func ParseImageFormat(s string) (ImageFormat, error) {
+	switch f := ImageFormat(strings.ToLower(s)); f {
+	case "":
+		return PNG, nil
+	case PNG, SVG:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown image format %q", s)
+}
+
+// Ext returns file name extension of format, zero value is PNG.
+func (f ImageFormat) Ext() string {
+	if f == SVG {
+		return ".svg"
+	}
+	return ".png"
+}
@@ -1,28 +1,49 @@
 package isotope
 
 import (
+	"cmp"
 	"embed"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"math/rand"
-	"os"
 	"sync"
-	"time"
 
-	"github.com/mroth/weightedrand"
-	"github.com/wcharczuk/go-chart/v2"
+	"physics/count"
+	"physics/random"
 )
 
 // Isotope is a variant of a chemical element.
 type Isotope struct {
 	// Usually described as "X" in chemistry.
-	Symbol string `json:"symbol"`
+	Symbol string `json:"symbol" yaml:"symbol"`
 
 	// Atomic number is only number of protons. Described as "Z"
-	Number int `json:"atomic_number"`
+	Number int `json:"atomic_number" yaml:"atomic_number"`
 
 	// Mass is number of protons + neutrons. Described as "A".
-	Mass int `json:"mass_number"`
+	Mass int `json:"mass_number" yaml:"mass_number"`
+
+	// AtomicMass is mass of neutral atom in unified atomic mass units.
+	AtomicMass float64 `json:"atomic_mass,omitempty" yaml:"atomic_mass,omitempty"`
+
+	// Binding is total nuclear binding energy in MeV.
+	Binding float64 `json:"binding_energy,omitempty" yaml:"binding_energy,omitempty"`
+
+	// Abundance is natural abundance in percent.
+	Abundance float64 `json:"abundance,omitempty" yaml:"abundance,omitempty"`
+
+	// SpinParity of ground state, for example "7/2-".
+	SpinParity string `json:"spin_parity,omitempty" yaml:"spin_parity,omitempty"`
+
+	// Multiplicity is distribution of neutrons released in fission of isotope, evaluated
+	// distribution is used if it's empty, see NeutronMultiplicity.
+	Multiplicity Multiplicity `json:"multiplicity,omitempty" yaml:"multiplicity,omitempty"`
+
+	// Metadata are user attributes, e.g. inventory codes from external database.
+	// They are preserved in fission products of the isotope.
+	Metadata map[string]any `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 }
 
 // Fragment represents isotope without a symbol.
@@ -31,41 +52,104 @@ func Fragment(number, mass int) *Isotope {
 }
 
 // Fissiles is slice of fissile isotopes which have very heavy nucleus - so it is "fissionable".
+// Fertile U-238 and Th-232 are fissioned only by fast neutrons. Fissiles have their own
+// multiplicities and cross sections, but not yield parameters: fission models draw fragments
+// from compound nucleus of any fissile the same way, and evaluated yields of a fissile are
+// read from ENDF-6 file of Config.Yields.
 func Fissiles() []*Isotope {
-	return []*Isotope{U233(), U235(), P239()}
+	return []*Isotope{U233(), U235(), P239(), P241(), U238(), Th232(), Am241()}
 }
 
 // U235 is Uranium-235 isotope.
 func U235() *Isotope {
 	return &Isotope{
-		Symbol: "U",
-		Number: 92,
-		Mass:   235,
+		Symbol:     "U",
+		Number:     92,
+		Mass:       235,
+		AtomicMass: 235.0439299,
+		Binding:    1783.870,
+		Abundance:  0.7204,
+		SpinParity: "7/2-",
 	}
 }
 
-// U235 is Uranium-233 isotope.
+// U233 is Uranium-233 isotope.
 func U233() *Isotope {
 	return &Isotope{
-		Symbol: "U",
-		Number: 92,
-		Mass:   233,
+		Symbol:     "U",
+		Number:     92,
+		Mass:       233,
+		AtomicMass: 233.0396352,
+		Binding:    1771.726,
+		SpinParity: "5/2+",
 	}
 }
 
 // P239 is Plutonium-239 isotope.
 func P239() *Isotope {
 	return &Isotope{
-		Symbol: "P",
-		Number: 94,
-		Mass:   239,
+		Symbol:     "Pu",
+		Number:     94,
+		Mass:       239,
+		AtomicMass: 239.0521634,
+		Binding:    1806.922,
+		SpinParity: "1/2+",
+	}
+}
+
+// U238 is Uranium-238 isotope.
+func U238() *Isotope {
+	return &Isotope{
+		Symbol:     "U",
+		Number:     92,
+		Mass:       238,
+		AtomicMass: 238.0507884,
+		Binding:    1801.690,
+		Abundance:  99.2742,
+		SpinParity: "0+",
+	}
+}
+
+// P241 is Plutonium-241 isotope.
+func P241() *Isotope {
+	return &Isotope{
+		Symbol:     "Pu",
+		Number:     94,
+		Mass:       241,
+		AtomicMass: 241.0568517,
+		Binding:    1818.692,
+		SpinParity: "5/2+",
+	}
+}
+
+// Th232 is Thorium-232 isotope.
+func Th232() *Isotope {
+	return &Isotope{
+		Symbol:     "Th",
+		Number:     90,
+		Mass:       232,
+		AtomicMass: 232.0380553,
+		Binding:    1766.688,
+		Abundance:  100,
+		SpinParity: "0+",
+	}
+}
+
+// Am241 is Americium-241 isotope.
+func Am241() *Isotope {
+	return &Isotope{
+		Symbol:     "Am",
+		Number:     95,
+		Mass:       241,
+		AtomicMass: 241.0568293,
+		Binding:    1817.930,
+		SpinParity: "5/2-",
 	}
 }
 
 // Returns random fissionable isotope from isotopes list.
 func Random() *Isotope {
 	isos := Fissiles()
-	rand.Seed(time.Now().UnixNano())
 	iso := isos[rand.Intn(len(isos))]
 	return iso
 }
@@ -76,37 +160,145 @@ type Products []*Isotope
 // It is caused by inducing neutron to the nucleus of an isotope.
 // Returns products and neutrons released during fission operation.
 func (iso Isotope) Destabilize() (Products, int, error) {
+	return iso.DestabilizeRand(random.Global)
+}
+
+// DestabilizeRand destabilizes nucleus like Destabilize, drawing random numbers from rng,
+// so fissions are reproducible with seeded rng.
+func (iso Isotope) DestabilizeRand(rng random.Rand) (Products, int, error) {
+	multiplicity := iso.NeutronMultiplicity()
 	// increase amu of isotope by one
 	iso.induceNeutron()
 
+	neutrons := multiplicity.Sample(rng)
+	heavier := iso.heavier(rng, neutrons)
+	return iso.split(Fragment(heavier.Number, heavier.Mass), neutrons)
+}
+
+// heavier draws heavier fragment of compound nucleus which releases neutrons.
+func (iso Isotope) heavier(rng random.Rand, neutrons int) ZA {
 	// Randomize mass of first fragment based on neutrons released
-	neutrons := randomNeutron()
-	amu := rand.Intn((iso.Mass-neutrons)-iso.Mass/2) + iso.Mass/2
+	amu := rng.Intn((iso.Mass-neutrons)-iso.Mass/2) + iso.Mass/2
+	return ZA{Number: (iso.Number * ((amu * 100) / iso.Mass)) / 100, Mass: amu}
+}
 
-	// Heavier and lighter fission fragments
-	heavier := Fragment((iso.Number*((amu*100)/iso.Mass))/100, amu)
-	lighter := Fragment(iso.Number-heavier.Number, iso.Mass-neutrons-amu)
+// stratified draws heavier fragment like heavier, from stratum of opts if it has strata, and
+// returns it with weight of stratum. Strata are at most as many as masses, so none is empty.
+func (iso Isotope) stratified(rng random.Rand, neutrons int, opts Options) (ZA, float64) {
+	lo, n := iso.Mass/2, (iso.Mass-neutrons)-iso.Mass/2
+	strata := min(opts.Strata, n)
+	if strata <= 1 {
+		return iso.heavier(rng, neutrons), 1
+	}
+	k := opts.Stratum % strata
+	from, to := k*n/strata, (k+1)*n/strata
+	amu := rng.Intn(to-from) + lo + from
+	weight := float64((to-from)*strata) / float64(n)
+	return ZA{Number: (iso.Number * ((amu * 100) / iso.Mass)) / 100, Mass: amu}, weight
+}
 
-	// Search each fragment isotope equivalent in isotopes
-	isos, err := Isotopes()
-	if err != nil {
-		return nil, 0, err
+// Tally is aggregated outcome of many fissions.
+type Tally struct {
+	Products Accumulator
+
+	// Fissions produced known isotopes, Failures didn't.
+	Fissions int
+	Failures int
+
+	// Neutrons maps multiplicity to number of fissions.
+	Neutrons count.Counter[int]
+}
+
+// Nu returns mean number of neutrons released per fission.
+func (t *Tally) Nu() float64 {
+	if t.Fissions == 0 {
+		return 0
 	}
-	for _, iso := range isos {
-		if iso.Mass == heavier.Mass && iso.Number == heavier.Number {
-			heavier.Symbol = iso.Symbol
+	sum := 0
+	for n, c := range t.Neutrons {
+		sum += n * c
+	}
+	return float64(sum) / float64(t.Fissions)
+}
+
+// DestabilizeN destabilizes n nuclei like n calls of DestabilizeRand with the same rng,
+// but returns only their tally. Products of each fission aren't allocated and isotopes
+// table is searched directly, so it's much faster for large n.
+func (iso Isotope) DestabilizeN(n int, rng random.Rand) (*Tally, error) {
+	if _, err := Isotopes(); err != nil {
+		return nil, err
+	}
+	multiplicity := iso.NeutronMultiplicity()
+	iso.induceNeutron()
+
+	t := &Tally{Neutrons: count.New[int]()}
+	for i := 0; i < n; i++ {
+		neutrons := multiplicity.Sample(rng)
+		h := iso.heavier(rng, neutrons)
+		first, ok := index[h]
+		if !ok || first.Symbol == "" {
+			t.Failures++
+			continue
 		}
-		if iso.Mass == lighter.Mass && iso.Number == lighter.Number {
-			lighter.Symbol = iso.Symbol
+		second, ok := index[ZA{Number: iso.Number - h.Number, Mass: iso.Mass - neutrons - h.Mass}]
+		if !ok || second.Symbol == "" {
+			t.Failures++
+			continue
 		}
+		t.Products.AddN(first, 1)
+		t.Products.AddN(second, 1)
+		t.Fissions++
+		t.Neutrons.Add(neutrons, 1)
+	}
+	return t, nil
+}
+
+// FragmentSampler samples atomic and mass number of a fission fragment, e.g. from evaluated yields.
+type FragmentSampler interface {
+	SampleFragment(rng random.Rand) (number, mass int)
+}
+
+// DestabilizeWith destabilizes nucleus like DestabilizeRand, but first fragment is drawn from
+// sampler. Second fragment is what remains of compound nucleus after neutrons are released.
+func (iso Isotope) DestabilizeWith(s FragmentSampler, rng random.Rand) (Products, int, error) {
+	multiplicity := iso.NeutronMultiplicity()
+	iso.induceNeutron()
+
+	neutrons := multiplicity.Sample(rng)
+	number, mass := s.SampleFragment(rng)
+	if number <= 0 || number >= iso.Number || mass <= 0 || mass >= iso.Mass-neutrons {
+		return nil, 0, fmt.Errorf("sampled fragment Z=%d A=%d can not be produced by %s", number, mass, iso.Name())
+	}
+	return iso.split(Fragment(number, mass), neutrons)
+}
+
+// split completes fission of compound nucleus into first fragment, its complement and neutrons.
+func (iso Isotope) split(first *Isotope, neutrons int) (Products, int, error) {
+	second := Fragment(iso.Number-first.Number, iso.Mass-neutrons-first.Mass)
+
+	// Search each fragment isotope equivalent in isotopes
+	if _, err := Isotopes(); err != nil {
+		return nil, 0, err
+	}
+	if iso, ok := Lookup(first.Number, first.Mass); ok {
+		h := *iso
+		first = &h
+	}
+	if iso, ok := Lookup(second.Number, second.Mass); ok {
+		l := *iso
+		second = &l
 	}
 	var prods Products
-	// if heavier and lighter fragment has an equivalent, add it to products slice
-	if heavier.Symbol != "" && lighter.Symbol != "" {
-		prods = append(prods, heavier, lighter)
+	// if first and second fragment has an equivalent, add it to products slice
+	if first.Symbol != "" && second.Symbol != "" {
+		prods = append(prods, first, second)
 		return prods, neutrons, nil
 	}
-	return nil, 0, fmt.Errorf("first or second fragment of a fission reaction does not have equivalent as an isotope")
+	unmatched := first
+	if first.Symbol != "" {
+		unmatched = second
+	}
+	return nil, 0, &FragmentError{Z: unmatched.Number, A: unmatched.Mass}
 }
 
 // Name is symbol of an isotope + it's atomic mass number
@@ -115,18 +307,114 @@ func (iso *Isotope) Name() string {
 }
 
 // Isotopes returns slice of parsed isotopes from isotopes.json file.
-// Parsing occurs only once.
+// Parsing occurs only once. Error matches ErrIsotopeDataUnavailable.
 func Isotopes() ([]*Isotope, error) {
 	once.Do(func() {
 		data, err := file.ReadFile("isotopes.json")
 		if err != nil {
+			loadErr = fmt.Errorf("%w: %v", ErrIsotopeDataUnavailable, err)
 			return
 		}
 		var isos []*Isotope
-		json.Unmarshal(data, &isos)
+		if err := json.Unmarshal(data, &isos); err != nil {
+			loadErr = fmt.Errorf("%w: isotopes.json: %v", ErrIsotopeDataUnavailable, err)
+			return
+		}
 		instance = isos
+		index = make(map[ZA]*Isotope, len(isos))
+		numbers = make(map[string]int)
+		for _, iso := range isos {
+			index[iso.ZA()] = iso
+			numbers[iso.Symbol] = iso.Number
+		}
+		indexElements(isos)
 	})
-	return instance, nil
+	return instance, loadErr
+}
+
+// ZA identifies nuclide by its atomic and mass number.
+type ZA struct {
+	Number int
+	Mass   int
+}
+
+// Compare orders nuclides by atomic number, nuclides of the same element by mass number.
+func (za ZA) Compare(other ZA) int {
+	if za.Number != other.Number {
+		return cmp.Compare(za.Number, other.Number)
+	}
+	return cmp.Compare(za.Mass, other.Mass)
+}
+
+// ZA returns atomic and mass number of an isotope.
+func (iso *Isotope) ZA() ZA {
+	return ZA{Number: iso.Number, Mass: iso.Mass}
+}
+
+// Lookup returns isotope of atomic number and mass number from isotopes table.
+func Lookup(number, mass int) (*Isotope, bool) {
+	if _, err := Isotopes(); err != nil {
+		return nil, false
+	}
+	iso, ok := index[ZA{Number: number, Mass: mass}]
+	return iso, ok
+}
+
+// Merge merges isotopes into isotopes table. Known isotopes get properties which are set
+// in merged isotope, unknown isotopes are appended to the table.
+func Merge(isos []*Isotope) error {
+	table, err := Isotopes()
+	if err != nil {
+		return err
+	}
+	for _, iso := range isos {
+		if t, ok := index[iso.ZA()]; ok {
+			t.merge(iso)
+			continue
+		}
+		c := *iso
+		table = append(table, &c)
+		index[c.ZA()] = &c
+		if c.Symbol != "" {
+			numbers[c.Symbol] = c.Number
+		}
+	}
+	instance = table
+	indexElements(table)
+	return nil
+}
+
+// indexElements indexes named isotopes of table by atomic number, in order of table.
+func indexElements(table []*Isotope) {
+	byNumber = make(map[int][]*Isotope)
+	maxNumber = 0
+	for _, iso := range table {
+		if iso.Symbol != "" {
+			byNumber[iso.Number] = append(byNumber[iso.Number], iso)
+			maxNumber = max(maxNumber, iso.Number)
+		}
+	}
+}
+
+func (iso *Isotope) merge(other *Isotope) {
+	if other.Symbol != "" {
+		iso.Symbol = other.Symbol
+	}
+	if other.AtomicMass != 0 {
+		iso.AtomicMass = other.AtomicMass
+	}
+	if other.Binding != 0 {
+		iso.Binding = other.Binding
+	}
+	if other.Abundance != 0 {
+		iso.Abundance = other.Abundance
+	}
+	if other.SpinParity != "" {
+		iso.SpinParity = other.SpinParity
+	}
+	for k, v := range other.Metadata {
+		iso.SetMeta(k, v)
+	}
 }
 
 // CountSymbols returns map of how many times each chemical element occured.
@@ -138,6 +426,11 @@ func (prods Products) CountSymbols() symbols {
 	return sc
 }
 
+// SymbolCounts returns counts of element symbols m, e.g. loaded from saved results.
+func SymbolCounts(m map[string]int) symbols {
+	return symbols(m)
+}
+
 // CountIsotopes returns map of element symbols and isotopes of this element, and how many times that isotope appears in products
 func (prods Products) CountIsotopes() groups {
 	ic := make(groups)
@@ -157,147 +450,99 @@ func (prods Products) CountIsotopes() groups {
 // CountProbabilities creates a map of element symbol key and avg occurence in percent value
 func (prods Products) CountProbabilities() probabilities {
 	// symbol : count map
-	sc := prods.CountSymbols()
+	return prods.CountSymbols().Probabilities()
+}
 
-	// sum of every element occurence
-	sum := 0
-	for _, c := range sc {
-		sum += c
-	}
-	// elements avg map
+// Probabilities creates a map of element symbol key and avg occurence in percent value
+func (sc symbols) Probabilities() probabilities {
+	return newProbabilities(count.Counter[string](sc))
+}
+
+// newProbabilities returns occurence of each element symbol of counts in percent.
+func newProbabilities(counts count.Counter[string]) probabilities {
 	probs := make(probabilities)
-	for s, c := range sc {
-		v := (float64(c) / float64(sum)) * 100 // calculate avg
-		probs[s] = v
+	total := counts.Total()
+	for s, f := range counts.Normalize() {
+		probs[s] = NewProbability(f, total)
 	}
 	return probs
 }
 
-// Saves to .json file
-func (sc symbols) SaveJson() error {
-	data, err := json.MarshalIndent(sc, "", " ")
-	if err != nil {
-		return err
+// Probability of element among products in percent, with its binomial standard error and
+// bounds of 95% Wilson score confidence interval, which is valid for rare elements too.
+type Probability struct {
+	Probability float64 `json:"probability" yaml:"probability"`
+	StdErr      float64 `json:"std_err" yaml:"std_err"`
+	Lower       float64 `json:"lower" yaml:"lower"`
+	Upper       float64 `json:"upper" yaml:"upper"`
+}
+
+// NewProbability returns probability of fraction p of n products.
+func NewProbability(p float64, n int) Probability {
+	if n == 0 {
+		return Probability{Probability: 100 * p, Lower: 100 * p, Upper: 100 * p}
 	}
-	return os.WriteFile("symbols-count.json", data, 0777)
+	const z = 1.96 // two-sided 95% quantile of normal distribution
+	nf := float64(n)
+	se := math.Sqrt(p * (1 - p) / nf)
+	center := (p + z*z/(2*nf)) / (1 + z*z/nf)
+	half := z / (1 + z*z/nf) * math.Sqrt(p*(1-p)/nf+z*z/(4*nf*nf))
+	return Probability{Probability: 100 * p, StdErr: 100 * se, Lower: 100 * max(center-half, 0), Upper: 100 * min(center+half, 1)}
 }
 
-// Saves to .json file
-func (ic groups) SaveJson() error {
-	data, err := json.MarshalIndent(ic, "", " ")
-	if err != nil {
-		return err
+// Percent returns probabilities of elements in percent without their errors.
+func (probs probabilities) Percent() map[string]float64 {
+	m := make(map[string]float64, len(probs))
+	for s, p := range probs {
+		m[s] = p.Probability
 	}
-	return os.WriteFile("isotopes-count.json", data, 0777)
+	return m
 }
 
-// Saves to .json file
-func (probs probabilities) SaveJson() error {
-	data, err := json.MarshalIndent(probs, "", " ")
-	if err != nil {
-		return err
+// Merge returns products of both runs.
+func (prods Products) Merge(other Products) Products {
+	merged := make(Products, 0, len(prods)+len(other))
+	return append(append(merged, prods...), other...)
+}
+
+// Add merges other probabilities. Probabilities are weighted by number of
+// products they were counted from, total for probs and otherTotal for other.
+func (probs probabilities) Add(other probabilities, total, otherTotal int) {
+	sum := float64(total + otherTotal)
+	if sum == 0 {
+		return
 	}
-	return os.WriteFile("probs.json", data, 0777)
-}
-
-// Saves bar chart of elements to png file
-func (sc symbols) SaveChart() {
-	var values []chart.Value
-	for s, c := range sc {
-		values = append(values, chart.Value{Label: s, Value: float64(c)})
-	}
-
-	graph := chart.BarChart{
-		Title: "Fission products",
-		Background: chart.Style{
-			Padding: chart.Box{
-				Top:   50,
-				Right: -15,
-			},
-		},
-		Canvas: chart.Style{
-			FontSize: 1,
-		},
-		YAxis: chart.YAxis{
-			Range: &chart.ContinuousRange{
-				Min: 0.0,
-				Max: 1000,
-			},
-		},
-		Width:    2560,
-		Height:   1080,
-		BarWidth: 10,
-		Bars:     values,
-	}
-	f, _ := os.Create("products.png")
-	defer f.Close()
-	graph.Render(chart.PNG, f)
-}
-
-// Saves each element symbol map to png file
-func (ic groups) SaveChart() {
-	for symbol, isotope := range ic {
-		var values []chart.Value
-
-		for name, number := range isotope {
-			values = append(values, chart.Value{Label: name, Value: float64(number)})
-
-			graph := chart.BarChart{
-				Title: symbol,
-				Background: chart.Style{
-					Padding: chart.Box{
-						Top: 50,
-					},
-				},
-				YAxis: chart.YAxis{
-					Range: &chart.ContinuousRange{
-						Min: 0.0,
-						Max: 15000,
-					},
-				},
-				Width:  720,
-				Height: 512,
-				Bars:   values,
-			}
-
-			f, _ := os.Create(fmt.Sprintf("charts/%s.png", symbol))
-			defer f.Close()
-			graph.Render(chart.PNG, f)
-		}
+	merged := make(map[string]float64, len(probs))
+	for s, v := range probs {
+		merged[s] = v.Probability * float64(total) / sum
+	}
+	for s, v := range other {
+		merged[s] += v.Probability * float64(otherTotal) / sum
+	}
+	for s, v := range merged {
+		probs[s] = NewProbability(v/100, total+otherTotal)
 	}
 }
 
-// Saves to png file
-func (probs probabilities) SaveChart() {
-	var values []chart.Value
-	for k, v := range probs {
-		label := fmt.Sprintf("%s (%.3f)", k, v) + "%"
-		values = append(values, chart.Value{Label: label, Value: v})
-	}
+// WriteJSON writes indented json to w
+func (sc symbols) WriteJSON(w io.Writer) error {
+	return writeJSON(w, sc)
+}
 
-	pie := chart.DonutChart{
-		Title:  "Probability of occurence",
-		Width:  3200,
-		Height: 1800,
-		Values: values,
-		Background: chart.Style{
-			FontSize:        0.1,
-			TextLineSpacing: 1,
-		},
-		Canvas: chart.Style{
-			FontSize:        0.1,
-			TextLineSpacing: 1,
-		},
-	}
-	f, _ := os.Create("probs.png")
-	defer f.Close()
-	pie.Render(chart.PNG, f)
+// WriteJSON writes indented json to w
+func (ic groups) WriteJSON(w io.Writer) error {
+	return writeJSON(w, ic)
+}
+
+// WriteJSON writes indented json to w
+func (probs probabilities) WriteJSON(w io.Writer) error {
+	return writeJSON(w, probs)
 }
 
 type (
 	groups        map[string]map[string]int
 	symbols       map[string]int
-	probabilities map[string]float64
+	probabilities map[string]Probability
 )
 
 func (iso *Isotope) induceNeutron() {
@@ -309,16 +554,11 @@ var file embed.FS
 
 var (
 	instance []*Isotope // singleton
+	loadErr  error
+	index    map[ZA]*Isotope
+	numbers  map[string]int // element symbol : atomic number
 	once     sync.Once
-)
 
-func randomNeutron() int {
-	rand.Seed(time.Now().UnixNano())
-	chooser, _ := weightedrand.NewChooser(
-		weightedrand.NewChoice(3, 10), // 3 neutrons - 0.1
-		weightedrand.NewChoice(2, 30), // 2 neutrons - 0.3
-		weightedrand.NewChoice(1, 60), // 1 neutron - 0.6
-	)
-	n, _ := chooser.Pick().(int)
-	return n
-}
+	byNumber  map[int][]*Isotope // atomic number : named isotopes
+	maxNumber int                // the highest atomic number of elements
+)
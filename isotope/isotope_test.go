@@ -0,0 +1,41 @@
+package isotope
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func BenchmarkDestabilizeRand(b *testing.B) {
+	iso, rng := U235(), rand.New(rand.NewSource(1))
+	b.ReportAllocs()
+	for range b.N {
+		iso.DestabilizeRand(rng)
+	}
+}
+
+func BenchmarkDestabilizePolicy(b *testing.B) {
+	iso, rng := U235(), rand.New(rand.NewSource(1))
+	b.ReportAllocs()
+	for range b.N {
+		iso.DestabilizePolicy(rng, FragmentNearest)
+	}
+}
+
+// BenchmarkDestabilizeN reports time per fission.
+func BenchmarkDestabilizeN(b *testing.B) {
+	iso, rng := U235(), rand.New(rand.NewSource(1))
+	b.ReportAllocs()
+	if _, err := iso.DestabilizeN(b.N, rng); err != nil {
+		b.Fatal(err)
+	}
+}
+
+func BenchmarkDestabilizeAppend(b *testing.B) {
+	iso, rng := U235(), rand.New(rand.NewSource(1))
+	prods := make(Products, 0, 2)
+	opts := Options{Fragments: FragmentNearest, KineticEnergy: true}
+	b.ReportAllocs()
+	for range b.N {
+		iso.DestabilizeAppend(prods, rng, opts)
+	}
+}
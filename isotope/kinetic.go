@@ -0,0 +1,53 @@
+package isotope
+
+import (
+	"math"
+
+	"physics/random"
+)
+
+const (
+	// coulomb is e²/4πε₀ in MeV fm.
+	coulomb = 1.44
+
+	// scissionRadius is distance of centers of fragments at scission per A^(1/3) of each
+	// fragment in fm, so that TKE of thermal fission of U-235 is about 170 MeV.
+	scissionRadius = 1.83
+
+	// tkeWidth is standard deviation of TKE of a mass split in MeV.
+	tkeWidth = 8.0
+)
+
+// TKE returns mean total kinetic energy in MeV of primary fragments of a fission, which is
+// their Coulomb repulsion at scission. It's the highest for symmetric splits.
+func TKE(first, second ZA) float64 {
+	if first.Number <= 0 || second.Number <= 0 || first.Mass <= 0 || second.Mass <= 0 {
+		return 0
+	}
+	d := scissionRadius * (math.Cbrt(float64(first.Mass)) + math.Cbrt(float64(second.Mass)))
+	return coulomb * float64(first.Number*second.Number) / d
+}
+
+// kineticEnergy draws total kinetic energy of primary fragments and splits it between them
+// by conservation of momentum, so the lighter fragment is faster and has more energy.
+func kineticEnergy(rng random.Rand, primary [2]ZA) [2]float64 {
+	g := rng.NormFloat64()
+	tke := TKE(primary[0], primary[1])
+	if tke == 0 {
+		return [2]float64{}
+	}
+	tke = max(0, tke+tkeWidth*g)
+	a := float64(primary[0].Mass + primary[1].Mass)
+	return [2]float64{tke * float64(primary[1].Mass) / a, tke * float64(primary[0].Mass) / a}
+}
+
+// capEnergy scales kinetic energy of fragments down to q, energy released by fission, keeping
+// their ratio, so that drawn TKE doesn't exceed energy which is available.
+func capEnergy(energy [2]float64, q float64) [2]float64 {
+	q = max(q, 0)
+	tke := energy[0] + energy[1]
+	if tke <= q {
+		return energy
+	}
+	return [2]float64{energy[0] * q / tke, energy[1] * q / tke}
+}
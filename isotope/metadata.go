@@ -0,0 +1,24 @@
+package isotope
+
+// Meta returns user metadata value of key.
+func (iso *Isotope) Meta(key string) (any, bool) {
+	v, ok := iso.Metadata[key]
+	return v, ok
+}
+
+// SetMeta sets user metadata value of key. Metadata map is copied first, because
+// products share it with isotopes table they were created from.
+func (iso *Isotope) SetMeta(key string, value any) {
+	m := make(map[string]any, len(iso.Metadata)+1)
+	for k, v := range iso.Metadata {
+		m[k] = v
+	}
+	m[key] = value
+	iso.Metadata = m
+}
+
+// MetaValue returns metadata value of key if it's of type T.
+func MetaValue[T any](iso *Isotope, key string) (T, bool) {
+	v, ok := iso.Metadata[key].(T)
+	return v, ok
+}
@@ -0,0 +1,41 @@
+package isotope
+
+import "physics/random"
+
+// FissionModel is physics of fission, how neutrons, fragments and their kinetic energy are
+// drawn, so alternative models can be used by DestabilizeAppend through Options.
+type FissionModel interface {
+	// SampleNeutrons draws number of prompt neutrons released in fission of target, the
+	// nucleus before it absorbed neutron. It isn't used by sawtooth neutron model, whose
+	// fragments evaporate neutrons.
+	SampleNeutrons(rng random.Rand, target Isotope) int
+
+	// SampleFragments draws heavier fragment of compound nucleus which releases neutrons, the
+	// lighter one is what remains, from stratum of opts if it has strata. It returns fragment
+	// with weight of its stratum, one if fission isn't stratified.
+	SampleFragments(rng random.Rand, compound Isotope, neutrons int, opts Options) (ZA, float64)
+
+	// SampleEnergy draws kinetic energy of each primary fragment in MeV. DestabilizeAppend caps
+	// their sum at Q value of fission.
+	SampleEnergy(rng random.Rand, primary [2]ZA) [2]float64
+}
+
+// SimpleModel is the default fission model, neutrons are drawn from evaluated multiplicity,
+// mass of heavier fragment uniformly and its charge in proportion to compound nucleus, and
+// TKE around Coulomb repulsion of fragments at scission.
+type SimpleModel struct{}
+
+// SampleNeutrons draws neutrons from multiplicity of target.
+func (SimpleModel) SampleNeutrons(rng random.Rand, target Isotope) int {
+	return target.NeutronMultiplicity().Sample(rng)
+}
+
+// SampleFragments draws mass of heavier fragment uniformly from the heavier half of masses.
+func (SimpleModel) SampleFragments(rng random.Rand, compound Isotope, neutrons int, opts Options) (ZA, float64) {
+	return compound.stratified(rng, neutrons, opts)
+}
+
+// SampleEnergy draws TKE normally around TKE of primary fragments.
+func (SimpleModel) SampleEnergy(rng random.Rand, primary [2]ZA) [2]float64 {
+	return kineticEnergy(rng, primary)
+}
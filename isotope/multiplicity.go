@@ -0,0 +1,161 @@
+package isotope
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"physics/random"
+)
+
+// Multiplicity is distribution of number of neutrons released in fission, value at index n
+// is relative probability of n neutrons. Probabilities needn't sum to one.
+type Multiplicity []float64
+
+// Terrell returns distribution of neutrons of Terrell's approximation, which is gaussian of
+// width sigma discretized so that mean number of neutrons is nu.
+func Terrell(nu, sigma float64) Multiplicity {
+	// cumulative probability of at most n neutrons is Φ((n - nu + 1/2 + b) / sigma),
+	// b is found by bisection so that mean of distribution is nu
+	terrell := func(b float64) Multiplicity {
+		var m Multiplicity
+		prev := 0.0
+		for n := 0; prev < 1-1e-7; n++ {
+			cdf := 0.5 * (1 + math.Erf((float64(n)-nu+0.5+b)/(sigma*math.Sqrt2)))
+			m = append(m, cdf-prev)
+			prev = cdf
+		}
+		return m
+	}
+	lo, hi := -1.0, 1.0
+	for i := 0; i < 60; i++ {
+		b := (lo + hi) / 2
+		if terrell(b).Nu() > nu {
+			lo = b
+		} else {
+			hi = b
+		}
+	}
+	return terrell((lo + hi) / 2)
+}
+
+// multiplicities are evaluated distributions of neutrons of thermal fission, with mean
+// number of neutrons of ENDF/B-VIII.0 and widths of Holden and Zucker. Fertile U-238 and
+// Th-232 have distributions of fission by 2 MeV neutrons.
+var multiplicities = map[ZA]Multiplicity{
+	{Number: 90, Mass: 232}: Terrell(2.19, 1.100),
+	{Number: 92, Mass: 233}: Terrell(2.4968, 1.070),
+	{Number: 92, Mass: 235}: Terrell(2.4367, 1.088),
+	{Number: 92, Mass: 238}: Terrell(2.62, 1.230),
+	{Number: 94, Mass: 239}: Terrell(2.8794, 1.140),
+	{Number: 94, Mass: 241}: Terrell(2.9453, 1.150),
+	{Number: 95, Mass: 241}: Terrell(3.22, 1.150),
+}
+
+// NeutronMultiplicity returns distribution of neutrons released in fission of isotope. It's
+// Multiplicity of isotope if it's set, otherwise evaluated distribution of isotope, or of U-235
+// for isotopes which have none.
+func (iso Isotope) NeutronMultiplicity() Multiplicity {
+	if len(iso.Multiplicity) > 0 {
+		return iso.Multiplicity
+	}
+	if m, ok := multiplicities[iso.ZA()]; ok {
+		return m
+	}
+	return multiplicities[ZA{Number: 92, Mass: 235}]
+}
+
+// Nu returns mean number of neutrons.
+func (m Multiplicity) Nu() float64 {
+	total, sum := 0.0, 0.0
+	for n, p := range m {
+		total += p
+		sum += float64(n) * p
+	}
+	if total == 0 {
+		return 0
+	}
+	return sum / total
+}
+
+// Probability returns normalized probability of n neutrons.
+func (m Multiplicity) Probability(n int) float64 {
+	total := 0.0
+	for _, p := range m {
+		total += p
+	}
+	if n < 0 || n >= len(m) || total == 0 {
+		return 0
+	}
+	return m[n] / total
+}
+
+// Validate returns error if distribution has negative or non finite probabilities,
+// or none that is positive.
+func (m Multiplicity) Validate() error {
+	total := 0.0
+	for n, p := range m {
+		if p < 0 || math.IsNaN(p) || math.IsInf(p, 0) {
+			return fmt.Errorf("multiplicity: invalid probability %g of %d neutrons", p, n)
+		}
+		total += p
+	}
+	if total == 0 {
+		return errors.New("multiplicity: no probability is positive")
+	}
+	return nil
+}
+
+// Sample draws number of neutrons from rng.
+func (m Multiplicity) Sample(rng random.Rand) int {
+	total := 0.0
+	for _, p := range m {
+		total += p
+	}
+	u := rng.Float64() * total
+	for n, p := range m {
+		if u < p {
+			return n
+		}
+		u -= p
+	}
+	// rounding of u can leave it just above the last probability
+	for n := len(m) - 1; n > 0; n-- {
+		if m[n] > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+func (m Multiplicity) String() string {
+	ps := make([]string, len(m))
+	for i, p := range m {
+		ps[i] = strconv.FormatFloat(p, 'g', -1, 64)
+	}
+	return strings.Join(ps, ",")
+}
+
+// Set parses comma separated probabilities of 0, 1, 2... neutrons, implements flag.Value.
+func (m *Multiplicity) Set(s string) error {
+	var v Multiplicity
+	for _, f := range strings.Split(s, ",") {
+		p, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+		if err != nil {
+			return fmt.Errorf("multiplicity: %w", err)
+		}
+		v = append(v, p)
+	}
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// Type implements pflag.Value.
+func (m *Multiplicity) Type() string {
+	return "weights"
+}
@@ -0,0 +1,133 @@
+package isotope
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Masses in unified atomic mass units and energy equivalent of one mass unit in MeV.
+const (
+	hydrogenMass = 1.00782503
+	neutronMass  = 1.00866492
+	amuEnergy    = 931.49410
+)
+
+// Neutrons is number of neutrons in nucleus. Described as "N".
+func (iso *Isotope) Neutrons() int {
+	return iso.Mass - iso.Number
+}
+
+// BindingEnergy returns total binding energy in MeV.
+// If isotope has no data, it's estimated by semi-empirical mass formula.
+func (iso *Isotope) BindingEnergy() float64 {
+	if iso.Binding != 0 {
+		return iso.Binding
+	}
+	return semiEmpirical(iso.Number, iso.Mass)
+}
+
+// BindingPerNucleon returns binding energy per nucleon in MeV.
+func (iso *Isotope) BindingPerNucleon() float64 {
+	if iso.Mass == 0 {
+		return 0
+	}
+	return iso.BindingEnergy() / float64(iso.Mass)
+}
+
+// AMU returns atomic mass in unified atomic mass units.
+// If isotope has no data, it's calculated from binding energy.
+func (iso *Isotope) AMU() float64 {
+	if iso.AtomicMass != 0 {
+		return iso.AtomicMass
+	}
+	return float64(iso.Number)*hydrogenMass + float64(iso.Neutrons())*neutronMass - iso.BindingEnergy()/amuEnergy
+}
+
+// NaturalAbundance returns natural abundance in percent, zero for isotopes not occuring in nature.
+func (iso *Isotope) NaturalAbundance() float64 {
+	return iso.Abundance
+}
+
+// Spin returns ground state spin (J) and true if it's known.
+func (iso *Isotope) Spin() (float64, bool) {
+	j, _ := iso.spinParity()
+	if j == "" {
+		return 0, false
+	}
+	if num, den, ok := strings.Cut(j, "/"); ok {
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			return 0, false
+		}
+		d, err := strconv.Atoi(den)
+		if err != nil || d == 0 {
+			return 0, false
+		}
+		return float64(n) / float64(d), true
+	}
+	n, err := strconv.ParseFloat(j, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// Parity returns ground state parity, +1 or -1, and 0 if it's unknown.
+func (iso *Isotope) Parity() int {
+	_, parity := iso.spinParity()
+	return parity
+}
+
+// spinParity splits spin and parity like "7/2-", also when they are tentative in NUBASE
+// notation like "(7/2-)", "(7/2)-" or estimated like "7/2-#".
+func (iso *Isotope) spinParity() (string, int) {
+	s := strings.Trim(strings.TrimSpace(strings.TrimRight(iso.SpinParity, "#")), "()")
+	parity := 0
+	switch {
+	case strings.HasSuffix(s, "+"):
+		parity = 1
+	case strings.HasSuffix(s, "-"):
+		parity = -1
+	}
+	return strings.Trim(strings.TrimRight(s, "+-"), "()"), parity
+}
+
+// semiEmpirical returns binding energy in MeV calculated with Weizsäcker formula.
+func semiEmpirical(z, a int) float64 {
+	if a <= 1 {
+		return 0
+	}
+	const (
+		volume    = 15.75
+		surface   = 17.8
+		coulomb   = 0.711
+		asymmetry = 23.7
+		pairing   = 11.18
+	)
+	Z, A := float64(z), float64(a)
+	N := A - Z
+
+	b := volume*A - surface*math.Pow(A, 2.0/3) - coulomb*Z*(Z-1)/math.Cbrt(A) - asymmetry*(N-Z)*(N-Z)/A
+	switch {
+	case z%2 == 0 && (a-z)%2 == 0:
+		b += pairing / math.Sqrt(A)
+	case z%2 == 1 && (a-z)%2 == 1:
+		b -= pairing / math.Sqrt(A)
+	}
+	return math.Max(b, 0)
+}
+
+// QValue returns energy in MeV released by fission of parent after neutron absorption into products,
+// as difference of binding energy of products and compound nucleus.
+func QValue(parent *Isotope, prods Products) float64 {
+	compound := semiEmpirical(parent.Number, parent.Mass+1)
+	if iso, ok := Lookup(parent.Number, parent.Mass+1); ok {
+		compound = iso.BindingEnergy()
+	}
+	var b float64
+	for _, p := range prods {
+		b += p.BindingEnergy()
+	}
+	return b - compound
+}
@@ -0,0 +1,161 @@
+package isotope
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Overwrite is policy of writing output file that already exists.
+type Overwrite int
+
+const (
+	// OverwriteReplace replaces existing file.
+	OverwriteReplace Overwrite = iota
+
+	// OverwriteFail fails with error wrapping os.ErrExist.
+	OverwriteFail
+
+	// OverwriteRename writes to a new file with number appended to its name, e.g. "probs-1.json".
+	OverwriteRename
+)
+
+// ParseOverwrite parses policy name "replace", "fail" or "rename".
+func ParseOverwrite(s string) (Overwrite, error) {
+	switch strings.ToLower(s) {
+	case "", "replace":
+		return OverwriteReplace, nil
+	case "fail":
+		return OverwriteFail, nil
+	case "rename":
+		return OverwriteRename, nil
+	}
+	return 0, fmt.Errorf("unknown overwrite policy %q", s)
+}
+
+// OutputConfig describes where output files are saved. Zero value saves
+// files to working directory, replacing existing files.
+type OutputConfig struct {
+	// Dir is output directory, created if it doesn't exist.
+	Dir string
+
+	// Prefix is prepended to each file name, e.g. "run-1-".
+	Prefix string
+
+	Overwrite Overwrite
+
+	// Compression of data files, its extension is appended to file names.
+	// Images are never compressed.
+	Compression Compression
+
+	// Image is format of charts, PNG by default.
+	Image ImageFormat
+}
+
+// Path returns path of output file name, which may contain directories.
+func (o OutputConfig) Path(name string) string {
+	dir, file := filepath.Split(name)
+	return filepath.Join(o.Dir, dir, o.Prefix+file)
+}
+
+// Save creates output file of name and writes it with write.
+func (o OutputConfig) Save(name string, write func(io.Writer) error) error {
+	c := o.Compression
+	if ext := filepath.Ext(name); ext == PNG.Ext() || ext == SVG.Ext() {
+		c = NoCompression
+	}
+	f, err := o.create(o.Path(name) + c.Ext())
+	if err != nil {
+		return err
+	}
+	w, err := c.Writer(f)
+	if err == nil {
+		err = write(w)
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (o OutputConfig) create(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return nil, err
+	}
+	switch o.Overwrite {
+	case OverwriteFail:
+		return os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0777)
+	case OverwriteRename:
+		ext := filepath.Ext(path)
+		if CompressionOf(path) != NoCompression {
+			ext = filepath.Ext(strings.TrimSuffix(path, ext)) + ext
+		}
+		base := strings.TrimSuffix(path, ext)
+		for i := 0; ; i++ {
+			p := path
+			if i > 0 {
+				p = fmt.Sprintf("%s-%d%s", base, i, ext)
+			}
+			f, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0777)
+			if !os.IsExist(err) {
+				return f, err
+			}
+		}
+	}
+	return os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0777)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	data, err := json.MarshalIndent(v, "", " ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Format of data output files.
+type Format string
+
+const (
+	JSON Format = "json"
+	YAML Format = "yaml"
+	CSV  Format = "csv"
+
+	// Protobuf is binary encoding of aggregated results, see package pb.
+	Protobuf Format = "protobuf"
+)
+
+// ParseFormats parses comma separated list of formats, e.g. "json,yaml".
+func ParseFormats(s string) ([]Format, error) {
+	var formats []Format
+	for _, f := range strings.Split(s, ",") {
+		switch format := Format(strings.ToLower(strings.TrimSpace(f))); format {
+		case JSON, YAML, CSV, Protobuf:
+			formats = append(formats, format)
+		case "":
+		default:
+			return nil, fmt.Errorf("unknown output format %q", f)
+		}
+	}
+	return formats, nil
+}
+
+// WriteYAML writes yaml of v indented by two spaces to w.
+func WriteYAML(w io.Writer, v any) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return enc.Close()
+}
@@ -0,0 +1,89 @@
+package isotope
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Parse returns isotope from isotopes table identified by a string like "U-235", "235U" or "Pu239".
+// Symbols are case insensitive. "P-239", name of Pu-239 in earlier versions, is Pu-239 too.
+func Parse(s string) (*Isotope, error) {
+	za, err := ParseZA(s)
+	if err != nil {
+		return nil, err
+	}
+	iso, ok := Lookup(za.Number, za.Mass)
+	if !ok {
+		return nil, fmt.Errorf("isotope %q: no isotope with Z=%d and A=%d", s, za.Number, za.Mass)
+	}
+	c := *iso
+	return &c, nil
+}
+
+// ParseZA returns atomic and mass number of string like Parse, also of nuclide which
+// isn't in isotopes table.
+func ParseZA(s string) (ZA, error) {
+	if name, ok := aliases[strings.TrimSpace(s)]; ok {
+		s = name
+	}
+	symbol, mass, err := split(strings.TrimSpace(s))
+	if err != nil {
+		return ZA{}, err
+	}
+	number, ok := SymbolNumber(symbol)
+	if !ok {
+		return ZA{}, fmt.Errorf("isotope %q: unknown element symbol %q", s, symbol)
+	}
+	return ZA{Number: number, Mass: mass}, nil
+}
+
+// aliases are former names of isotopes.
+var aliases = map[string]string{"P-239": "Pu-239"}
+
+// SymbolNumber returns atomic number of chemical element symbol.
+func SymbolNumber(symbol string) (int, bool) {
+	if _, err := Isotopes(); err != nil {
+		return 0, false
+	}
+	n, ok := numbers[normalizeSymbol(symbol)]
+	return n, ok
+}
+
+// split splits identifier to element symbol and mass number, in either order.
+func split(s string) (string, int, error) {
+	letters := strings.IndexFunc(s, unicode.IsLetter)
+	digits := strings.IndexFunc(s, unicode.IsDigit)
+	if letters < 0 || digits < 0 {
+		return "", 0, fmt.Errorf("isotope %q: expected element symbol and mass number", s)
+	}
+
+	var symbol, mass string
+	if letters < digits {
+		symbol, mass = s[:digits], s[digits:]
+	} else {
+		mass, symbol = s[:letters], s[letters:]
+	}
+	symbol = strings.TrimRight(strings.TrimSpace(symbol), "- ")
+	mass = strings.TrimLeft(strings.TrimSpace(mass), "- ")
+	mass = strings.TrimRight(mass, "- ")
+
+	a, err := strconv.Atoi(mass)
+	if err != nil || a <= 0 {
+		return "", 0, fmt.Errorf("isotope %q: invalid mass number %q", s, mass)
+	}
+	for _, r := range symbol {
+		if !unicode.IsLetter(r) {
+			return "", 0, fmt.Errorf("isotope %q: invalid element symbol %q", s, symbol)
+		}
+	}
+	return symbol, a, nil
+}
+
+func normalizeSymbol(symbol string) string {
+	if symbol == "" {
+		return ""
+	}
+	return strings.ToUpper(symbol[:1]) + strings.ToLower(symbol[1:])
+}
@@ -0,0 +1,276 @@
+package isotope
+
+import (
+	"physics/internal/enum"
+	"physics/random"
+)
+
+// FragmentPolicy is how fission handles fragment which has no equivalent in isotopes table.
+type FragmentPolicy int
+
+const (
+	// FragmentReject discards fission, it fails with FragmentError.
+	FragmentReject FragmentPolicy = iota
+
+	// FragmentRetry samples fission again, at most MaxRetries times.
+	FragmentRetry
+
+	// FragmentKeep keeps fragment which isn't in isotopes table, with symbol of its element.
+	FragmentKeep
+
+	// FragmentNearest replaces fragment with known isotope of the same element of the closest
+	// mass number, or of the closest element if the element has no known isotopes.
+	FragmentNearest
+)
+
+// MaxRetries is number of times FragmentRetry samples fission before it's rejected.
+const MaxRetries = 100
+
+var fragmentPolicies = enum.New[FragmentPolicy]("fragment policy", "reject", "retry", "keep", "nearest")
+
+// ParseFragmentPolicy parses policy name "reject", "retry", "keep" or "nearest".
+func ParseFragmentPolicy(s string) (FragmentPolicy, error) {
+	return fragmentPolicies.Parse(s)
+}
+
+func (p FragmentPolicy) String() string {
+	return fragmentPolicies.String(p)
+}
+
+// Set implements flag.Value.
+func (p *FragmentPolicy) Set(s string) error {
+	return fragmentPolicies.Set(p, s)
+}
+
+// Type implements pflag.Value.
+func (p *FragmentPolicy) Type() string {
+	return "policy"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p FragmentPolicy) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *FragmentPolicy) UnmarshalText(text []byte) error {
+	return p.Set(string(text))
+}
+
+// Unmatched counts fragments without equivalent isotope by what fragment policy did with them.
+type Unmatched struct {
+	// Rejected is number of discarded fissions.
+	Rejected int `json:"rejected" yaml:"rejected"`
+
+	// Retries is number of times fission was sampled again.
+	Retries int `json:"retries" yaml:"retries"`
+
+	// Kept and Remapped are numbers of fragments kept as they are or replaced by nearest isotope.
+	Kept     int `json:"kept" yaml:"kept"`
+	Remapped int `json:"remapped" yaml:"remapped"`
+}
+
+// Add adds counts of other.
+func (u *Unmatched) Add(other Unmatched) {
+	u.Rejected += other.Rejected
+	u.Retries += other.Retries
+	u.Kept += other.Kept
+	u.Remapped += other.Remapped
+}
+
+// DestabilizePolicy destabilizes nucleus like DestabilizeRand, handling fragments which
+// have no equivalent isotope by policy. It returns what policy did, also when fission fails.
+// With FragmentReject it draws the same random numbers and fails the same as DestabilizeRand.
+func (iso Isotope) DestabilizePolicy(rng random.Rand, policy FragmentPolicy) (Products, int, Unmatched, error) {
+	f, err := iso.DestabilizeAppend(make(Products, 0, 2), rng, Options{Fragments: policy})
+	if err != nil {
+		return nil, 0, f.Unmatched, err
+	}
+	for i, p := range f.Products {
+		c := *p
+		f.Products[i] = &c
+	}
+	return f.Products, f.Neutrons, f.Unmatched, err
+}
+
+// Options of fission.
+type Options struct {
+	// Fragments handles fragments which have no equivalent isotope.
+	Fragments FragmentPolicy
+
+	// Neutrons is how number of neutrons is drawn.
+	Neutrons NeutronModel
+
+	// KineticEnergy samples kinetic energy of fragments, which draws more random numbers.
+	KineticEnergy bool
+
+	// Model draws neutrons, fragments and their energy, SimpleModel if it's nil.
+	Model FissionModel
+
+	// Strata, if it's set, splits masses of heavier fragment into bins of equal width, and
+	// mass is drawn from bin Stratum, so fission has weight of probability of bin.
+	Stratum, Strata int
+}
+
+// Fission is outcome of fission of a nucleus.
+type Fission struct {
+	Products Products
+	Neutrons int
+
+	// FragmentNeutrons are neutrons evaporated by each fragment, in order of products.
+	// They are zero unless neutron model attributes neutrons to fragments.
+	FragmentNeutrons [2]int
+
+	// KineticEnergy is kinetic energy of each product in MeV, zero unless it's sampled.
+	KineticEnergy [2]float64
+
+	// Weight is probability of stratum of heavier fragment relative to probability that it's
+	// drawn, one if fission isn't stratified.
+	Weight float64
+
+	// Unmatched is what fragment policy did, also when fission fails.
+	Unmatched Unmatched
+}
+
+// DestabilizeAppend destabilizes nucleus like DestabilizePolicy, with neutrons, fragments and
+// their energy drawn by models of opts, and appends products to prods. Products aren't copies, they are shared with isotopes
+// table and must not be modified, so fission with enough capacity of prods doesn't allocate.
+// Products of failed fission are not appended.
+func (iso Isotope) DestabilizeAppend(prods Products, rng random.Rand, opts Options) (Fission, error) {
+	f := Fission{Weight: 1}
+	if _, err := Isotopes(); err != nil {
+		f.Products = prods
+		return f, err
+	}
+	model := opts.Model
+	if model == nil {
+		model = SimpleModel{}
+	}
+	target := iso
+	iso.induceNeutron()
+	start := len(prods)
+	policy := opts.Fragments
+	var scale float64
+	if opts.Neutrons == NeutronsSawtooth {
+		scale = iso.sawtoothScale(target.NeutronMultiplicity().Nu())
+	}
+	u := &f.Unmatched
+	for {
+		var fragments [2]ZA
+		if opts.Neutrons == NeutronsSawtooth {
+			var h ZA
+			h, f.Weight = model.SampleFragments(rng, iso, 0, opts)
+			fragments = iso.evaporation(rng, h, scale, &f.FragmentNeutrons)
+			f.Neutrons = f.FragmentNeutrons[0] + f.FragmentNeutrons[1]
+		} else {
+			f.Neutrons = model.SampleNeutrons(rng, target)
+			var h ZA
+			h, f.Weight = model.SampleFragments(rng, iso, f.Neutrons, opts)
+			fragments = [2]ZA{h, {Number: iso.Number - h.Number, Mass: iso.Mass - f.Neutrons - h.Mass}}
+		}
+
+		prods = prods[:start]
+		var missing *ZA
+		for i, za := range fragments {
+			p, ok := product(za)
+			if !ok {
+				if missing == nil {
+					missing = &fragments[i]
+				}
+				switch policy {
+				case FragmentKeep:
+					p, ok = unnamed(za), true
+				case FragmentNearest:
+					p, ok = nearest(za)
+				}
+				if !ok {
+					break
+				}
+				if policy == FragmentKeep {
+					u.Kept++
+				} else {
+					u.Remapped++
+				}
+			}
+			prods = append(prods, p)
+		}
+		if len(prods)-start == len(fragments) {
+			if opts.KineticEnergy {
+				primary := fragments
+				for i := range primary {
+					primary[i].Mass += f.FragmentNeutrons[i]
+				}
+				f.KineticEnergy = capEnergy(model.SampleEnergy(rng, primary), QValue(&target, prods[start:]))
+			}
+			f.Products = prods
+			return f, nil
+		}
+		if policy == FragmentRetry && u.Retries < MaxRetries {
+			u.Retries++
+			continue
+		}
+		u.Rejected++
+		f.Products, f.Neutrons, f.FragmentNeutrons, f.Weight = prods[:start], 0, [2]int{}, 1
+		return f, &FragmentError{Z: missing.Number, A: missing.Mass}
+	}
+}
+
+// evaporation splits compound nucleus into primary fragments, heavier fragment h first, and
+// returns fragments which remain after each of them evaporates neutrons by its mass.
+func (iso Isotope) evaporation(rng random.Rand, h ZA, scale float64, neutrons *[2]int) [2]ZA {
+	fragments := [2]ZA{h, {Number: iso.Number - h.Number, Mass: iso.Mass - h.Mass}}
+	for i, za := range fragments {
+		// fragment can't evaporate more neutrons than it has
+		n := min(evaporate(rng, za.Mass, scale), max(za.Mass-za.Number, 0))
+		neutrons[i] = n
+		fragments[i].Mass -= n
+	}
+	return fragments
+}
+
+// product returns isotope of za from isotopes table.
+func product(za ZA) (*Isotope, bool) {
+	iso, ok := index[za]
+	if !ok || iso.Symbol == "" {
+		return nil, false
+	}
+	return iso, true
+}
+
+// unnamed returns fragment of za, named by symbol of its element if it's known.
+func unnamed(za ZA) *Isotope {
+	f := Fragment(za.Number, za.Mass)
+	if isos := byNumber[za.Number]; len(isos) > 0 {
+		f.Symbol = isos[0].Symbol
+	}
+	return f
+}
+
+// nearest returns isotope of isotopes table closest to za, preferring isotopes of the same element.
+// Elements are searched outwards from atomic number of za, the lighter of equally distant
+// elements first.
+func nearest(za ZA) (*Isotope, bool) {
+	var best *Isotope
+	bestDistance := 0
+	for dz := 0; best == nil && (za.Number-dz >= 0 || za.Number+dz <= maxNumber); dz++ {
+		for _, z := range [2]int{za.Number - dz, za.Number + dz} {
+			for _, iso := range byNumber[z] {
+				d := abs(iso.Mass - za.Mass)
+				if best == nil || d < bestDistance {
+					best, bestDistance = iso, d
+				}
+			}
+			if dz == 0 {
+				break
+			}
+		}
+	}
+	return best, best != nil
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,73 @@
+package isotope
+
+import (
+	"sort"
+
+	"physics/count"
+)
+
+// Count is number of occurences of an isotope in products.
+type Count struct {
+	Isotope *Isotope `json:"isotope" yaml:"isotope"`
+	Count   int      `json:"count" yaml:"count"`
+}
+
+// Filter returns products for which keep returns true.
+func (prods Products) Filter(keep func(*Isotope) bool) Products {
+	var filtered Products
+	for _, p := range prods {
+		if keep(p) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+// FilterByElement returns products of chemical element symbol, e.g. "Xe".
+func (prods Products) FilterByElement(symbol string) Products {
+	return prods.Filter(func(iso *Isotope) bool { return iso.Symbol == symbol })
+}
+
+// FilterByMassRange returns products with mass number in range [min, max].
+func (prods Products) FilterByMassRange(min, max int) Products {
+	return prods.Filter(func(iso *Isotope) bool { return iso.Mass >= min && iso.Mass <= max })
+}
+
+// Counts returns number of occurences of each isotope, the most common first.
+// Isotopes with the same count are ordered by atomic and mass number.
+func (prods Products) Counts() []Count {
+	return prods.TopN(0)
+}
+
+// SortByCount returns products sorted so that the most common isotopes come first.
+func (prods Products) SortByCount() Products {
+	counts := prods.Counts()
+	rank := make(map[ZA]int, len(counts))
+	for i, c := range counts {
+		rank[c.Isotope.ZA()] = i
+	}
+	sorted := append(Products{}, prods...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return rank[sorted[i].ZA()] < rank[sorted[j].ZA()]
+	})
+	return sorted
+}
+
+// TopN returns k most common isotopes, all isotopes if k isn't positive.
+func (prods Products) TopN(k int) []Count {
+	counts := count.New[ZA]()
+	first := make(map[ZA]*Isotope)
+	for _, p := range prods {
+		za := p.ZA()
+		if _, ok := first[za]; !ok {
+			first[za] = p
+		}
+		counts.Add(za, 1)
+	}
+	entries := counts.TopN(k)
+	top := make([]Count, len(entries))
+	for i, e := range entries {
+		top[i] = Count{Isotope: first[e.Key], Count: e.Count}
+	}
+	return top
+}
@@ -0,0 +1,82 @@
+package isotope
+
+import (
+	"cmp"
+	"io"
+	"maps"
+	"slices"
+)
+
+// ChartValue is labelled value of chart with its standard error, zero if it isn't known.
+type ChartValue struct {
+	Label string
+	Value float64
+	Error float64
+}
+
+// Plot is data of chart independent of library which renders it.
+type Plot struct {
+	Title  string
+	XLabel string
+	YLabel string
+	Values []ChartValue
+}
+
+// Renderer renders charts of elements, isotopes, probabilities and neutrons when it's set in
+// ChartOptions, so they can be drawn by other plotting library than go-chart.
+type Renderer interface {
+	// Bars renders bar chart of values in their order.
+	Bars(w io.Writer, format ImageFormat, p Plot, opts ChartOptions) error
+
+	// Shares renders values as shares of their total, e.g. donut chart of probabilities.
+	Shares(w io.Writer, format ImageFormat, p Plot, opts ChartOptions) error
+}
+
+// Plot returns data of bar chart of elements, ordered by atomic number.
+func (sc symbols) Plot(opts ChartOptions) Plot {
+	p := Plot{Title: opts.title("Fission products"), XLabel: "element", YLabel: "products"}
+	for _, s := range elements(sc) {
+		p.Values = append(p.Values, ChartValue{Label: s, Value: float64(sc[s])})
+	}
+	return p
+}
+
+// Plot returns data of bar chart of isotopes of element symbol, ordered by mass number.
+func (ic groups) Plot(symbol string, opts ChartOptions) Plot {
+	p := Plot{Title: opts.title(symbol), XLabel: "isotope", YLabel: "products"}
+	for _, name := range ic.names(symbol) {
+		p.Values = append(p.Values, ChartValue{Label: name, Value: float64(ic[symbol][name])})
+	}
+	return p
+}
+
+// Plot returns data of chart of probabilities in percent with their standard errors,
+// ordered by atomic number.
+func (probs probabilities) Plot(opts ChartOptions) Plot {
+	p := Plot{Title: opts.title("Probability of occurence"), XLabel: "element", YLabel: "probability [%]"}
+	for _, s := range elements(probs) {
+		v := probs[s]
+		p.Values = append(p.Values, ChartValue{Label: s, Value: v.Probability, Error: v.StdErr})
+	}
+	return p
+}
+
+// names returns names of isotopes of element symbol ordered by mass number.
+func (ic groups) names(symbol string) []string {
+	return slices.SortedFunc(maps.Keys(ic[symbol]), func(a, b string) int {
+		return cmp.Compare(massNumber(a), massNumber(b))
+	})
+}
+
+// massNumber returns mass number of isotope name, e.g. 140 of "Xe-140".
+func massNumber(name string) int {
+	_, a, _ := split(name)
+	return a
+}
+
+// elements returns element symbols of m ordered by atomic number.
+func elements[V any](m map[string]V) []string {
+	return slices.SortedFunc(maps.Keys(m), func(a, b string) int {
+		return cmp.Or(cmp.Compare(numbers[a], numbers[b]), cmp.Compare(a, b))
+	})
+}
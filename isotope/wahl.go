@@ -0,0 +1,124 @@
+package isotope
+
+import (
+	"math"
+
+	"physics/internal/enum"
+	"physics/random"
+)
+
+// ChargeModel is how atomic numbers of fragments are assigned to their masses.
+type ChargeModel int
+
+const (
+	// ChargeProportional splits charge of compound nucleus in proportion to masses of
+	// fragments, by SimpleModel.
+	ChargeProportional ChargeModel = iota
+
+	// ChargeWahl draws charge of fragments around most probable charge of Wahl's Zp
+	// systematics, by WahlModel.
+	ChargeWahl
+)
+
+var chargeModels = enum.New[ChargeModel]("charge model", "proportional", "wahl")
+
+// ParseChargeModel parses model name "proportional" or "wahl".
+func ParseChargeModel(s string) (ChargeModel, error) {
+	return chargeModels.Parse(s)
+}
+
+func (m ChargeModel) String() string {
+	return chargeModels.String(m)
+}
+
+// Set implements flag.Value.
+func (m *ChargeModel) Set(s string) error {
+	return chargeModels.Set(m, s)
+}
+
+// Type implements pflag.Value.
+func (m *ChargeModel) Type() string {
+	return "model"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m ChargeModel) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *ChargeModel) UnmarshalText(text []byte) error {
+	return m.Set(string(text))
+}
+
+// FissionModel returns fission model of charge model, nil for the default SimpleModel.
+func (m ChargeModel) FissionModel() FissionModel {
+	if m == ChargeWahl {
+		return WahlModel{}
+	}
+	return nil
+}
+
+const (
+	// wahlPolarization is ΔZ of heavy fragment, by which its most probable charge is below
+	// charge of unchanged charge density of compound nucleus. Light fragment is above it.
+	wahlPolarization = 0.5
+
+	// wahlWidth is σ_Z of Gaussian distribution of charges of fragments of a mass.
+	wahlWidth = 0.56
+
+	// wahlOddEven is proton odd-even effect δ_Z, by which even charges are more probable
+	// and odd charges less probable.
+	wahlOddEven = 0.2
+)
+
+// WahlModel is SimpleModel with charge of heavier fragment drawn from Wahl's Zp systematics
+// of low energy fission. Most probable charge Zp of fragment of mass A is charge of
+// unchanged charge density A·Zc/Ac shifted by charge polarization ΔZ, and charges are
+// distributed around it by Gaussian of width σ_Z integrated over unit intervals, enhanced
+// for even and suppressed for odd charges. Lighter fragment takes the remaining charge.
+type WahlModel struct {
+	SimpleModel
+}
+
+// SampleFragments draws mass of heavier fragment like SimpleModel and then its charge.
+func (m WahlModel) SampleFragments(rng random.Rand, compound Isotope, neutrons int, opts Options) (ZA, float64) {
+	h, weight := m.SimpleModel.SampleFragments(rng, compound, neutrons, opts)
+	h.Number = wahlCharge(rng, compound, h.Mass)
+	return h, weight
+}
+
+// wahlCharge draws charge of heavy fragment of mass a of compound nucleus.
+func wahlCharge(rng random.Rand, compound Isotope, a int) int {
+	zp := float64(compound.Number*a)/float64(compound.Mass) - wahlPolarization
+	lo := max(1, int(math.Round(zp))-3)
+	hi := min(compound.Number-1, int(math.Round(zp))+3)
+	if hi < lo {
+		return max(1, min(int(math.Round(zp)), compound.Number-1))
+	}
+	var weights [7]float64
+	total := 0.0
+	for z := lo; z <= hi; z++ {
+		w := wahlFraction(float64(z), zp)
+		if z%2 == 0 {
+			w *= 1 + wahlOddEven
+		} else {
+			w *= 1 - wahlOddEven
+		}
+		weights[z-lo] = w
+		total += w
+	}
+	u := rng.Float64() * total
+	for z := lo; z < hi; z++ {
+		if u -= weights[z-lo]; u < 0 {
+			return z
+		}
+	}
+	return hi
+}
+
+// wahlFraction returns fraction of charges of Gaussian around zp within z ± ½.
+func wahlFraction(z, zp float64) float64 {
+	s := wahlWidth * math.Sqrt2
+	return (math.Erf((z-zp+0.5)/s) - math.Erf((z-zp-0.5)/s)) / 2
+}
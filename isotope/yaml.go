@@ -0,0 +1,41 @@
+package isotope
+
+import (
+	"fmt"
+	"io"
+)
+
+// WriteYAML writes yaml to w
+func (sc symbols) WriteYAML(w io.Writer) error {
+	return WriteYAML(w, sc)
+}
+
+// WriteYAML writes yaml to w
+func (ic groups) WriteYAML(w io.Writer) error {
+	return WriteYAML(w, ic)
+}
+
+// WriteYAML writes yaml to w
+func (probs probabilities) WriteYAML(w io.Writer) error {
+	return WriteYAML(w, probs)
+}
+
+// dataWriter writes counts of products in each data format.
+type dataWriter interface {
+	WriteJSON(io.Writer) error
+	WriteYAML(io.Writer) error
+	WriteCSV(io.Writer) error
+}
+
+// saveData saves t in format to file of name with extension of format.
+func saveData(out OutputConfig, name string, format Format, t dataWriter) error {
+	switch format {
+	case JSON:
+		return out.Save(name+".json", t.WriteJSON)
+	case YAML:
+		return out.Save(name+".yaml", t.WriteYAML)
+	case CSV:
+		return out.Save(name+".csv", t.WriteCSV)
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
@@ -0,0 +1,122 @@
+// Package kinematics samples directions and velocities of fission fragments and neutrons,
+// so that geometry and transport can follow particles emitted in fission.
+package kinematics
+
+import (
+	"math"
+
+	"physics/internal/enum"
+	"physics/random"
+)
+
+const (
+	// SpeedOfLight in m/s.
+	SpeedOfLight = 299792458.0
+
+	// AtomicMassUnit and NeutronMass are rest energies in MeV.
+	AtomicMassUnit = 931.49410242
+	NeutronMass    = 939.56542052
+
+	// NeutronTemperature is temperature of Maxwellian spectrum of prompt fission neutrons
+	// in MeV, for which mean neutron energy is about 2 MeV.
+	NeutronTemperature = 1.32
+)
+
+// Vector is a 3D vector, e.g. velocity in m/s.
+type Vector struct {
+	X float64 `json:"x" yaml:"x"`
+	Y float64 `json:"y" yaml:"y"`
+	Z float64 `json:"z" yaml:"z"`
+}
+
+// Add returns sum of vectors.
+func (v Vector) Add(w Vector) Vector {
+	return Vector{v.X + w.X, v.Y + w.Y, v.Z + w.Z}
+}
+
+// Scale returns vector multiplied by f.
+func (v Vector) Scale(f float64) Vector {
+	return Vector{f * v.X, f * v.Y, f * v.Z}
+}
+
+// Dot returns scalar product of vectors.
+func (v Vector) Dot(w Vector) float64 {
+	return v.X*w.X + v.Y*w.Y + v.Z*w.Z
+}
+
+// Cross returns vector product of vectors.
+func (v Vector) Cross(w Vector) Vector {
+	return Vector{v.Y*w.Z - v.Z*w.Y, v.Z*w.X - v.X*w.Z, v.X*w.Y - v.Y*w.X}
+}
+
+// Norm returns length of vector.
+func (v Vector) Norm() float64 {
+	return math.Sqrt(v.Dot(v))
+}
+
+// Isotropic returns unit vector of uniformly distributed direction.
+func Isotropic(rng random.Rand) Vector {
+	mu := 2*rng.Float64() - 1
+	phi := 2 * math.Pi * rng.Float64()
+	s := math.Sqrt(1 - mu*mu)
+	return Vector{s * math.Cos(phi), s * math.Sin(phi), mu}
+}
+
+// Speed returns speed in m/s of particle of kinetic energy and rest energy in MeV.
+func Speed(energy, mass float64) float64 {
+	if energy <= 0 || mass <= 0 {
+		return 0
+	}
+	gamma := 1 + energy/mass
+	return SpeedOfLight * math.Sqrt(1-1/(gamma*gamma))
+}
+
+// Maxwell draws energy in MeV from Maxwellian spectrum of temperature in MeV.
+func Maxwell(rng random.Rand, temperature float64) float64 {
+	c := math.Cos(math.Pi / 2 * rng.Float64())
+	return -temperature * (math.Log(1-rng.Float64()) + math.Log(1-rng.Float64())*c*c)
+}
+
+// Emission is angular distribution of neutrons.
+type Emission int
+
+const (
+	// EmissionIsotropic emits neutrons isotropically in laboratory frame.
+	EmissionIsotropic Emission = iota
+
+	// EmissionFragments emits neutrons isotropically in frame of fragment which evaporated
+	// them, so they are boosted along its direction. Neutrons which aren't attributed to
+	// a fragment are emitted isotropically in laboratory frame.
+	EmissionFragments
+)
+
+var emissions = enum.New[Emission]("emission", "isotropic", "fragments")
+
+// ParseEmission parses emission name "isotropic" or "fragments".
+func ParseEmission(s string) (Emission, error) {
+	return emissions.Parse(s)
+}
+
+func (e Emission) String() string {
+	return emissions.String(e)
+}
+
+// Set implements flag.Value.
+func (e *Emission) Set(s string) error {
+	return emissions.Set(e, s)
+}
+
+// Type implements pflag.Value.
+func (e *Emission) Type() string {
+	return "emission"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (e Emission) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (e *Emission) UnmarshalText(text []byte) error {
+	return e.Set(string(text))
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"physics/fission"
+	"physics/isotope"
+	"physics/kinetics"
+	"physics/scenario"
+)
+
+func kineticsCmd() *cobra.Command {
+	var (
+		output         outputFlags
+		path           string
+		keff           float64
+		pcm            float64
+		at             time.Duration
+		scriptPath     string
+		duration       time.Duration
+		step           time.Duration
+		generationTime float64
+		format         string
+		nocharts       bool
+	)
+	cmd := &cobra.Command{
+		Use:   "kinetics",
+		Short: "Compute power over time after step insertion of reactivity of k_eff",
+		Long: "Compute power over time of point kinetics with six groups of delayed neutrons of a\n" +
+			"critical system, after step insertion of reactivity (k_eff - 1) / k_eff. k_eff is\n" +
+			"estimated by simulation of config with transport unless --keff or --reactivity is given.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := cmd.Flags()
+			if f.Changed("keff") && f.Changed("reactivity") {
+				return errors.New("kinetics: --keff and --reactivity are exclusive")
+			}
+			out, err := output.config()
+			if err != nil {
+				return err
+			}
+			formats, err := isotope.ParseFormats(format)
+			if err != nil {
+				return err
+			}
+
+			var rho float64
+			switch {
+			case f.Changed("reactivity"):
+				rho = pcm * scenario.PCM
+			case f.Changed("keff"):
+				if keff <= 0 {
+					return fmt.Errorf("kinetics: k_eff %g isn't positive", keff)
+				}
+				rho = kinetics.Reactivity(keff)
+			default:
+				if keff, err = simulateKEff(cmd, path); err != nil {
+					return err
+				}
+				fmt.Printf("k_eff:      %.5f\n", keff)
+				rho = kinetics.Reactivity(keff)
+			}
+
+			script := kinetics.StepInsertion(rho, at)
+			actions, err := loadScript(scriptPath)
+			if err != nil {
+				return err
+			}
+			script = scenario.New(append(script, actions...)...)
+
+			k, err := kinetics.New(kinetics.U235, generationTime)
+			if err != nil {
+				return err
+			}
+			if err := script.Check(k); err != nil {
+				return err
+			}
+			curve := k.Run(script, duration, step)
+
+			beta := kinetics.Beta(k.Groups)
+			fmt.Printf("reactivity: %.1f pcm (%.3f $)\n", rho/scenario.PCM, rho/beta)
+			if rho < beta {
+				fmt.Printf("prompt jump: %.4g\n", k.PromptJump(rho))
+			}
+			fmt.Printf("period:     %.4g s\n", k.Period(rho))
+			peak := curve.Peak()
+			fmt.Printf("peak power: %.4g at %.4g s\n", peak.Power, peak.Time)
+			fmt.Printf("power:      %.4g at %.4g s\n", k.Power(), k.Time.Seconds())
+
+			for _, f := range formats {
+				if err := curve.Save(out, f); err != nil {
+					return err
+				}
+			}
+			if !nocharts {
+				if err := curve.SaveChart(out, isotope.ChartOptions{}); err != nil {
+					fmt.Fprintln(os.Stderr, "warning: charts not saved:", err)
+				}
+			}
+			return nil
+		},
+	}
+	output.add(cmd)
+	f := cmd.Flags()
+	f.StringVarP(&path, "config", "c", "", "simulation config file with transport, whose k_eff is inserted")
+	f.Float64Var(&keff, "keff", 1, "k_eff whose reactivity is inserted, instead of simulation")
+	f.Float64Var(&pcm, "reactivity", 0, "reactivity in pcm inserted, instead of simulation")
+	f.DurationVar(&at, "at", 0, "time of insertion")
+	f.StringVar(&scriptPath, "script", "", "file of further actions, e.g. \"at 30s insert -1000 pcm\"")
+	f.DurationVar(&duration, "duration", time.Minute, "length of transient")
+	f.DurationVar(&step, "step", 100*time.Millisecond, "interval of points of power")
+	f.Float64Var(&generationTime, "generation-time", kinetics.FastGenerationTime, "prompt neutron generation time in s")
+	f.StringVar(&format, "format", "csv", "comma separated data formats: json, yaml, csv")
+	f.BoolVar(&nocharts, "nocharts", false, "skip chart rendering, only data files are saved")
+	return cmd
+}
+
+// loadScript reads scenario file at path, empty script if path is empty.
+func loadScript(path string) (scenario.Script, error) {
+	if path == "" {
+		return nil, nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	script, err := scenario.Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return script, nil
+}
+
+// simulateKEff runs simulation of config file at path, default config if it's empty,
+// and returns k_eff estimated by its transport.
+func simulateKEff(cmd *cobra.Command, path string) (float64, error) {
+	c := fission.DefaultConfig()
+	if path != "" {
+		var err error
+		if c, err = fission.LoadConfig(path); err != nil {
+			return 0, err
+		}
+	}
+	if c.Transport == nil {
+		return 0, errors.New("kinetics: config has no transport, give --keff or --reactivity")
+	}
+	sim, err := c.Simulation()
+	if err != nil {
+		return 0, err
+	}
+	results, err := sim.Run(cmd.Context())
+	if err != nil {
+		return 0, err
+	}
+	return results.Observables["k_eff"], nil
+}
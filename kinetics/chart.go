@@ -0,0 +1,55 @@
+//go:build !nochart
+
+package kinetics
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/wcharczuk/go-chart/v2"
+
+	"physics/isotope"
+)
+
+// Saves power over time to image file
+func (c Curve) SaveChart(out isotope.OutputConfig, opts isotope.ChartOptions) error {
+	return out.Save("power"+out.Image.Ext(), func(w io.Writer) error {
+		return c.RenderChart(w, out.Image, opts)
+	})
+}
+
+// RenderChart renders power over time in format to w
+func (c Curve) RenderChart(w io.Writer, format isotope.ImageFormat, opts isotope.ChartOptions) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", isotope.ErrChartFailed, r)
+		}
+	}()
+
+	xs := make([]float64, len(c.Points))
+	ys := make([]float64, len(c.Points))
+	for i, p := range c.Points {
+		xs[i], ys[i] = p.Time, p.Power
+	}
+	graph := chart.Chart{
+		Title: "Relative power",
+		Background: chart.Style{
+			Padding: chart.Box{Top: 50, Left: 20, Right: 20},
+		},
+		Width:  1200,
+		Height: 500,
+		XAxis:  chart.XAxis{Name: "time [s]"},
+		YAxis:  chart.YAxis{Name: "power"},
+		Series: []chart.Series{chart.ContinuousSeries{
+			Name:    "power",
+			XValues: xs,
+			YValues: ys,
+			Style: chart.Style{
+				StrokeColor: chart.GetDefaultColor(0),
+				StrokeWidth: 2,
+			},
+		}},
+	}
+	opts.Line(&graph)
+	return graph.Render(format.Renderer(), w)
+}
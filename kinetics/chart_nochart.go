@@ -0,0 +1,19 @@
+//go:build nochart
+
+package kinetics
+
+import (
+	"io"
+
+	"physics/isotope"
+)
+
+// Saves power over time to image file
+func (c Curve) SaveChart(out isotope.OutputConfig, opts isotope.ChartOptions) error {
+	return isotope.ErrChartsDisabled
+}
+
+// RenderChart renders power over time in format to w
+func (c Curve) RenderChart(w io.Writer, format isotope.ImageFormat, opts isotope.ChartOptions) error {
+	return isotope.ErrChartsDisabled
+}
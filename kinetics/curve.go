@@ -0,0 +1,138 @@
+package kinetics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"physics/isotope"
+	"physics/scenario"
+)
+
+// Point is state of system at a time.
+type Point struct {
+	// Time in s.
+	Time       float64 `json:"time" yaml:"time"`
+	Power      float64 `json:"power" yaml:"power"`
+	Reactivity float64 `json:"reactivity" yaml:"reactivity"`
+}
+
+// Curve is power over time.
+type Curve struct {
+	Points []Point `json:"points" yaml:"points"`
+}
+
+// StepInsertion returns script which inserts reactivity rho at time at.
+func StepInsertion(rho float64, at time.Duration) scenario.Script {
+	return scenario.New(scenario.Action{At: at, Kind: scenario.InsertReactivity, Value: rho})
+}
+
+// Run advances system for duration in steps, applying actions of script when they are due,
+// and returns state at start and after every step. Actions due at start or before it are
+// applied first.
+func (k *Kinetics) Run(script scenario.Script, duration, step time.Duration) Curve {
+	if step <= 0 {
+		step = duration
+	}
+	script.Apply(k, math.MinInt64, k.Time)
+	c := Curve{Points: []Point{k.point()}}
+	for end := k.Time + duration; k.Time < end; {
+		from := k.Time
+		k.Step(min(step, end-from))
+		script.Apply(k, from, k.Time)
+		c.Points = append(c.Points, k.point())
+	}
+	return c
+}
+
+func (k *Kinetics) point() Point {
+	return Point{Time: k.Time.Seconds(), Power: k.power, Reactivity: k.Reactivity}
+}
+
+// Peak returns point of the highest power.
+func (c Curve) Peak() Point {
+	var peak Point
+	for i, p := range c.Points {
+		if i == 0 || p.Power > peak.Power {
+			peak = p
+		}
+	}
+	return peak
+}
+
+// Last returns the last point, zero if there are none.
+func (c Curve) Last() Point {
+	if len(c.Points) == 0 {
+		return Point{}
+	}
+	return c.Points[len(c.Points)-1]
+}
+
+// Saves to .json file
+func (c Curve) SaveJson(out isotope.OutputConfig) error {
+	return out.Save("kinetics.json", c.WriteJSON)
+}
+
+// WriteJSON writes indented json to w
+func (c Curve) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(c, "", " ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Saves to .yaml file
+func (c Curve) SaveYAML(out isotope.OutputConfig) error {
+	return out.Save("kinetics.yaml", c.WriteYAML)
+}
+
+// WriteYAML writes yaml to w
+func (c Curve) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Saves to .csv file
+func (c Curve) SaveCSV(out isotope.OutputConfig) error {
+	return out.Save("kinetics.csv", c.WriteCSV)
+}
+
+// WriteCSV writes a row of time, power and reactivity of each point to w
+func (c Curve) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"time", "power", "reactivity"})
+	for _, p := range c.Points {
+		cw.Write([]string{
+			strconv.FormatFloat(p.Time, 'g', -1, 64),
+			strconv.FormatFloat(p.Power, 'g', -1, 64),
+			strconv.FormatFloat(p.Reactivity, 'g', -1, 64),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Save saves curve in format
+func (c Curve) Save(out isotope.OutputConfig, format isotope.Format) error {
+	switch format {
+	case isotope.JSON:
+		return c.SaveJson(out)
+	case isotope.YAML:
+		return c.SaveYAML(out)
+	case isotope.CSV:
+		return c.SaveCSV(out)
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
@@ -0,0 +1,197 @@
+// Package kinetics solves point reactor kinetics equations with six groups of delayed
+// neutrons, giving power over time of a system of given reactivity, e.g. reactivity of
+// k_eff estimated by transport of neutrons.
+package kinetics
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Group is a group of delayed neutron precursors.
+type Group struct {
+	// Fraction of fission neutrons which are delayed neutrons of group, β_i.
+	Fraction float64 `json:"fraction" yaml:"fraction"`
+
+	// Decay constant of precursors in 1/s, λ_i.
+	Decay float64 `json:"decay" yaml:"decay"`
+}
+
+// U235 are six groups of delayed neutrons of fast fission of U-235 of Keepin, with total
+// delayed fraction 0.0064.
+var U235 = []Group{
+	{Fraction: 0.038 * 0.0064, Decay: 0.0127},
+	{Fraction: 0.213 * 0.0064, Decay: 0.0317},
+	{Fraction: 0.188 * 0.0064, Decay: 0.115},
+	{Fraction: 0.407 * 0.0064, Decay: 0.311},
+	{Fraction: 0.128 * 0.0064, Decay: 1.40},
+	{Fraction: 0.026 * 0.0064, Decay: 3.87},
+}
+
+// FastGenerationTime is prompt neutron generation time in s of bare fast uranium assembly
+// like Godiva.
+const FastGenerationTime = 6e-9
+
+// Beta returns total delayed neutron fraction of groups.
+func Beta(groups []Group) float64 {
+	beta := 0.0
+	for _, g := range groups {
+		beta += g.Fraction
+	}
+	return beta
+}
+
+// Reactivity returns reactivity (k - 1) / k of multiplication factor k.
+func Reactivity(k float64) float64 {
+	return (k - 1) / k
+}
+
+// maxStep is the longest step of integration, longer steps are divided.
+const maxStep = time.Millisecond
+
+// Kinetics is state of point kinetics of a system. It implements scenario.Engine, so
+// scripts can insert reactivity and set power.
+type Kinetics struct {
+	Groups []Group
+
+	// GenerationTime is prompt neutron generation time Λ in s.
+	GenerationTime float64
+
+	// Reactivity is current reactivity, delta k / k.
+	Reactivity float64
+
+	// Source is rate of external source, in power per s.
+	Source float64
+
+	// Time since start.
+	Time time.Duration
+
+	power      float64
+	precursors []float64
+}
+
+// New creates critical system of groups and generation time at power 1, with precursors
+// in equilibrium.
+func New(groups []Group, generationTime float64) (*Kinetics, error) {
+	if generationTime <= 0 || math.IsInf(generationTime, 0) {
+		return nil, fmt.Errorf("kinetics: generation time %g isn't positive", generationTime)
+	}
+	if len(groups) == 0 {
+		return nil, errors.New("kinetics: no delayed neutron groups")
+	}
+	for i, g := range groups {
+		if g.Fraction < 0 || g.Decay <= 0 {
+			return nil, fmt.Errorf("kinetics: invalid group %d %+v", i+1, g)
+		}
+	}
+	k := &Kinetics{Groups: groups, GenerationTime: generationTime, precursors: make([]float64, len(groups))}
+	k.SetPower(1)
+	return k, nil
+}
+
+// Power returns power relative to initial power.
+func (k *Kinetics) Power() float64 {
+	return k.power
+}
+
+// Precursors returns concentrations of precursors of groups, in units of power times s.
+func (k *Kinetics) Precursors() []float64 {
+	return append([]float64(nil), k.precursors...)
+}
+
+// InsertReactivity adds reactivity rho.
+func (k *Kinetics) InsertReactivity(rho float64) {
+	k.Reactivity += rho
+}
+
+// SetPower sets power, and precursors to equilibrium at power, as if system was held at
+// power long enough.
+func (k *Kinetics) SetPower(power float64) {
+	k.power = power
+	for i, g := range k.Groups {
+		k.precursors[i] = g.Fraction / (k.GenerationTime * g.Decay) * power
+	}
+}
+
+// Step advances system by dt. It's integrated by implicit Euler method, which is stable
+// for stiff equations, in steps of at most a millisecond, shorter for prompt supercritical
+// system so that power grows by little in a step.
+func (k *Kinetics) Step(dt time.Duration) {
+	if dt <= 0 {
+		return
+	}
+	n := int((dt + maxStep - 1) / maxStep)
+	if prompt := k.Reactivity - Beta(k.Groups); prompt > 0 {
+		n = max(n, int(math.Ceil(10*dt.Seconds()*prompt/k.GenerationTime)))
+	}
+	h := dt.Seconds() / float64(n)
+	for range n {
+		k.step(h)
+	}
+	k.Time += dt
+}
+
+// step advances system by h seconds.
+func (k *Kinetics) step(h float64) {
+	// precursors at the end of step are C_i' = (C_i + h β_i/Λ n') / (1 + h λ_i),
+	// substituting them to equation of power leaves linear equation of n'
+	l := k.GenerationTime
+	a := 1 - h*(k.Reactivity-Beta(k.Groups))/l
+	b := k.power + h*k.Source
+	for i, g := range k.Groups {
+		d := 1 + h*g.Decay
+		a -= h * g.Decay * h * g.Fraction / (l * d)
+		b += h * g.Decay * k.precursors[i] / d
+	}
+	k.power = b / a
+	for i, g := range k.Groups {
+		k.precursors[i] = (k.precursors[i] + h*g.Fraction/l*k.power) / (1 + h*g.Decay)
+	}
+}
+
+// Period returns stable period in s of system of reactivity rho, the inverse of the largest
+// root of inhour equation. It's negative when power decreases and +Inf when system is
+// critical.
+func (k *Kinetics) Period(rho float64) float64 {
+	if rho == 0 {
+		return math.Inf(1)
+	}
+	// reactivity of inverse period w, it increases with w above -λ of the slowest group
+	inhour := func(w float64) float64 {
+		r := w * k.GenerationTime
+		for _, g := range k.Groups {
+			r += g.Fraction * w / (w + g.Decay)
+		}
+		return r
+	}
+	lo, hi := 0.0, 1.0
+	if rho > 0 {
+		for inhour(hi) < rho {
+			lo, hi = hi, 2*hi
+		}
+	} else {
+		slowest := math.Inf(1)
+		for _, g := range k.Groups {
+			slowest = min(slowest, g.Decay)
+		}
+		lo, hi = -slowest, 0
+	}
+	for range 200 {
+		w := (lo + hi) / 2
+		if inhour(w) < rho {
+			lo = w
+		} else {
+			hi = w
+		}
+	}
+	return 2 / (lo + hi)
+}
+
+// PromptJump returns ratio of power shortly after step insertion of reactivity rho below
+// prompt criticality to power before it.
+func (k *Kinetics) PromptJump(rho float64) float64 {
+	beta := Beta(k.Groups)
+	return beta / (beta - rho)
+}
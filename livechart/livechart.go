@@ -0,0 +1,181 @@
+// Package livechart fetches ground state nuclide properties from IAEA Livechart API
+// and caches responses on disk.
+package livechart
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"physics/isotope"
+)
+
+// DefaultURL is address of IAEA Livechart data API.
+const DefaultURL = "https://nds.iaea.org/relnsd/v0/data"
+
+// Client fetches nuclide data. Zero value is usable and doesn't cache responses.
+type Client struct {
+	// BaseURL of API, DefaultURL if empty.
+	BaseURL string
+
+	// CacheDir is directory of cached responses. Caching is disabled if empty.
+	CacheDir string
+
+	// MaxAge of cached response, after which it's fetched again. Zero means never expires.
+	MaxAge time.Duration
+
+	HTTPClient *http.Client
+}
+
+// Fetch returns ground states of nuclides, e.g. "235u" or "all".
+func (c *Client) Fetch(ctx context.Context, nuclides string) ([]*isotope.Isotope, error) {
+	data, err := c.get(ctx, url.Values{"fields": {"ground_states"}, "nuclides": {nuclides}})
+	if err != nil {
+		return nil, err
+	}
+	return Parse(strings.NewReader(string(data)))
+}
+
+// Update fetches nuclides and merges them into isotopes table.
+func (c *Client) Update(ctx context.Context, nuclides string) error {
+	isos, err := c.Fetch(ctx, nuclides)
+	if err != nil {
+		return err
+	}
+	return isotope.Merge(isos)
+}
+
+func (c *Client) get(ctx context.Context, query url.Values) ([]byte, error) {
+	base := c.BaseURL
+	if base == "" {
+		base = DefaultURL
+	}
+	u := base + "?" + query.Encode()
+
+	cache := c.cachePath(u)
+	if data, ok := c.cached(cache); ok {
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	// API rejects requests without user agent
+	req.Header.Set("User-Agent", "fission-mc")
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("livechart: %s: %s", u, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if cache != "" {
+		if err := os.MkdirAll(c.CacheDir, 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(cache, data, 0644); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+func (c *Client) cachePath(u string) string {
+	if c.CacheDir == "" {
+		return ""
+	}
+	sum := sha1.Sum([]byte(u))
+	return filepath.Join(c.CacheDir, hex.EncodeToString(sum[:])+".csv")
+}
+
+func (c *Client) cached(path string) ([]byte, bool) {
+	if path == "" {
+		return nil, false
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	if c.MaxAge > 0 && time.Since(info.ModTime()) > c.MaxAge {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	return data, err == nil
+}
+
+// Parse parses ground states CSV returned by the API. Binding energy is given
+// in keV per nucleon and atomic mass in micro atomic mass units.
+func Parse(r io.Reader) ([]*isotope.Isotope, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{"z", "n", "symbol"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("livechart: missing %q column", name)
+		}
+	}
+	get := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	number := func(record []string, name string) float64 {
+		v, _ := strconv.ParseFloat(get(record, name), 64)
+		return v
+	}
+
+	var isos []*isotope.Isotope
+	for _, record := range records[1:] {
+		z, err := strconv.Atoi(get(record, "z"))
+		if err != nil {
+			continue
+		}
+		n, err := strconv.Atoi(get(record, "n"))
+		if err != nil {
+			continue
+		}
+		a := z + n
+		isos = append(isos, &isotope.Isotope{
+			Symbol:     get(record, "symbol"),
+			Number:     z,
+			Mass:       a,
+			AtomicMass: number(record, "atomic_mass") / 1e6,
+			Binding:    number(record, "binding") * float64(a) / 1000,
+			Abundance:  number(record, "abundance"),
+			SpinParity: strings.TrimSpace(get(record, "jp")),
+		})
+	}
+	return isos, nil
+}
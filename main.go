@@ -1,34 +1,87 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/spf13/cobra"
+
 	"physics/isotope"
+	"physics/plot"
 )
 
 func main() {
-	iso := isotope.U235()
+	root := &cobra.Command{
+		Use:          "fission-mc",
+		Short:        "Monte Carlo simulation of nuclear fission products",
+		SilenceUsage: true,
+	}
+	root.AddCommand(
+		simulateCmd(),
+		chartCmd(),
+		statsCmd(),
+		dataCmd(),
+		sweepSeedsCmd(),
+		verifyCmd(),
+		dashboardCmd(),
+		serveCmd(),
+		kineticsCmd(),
+		reactionCmd(),
+		depleteCmd(),
+		eventsCmd(),
+	)
+	// interrupt stops running simulation, its partial results are still saved
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := root.ExecuteContext(ctx); err != nil {
+		os.Exit(1)
+	}
+}
 
-	var products isotope.Products
-	var neutrons []int
+// outputFlags are flags of commands which save files.
+type outputFlags struct {
+	dir       string
+	prefix    string
+	overwrite string
+	image     string
+	compress  string
+}
 
-	for i := 0; i < 10000; i++ {
-		prods, ns, err := iso.Destabilize()
+func (o *outputFlags) add(cmd *cobra.Command) {
+	f := cmd.Flags()
+	f.StringVar(&o.dir, "out", "", "output directory")
+	f.StringVar(&o.prefix, "prefix", "", "prefix of output file names")
+	f.StringVar(&o.overwrite, "overwrite", "replace", "policy for existing output files: replace, fail or rename")
+	f.StringVar(&o.image, "image", "png", "format of charts: png or svg")
+	f.StringVar(&o.compress, "compress", "none", "compression of data files: none, gzip or zstd")
+}
 
-		if err != nil {
-			// do something there...
-		}
-		products = append(products, prods...)
-		neutrons = append(neutrons, ns)
+func (o *outputFlags) config() (isotope.OutputConfig, error) {
+	policy, err := isotope.ParseOverwrite(o.overwrite)
+	if err != nil {
+		return isotope.OutputConfig{}, err
 	}
+	compression, err := isotope.ParseCompression(o.compress)
+	if err != nil {
+		return isotope.OutputConfig{}, err
+	}
+	image, err := isotope.ParseImageFormat(o.image)
+	if err != nil {
+		return isotope.OutputConfig{}, err
+	}
+	return isotope.OutputConfig{Dir: o.dir, Prefix: o.prefix, Overwrite: policy, Compression: compression, Image: image}, nil
+}
 
-	symbols := products.CountSymbols()
-	probs := products.CountProbabilities()
-	groups := products.CountIsotopes()
-
-	symbols.SaveJson()
-	groups.SaveJson()
-	probs.SaveJson()
-
-	symbols.SaveChart()
-	probs.SaveChart()
-	groups.SaveChart()
+// parseRenderer parses name of library which draws charts, "gochart" or "gonum".
+func parseRenderer(name string) (isotope.Renderer, error) {
+	switch strings.ToLower(name) {
+	case "", "gochart":
+		return nil, nil
+	case "gonum":
+		return plot.Renderer{}, nil
+	}
+	return nil, fmt.Errorf("unknown chart renderer %q", name)
 }
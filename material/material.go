@@ -0,0 +1,196 @@
+// Package material describes simple lumped reactor materials, so effects of fuel
+// composition and arrangement on multiplication can be illustrated without full geometry.
+package material
+
+import "math"
+
+// Nuclide holds thermal and resonance data of a nuclide used by the lumped models.
+type Nuclide struct {
+	Name string
+
+	// Microscopic cross sections at 0.0253 eV in barns.
+	Absorption float64
+	Fission    float64
+	Scattering float64
+
+	// Average number of neutrons released per thermal fission.
+	Nu float64
+
+	// Average logarithmic energy decrement per collision.
+	Xi float64
+
+	// Infinitely dilute capture resonance integral in barns.
+	ResonanceIntegral float64
+}
+
+var (
+	// U235 is Uranium-235 nuclide.
+	U235 = Nuclide{Name: "U-235", Absorption: 680.9, Fission: 582.6, Scattering: 15.0, Nu: 2.43, Xi: 0.0085}
+
+	// U238 is Uranium-238 nuclide.
+	U238 = Nuclide{Name: "U-238", Absorption: 2.68, Scattering: 9.0, Xi: 0.0084, ResonanceIntegral: 275}
+
+	// H1 is hydrogen nuclide.
+	H1 = Nuclide{Name: "H-1", Absorption: 0.332, Scattering: 20.4, Xi: 1.0}
+
+	// O16 is oxygen nuclide.
+	O16 = Nuclide{Name: "O-16", Absorption: 0.00019, Scattering: 3.8, Xi: 0.120}
+)
+
+// Component is a nuclide with its relative number of atoms in a material.
+type Component struct {
+	Nuclide Nuclide
+	Atoms   float64
+}
+
+// Lumping describes how fuel is arranged in moderator.
+// Zero value means fuel is homogeneously mixed with moderator.
+type Lumping struct {
+	// Radius of a cylindrical fuel pin in cm.
+	Radius float64
+
+	// Density of U-238 inside the fuel pin in atoms/(barn*cm).
+	Density float64
+}
+
+// Homogeneous reports whether fuel is mixed with moderator.
+func (l Lumping) Homogeneous() bool {
+	return l.Radius <= 0 || l.Density <= 0
+}
+
+// Material is a fuel and moderator mixture. Atoms of components are relative,
+// usually per one uranium atom.
+type Material struct {
+	Name      string
+	Fuel      []Component
+	Moderator []Component
+
+	// Lumping of fuel, used for resonance self-shielding.
+	Lumping Lumping
+}
+
+// UO2Water returns uranium dioxide fuel with given U-235 enrichment (atom fraction)
+// and number of water molecules per uranium atom.
+func UO2Water(enrichment, waterRatio float64) Material {
+	return Material{
+		Name: "UO2-H2O",
+		Fuel: []Component{
+			{Nuclide: U235, Atoms: enrichment},
+			{Nuclide: U238, Atoms: 1 - enrichment},
+			{Nuclide: O16, Atoms: 2},
+		},
+		Moderator: []Component{
+			{Nuclide: H1, Atoms: 2 * waterRatio},
+			{Nuclide: O16, Atoms: waterRatio},
+		},
+	}
+}
+
+// Bell factor of the rational (Wigner) escape approximation for a cylinder.
+const bell = 1.16
+
+// Parameter of self-shielding factor fitted to Hellstrand's U-238 correlation in barns.
+const shielding = 13400
+
+// Dilution is background cross section per U-238 atom (sigma zero) in barns.
+// Moderator dilutes resonances only when fuel is homogeneous, lumped fuel gets
+// an escape cross section based on mean chord length of the pin instead.
+func (m Material) Dilution() float64 {
+	n := atoms(m.Fuel, U238.Name)
+	if n == 0 {
+		return math.Inf(1)
+	}
+	var sigma float64
+	for _, c := range m.Fuel {
+		if c.Nuclide.Name != U238.Name {
+			sigma += c.Atoms * c.Nuclide.Scattering
+		}
+	}
+	sigma /= n
+
+	if m.Lumping.Homogeneous() {
+		for _, c := range m.Moderator {
+			sigma += c.Atoms * c.Nuclide.Scattering / n
+		}
+		return sigma
+	}
+	chord := 2 * m.Lumping.Radius
+	return sigma + bell/(m.Lumping.Density*chord)
+}
+
+// SelfShielding returns ratio of effective to infinitely dilute U-238 capture resonance integral.
+func (m Material) SelfShielding() float64 {
+	d := m.Dilution()
+	if math.IsInf(d, 1) {
+		return 1
+	}
+	return math.Sqrt(d / (d + shielding))
+}
+
+// ResonanceIntegral is effective U-238 capture resonance integral in barns.
+func (m Material) ResonanceIntegral() float64 {
+	return U238.ResonanceIntegral * m.SelfShielding()
+}
+
+// ResonanceEscape is probability that a neutron slows down past U-238 resonances without capture.
+func (m Material) ResonanceEscape() float64 {
+	var moderating float64
+	for _, c := range m.components() {
+		moderating += c.Atoms * c.Nuclide.Xi * c.Nuclide.Scattering
+	}
+	if moderating == 0 {
+		return 0
+	}
+	return math.Exp(-atoms(m.Fuel, U238.Name) * m.ResonanceIntegral() / moderating)
+}
+
+// ThermalUtilization is fraction of thermal neutrons absorbed in fuel.
+func (m Material) ThermalUtilization() float64 {
+	fuel := absorption(m.Fuel)
+	total := fuel + absorption(m.Moderator)
+	if total == 0 {
+		return 0
+	}
+	return fuel / total
+}
+
+// Eta is number of fission neutrons produced per thermal neutron absorbed in fuel.
+func (m Material) Eta() float64 {
+	var produced float64
+	for _, c := range m.Fuel {
+		produced += c.Atoms * c.Nuclide.Nu * c.Nuclide.Fission
+	}
+	a := absorption(m.Fuel)
+	if a == 0 {
+		return 0
+	}
+	return produced / a
+}
+
+// KInfinity is infinite multiplication factor from four factor formula.
+// Fast fission factor is taken as one.
+func (m Material) KInfinity() float64 {
+	return m.Eta() * m.ThermalUtilization() * m.ResonanceEscape()
+}
+
+func (m Material) components() []Component {
+	return append(append([]Component{}, m.Fuel...), m.Moderator...)
+}
+
+func atoms(cs []Component, name string) float64 {
+	var n float64
+	for _, c := range cs {
+		if c.Nuclide.Name == name {
+			n += c.Atoms
+		}
+	}
+	return n
+}
+
+func absorption(cs []Component) float64 {
+	var a float64
+	for _, c := range cs {
+		a += c.Atoms * c.Nuclide.Absorption
+	}
+	return a
+}
@@ -0,0 +1,144 @@
+package material
+
+import "math"
+
+// PinCell is a two region heterogeneous lattice cell, a cylindrical fuel pin surrounded
+// by moderator in a square lattice. Atoms of components are number densities in atoms/(barn*cm).
+type PinCell struct {
+	// Radius of a fuel pin in cm.
+	Radius float64
+
+	// Pitch of a square lattice in cm.
+	Pitch float64
+
+	Fuel      []Component
+	Moderator []Component
+}
+
+// UO2Pin returns cell of uranium dioxide pin with given U-235 enrichment (atom fraction)
+// in light water at room temperature.
+func UO2Pin(enrichment, radius, pitch float64) PinCell {
+	const (
+		uranium = 0.02319 // UO2 of 10.4 g/cm³
+		water   = 0.03343 // H2O of 1.0 g/cm³
+	)
+	return PinCell{
+		Radius: radius,
+		Pitch:  pitch,
+		Fuel: []Component{
+			{Nuclide: U235, Atoms: enrichment * uranium},
+			{Nuclide: U238, Atoms: (1 - enrichment) * uranium},
+			{Nuclide: O16, Atoms: 2 * uranium},
+		},
+		Moderator: []Component{
+			{Nuclide: H1, Atoms: 2 * water},
+			{Nuclide: O16, Atoms: water},
+		},
+	}
+}
+
+// FuelVolume is volume of fuel per unit height of a cell in cm².
+func (c PinCell) FuelVolume() float64 {
+	return math.Pi * c.Radius * c.Radius
+}
+
+// ModeratorVolume is volume of moderator per unit height of a cell in cm².
+func (c PinCell) ModeratorVolume() float64 {
+	return math.Max(c.Pitch*c.Pitch-c.FuelVolume(), 0)
+}
+
+// Homogenized returns material with fuel and moderator smeared over a whole cell.
+func (c PinCell) Homogenized() Material {
+	return Material{
+		Name:      "homogenized pin cell",
+		Fuel:      scale(c.Fuel, c.FuelVolume()),
+		Moderator: scale(c.Moderator, c.ModeratorVolume()),
+	}
+}
+
+// Lumped returns homogenized material that keeps fuel lumping for resonance self-shielding.
+func (c PinCell) Lumped() Material {
+	m := c.Homogenized()
+	m.Name = "pin cell"
+	m.Lumping = Lumping{Radius: c.Radius, Density: atoms(c.Fuel, U238.Name)}
+	return m
+}
+
+// ThermalUtilization is fraction of thermal neutrons absorbed in fuel. Thermal flux
+// in both regions is found by one group collision probability method with flat
+// slowing down source in moderator and rational approximation of pin escape probability.
+func (c PinCell) ThermalUtilization() float64 {
+	vf, vm := c.FuelVolume(), c.ModeratorVolume()
+	af, sf := absorption(c.Fuel), scattering(c.Fuel)
+	am, sm := absorption(c.Moderator), scattering(c.Moderator)
+	tf, tm := af+sf, am+sm
+	if vm == 0 || tf == 0 || tm == 0 {
+		return c.Homogenized().ThermalUtilization()
+	}
+
+	// Collision probabilities, every neutron escaping the pin collides in moderator first.
+	escape := 1 / (1 + tf*2*c.Radius)
+	pff := 1 - escape
+	pfm := escape
+	pmf := math.Min(vf*tf*pfm/(vm*tm), 1)
+	pmm := 1 - pmf
+
+	// Balance of collisions in each region for unit source density in moderator:
+	//   vf*tf*ff = pff*vf*sf*ff + pmf*vm*(1 + sm*fm)
+	//   vm*tm*fm = pfm*vf*sf*ff + pmm*vm*(1 + sm*fm)
+	a11, a12, b1 := vf*tf-pff*vf*sf, -pmf*vm*sm, pmf*vm
+	a21, a22, b2 := -pfm*vf*sf, vm*tm-pmm*vm*sm, pmm*vm
+	det := a11*a22 - a12*a21
+	if det == 0 {
+		return c.Homogenized().ThermalUtilization()
+	}
+	ff := (b1*a22 - a12*b2) / det
+	fm := (a11*b2 - a21*b1) / det
+
+	fuel := af * vf * ff
+	return fuel / (fuel + am*vm*fm)
+}
+
+// DisadvantageFactor is ratio of average thermal flux in moderator to flux in fuel.
+func (c PinCell) DisadvantageFactor() float64 {
+	f := c.ThermalUtilization()
+	vf, vm := c.FuelVolume(), c.ModeratorVolume()
+	af, am := absorption(c.Fuel), absorption(c.Moderator)
+	if f == 0 || am == 0 || vm == 0 {
+		return 1
+	}
+	// f = af*vf / (af*vf + am*vm*d)
+	return af * vf * (1 - f) / (f * am * vm)
+}
+
+// ResonanceEscape is U-238 resonance escape probability with self-shielding of lumped fuel.
+func (c PinCell) ResonanceEscape() float64 {
+	return c.Lumped().ResonanceEscape()
+}
+
+// Eta is number of fission neutrons produced per thermal neutron absorbed in fuel.
+func (c PinCell) Eta() float64 {
+	return c.Homogenized().Eta()
+}
+
+// KInfinity is infinite multiplication factor of heterogeneous lattice.
+// Compare with Homogenized().KInfinity() to see the effect of fuel lumping.
+func (c PinCell) KInfinity() float64 {
+	return c.Eta() * c.ThermalUtilization() * c.ResonanceEscape()
+}
+
+func scale(cs []Component, factor float64) []Component {
+	scaled := make([]Component, len(cs))
+	for i, c := range cs {
+		scaled[i] = Component{Nuclide: c.Nuclide, Atoms: c.Atoms * factor}
+	}
+	return scaled
+}
+
+func scattering(cs []Component) float64 {
+	var s float64
+	for _, c := range cs {
+		s += c.Atoms * c.Nuclide.Scattering
+	}
+	return s
+}
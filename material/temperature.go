@@ -0,0 +1,71 @@
+package material
+
+// RoomTemperature is temperature in K at which built-in number densities are given.
+const RoomTemperature = 293.15
+
+// Expansion describes how density of a region changes with temperature.
+// Zero value keeps density constant.
+type Expansion struct {
+	// Coefficient is volumetric thermal expansion coefficient in 1/K.
+	Coefficient float64
+
+	// Reference is temperature in K at which number densities are given.
+	// RoomTemperature is used if zero.
+	Reference float64
+
+	// Density, if not nil, replaces linear expansion and returns density at temperature
+	// relative to density at reference temperature.
+	Density func(temperature float64) float64
+}
+
+// Factor returns relative density at temperature in K.
+func (e Expansion) Factor(temperature float64) float64 {
+	if e.Density != nil {
+		return e.Density(temperature)
+	}
+	ref := e.Reference
+	if ref == 0 {
+		ref = RoomTemperature
+	}
+	f := 1 + e.Coefficient*(temperature-ref)
+	if f <= 0 {
+		return 0
+	}
+	return 1 / f
+}
+
+// FuelExpansion is thermal expansion of uranium dioxide.
+var FuelExpansion = Expansion{Coefficient: 3.3e-5}
+
+// WaterExpansion is thermal expansion of pressurized light water, fitted to
+// densities at 20, 150 and 300 °C.
+var WaterExpansion = Expansion{Density: func(temperature float64) float64 {
+	t := temperature - 273.15
+	density := 1.00249 - 1.7161e-4*t - 2.6557e-6*t*t
+	return density / 0.998
+}}
+
+// Temperatures of fuel and moderator in K, e.g. from lumped temperature model of a transient.
+type Temperatures struct {
+	Fuel      float64
+	Moderator float64
+}
+
+// AtTemperature returns material with number densities of fuel and moderator
+// changed by their thermal expansion.
+func (m Material) AtTemperature(fuel, moderator Expansion, t Temperatures) Material {
+	m.Fuel = scale(m.Fuel, fuel.Factor(t.Fuel))
+	m.Moderator = scale(m.Moderator, moderator.Factor(t.Moderator))
+	if m.Lumping.Density != 0 {
+		m.Lumping.Density *= fuel.Factor(t.Fuel)
+	}
+	return m
+}
+
+// AtTemperature returns cell with number densities of fuel and moderator
+// changed by their thermal expansion. Cell dimensions are kept.
+func (c PinCell) AtTemperature(fuel, moderator Expansion, t Temperatures) PinCell {
+	c.Fuel = scale(c.Fuel, fuel.Factor(t.Fuel))
+	c.Moderator = scale(c.Moderator, moderator.Factor(t.Moderator))
+	return c
+}
@@ -0,0 +1,343 @@
+// Package pb encodes isotopes, fission events and aggregated results as Protocol Buffers
+// messages defined in fission.proto.
+package pb
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"physics/count"
+	"physics/fission"
+	"physics/isotope"
+)
+
+func init() {
+	fission.RegisterProtobuf(func(r *fission.Results, out isotope.OutputConfig) error {
+		return NewResults(r).Save(out)
+	})
+}
+
+// MarshalIsotope encodes isotope as Isotope message. Metadata is not encoded.
+func MarshalIsotope(iso *isotope.Isotope) []byte {
+	return appendIsotope(nil, iso)
+}
+
+// UnmarshalIsotope decodes Isotope message.
+func UnmarshalIsotope(b []byte) (*isotope.Isotope, error) {
+	iso := &isotope.Isotope{}
+	err := decode(b, func(num protowire.Number, v value) error {
+		switch num {
+		case 1:
+			iso.Symbol = string(v.bytes)
+		case 2:
+			iso.Number = int(int32(v.varint))
+		case 3:
+			iso.Mass = int(int32(v.varint))
+		case 4:
+			iso.AtomicMass = v.double()
+		case 5:
+			iso.Binding = v.double()
+		case 6:
+			iso.Abundance = v.double()
+		case 7:
+			iso.SpinParity = string(v.bytes)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return iso, nil
+}
+
+// MarshalEvent encodes fission event as Event message.
+func MarshalEvent(e fission.Event) []byte {
+	var b []byte
+	if e.Parent != nil {
+		b = appendMessage(b, 1, appendIsotope(nil, e.Parent))
+	}
+	for _, p := range e.Products {
+		b = appendMessage(b, 2, appendIsotope(nil, p))
+	}
+	b = appendVarint(b, 3, uint64(int64(e.Neutrons)))
+	if e.FragmentNeutrons != [2]int{} {
+		var packed []byte
+		for _, n := range e.FragmentNeutrons {
+			packed = protowire.AppendVarint(packed, uint64(int64(n)))
+		}
+		b = appendMessage(b, 4, packed)
+	}
+	return b
+}
+
+// UnmarshalEvent decodes Event message.
+func UnmarshalEvent(b []byte) (fission.Event, error) {
+	var e fission.Event
+	fragments := 0
+	err := decode(b, func(num protowire.Number, v value) error {
+		switch num {
+		case 1, 2:
+			iso, err := UnmarshalIsotope(v.bytes)
+			if err != nil {
+				return err
+			}
+			if num == 1 {
+				e.Parent = iso
+			} else {
+				e.Products = append(e.Products, iso)
+			}
+		case 3:
+			e.Neutrons = int(int32(v.varint))
+		case 4:
+			// repeated scalars are packed, but unpacked values must be accepted too
+			values := []uint64{v.varint}
+			if v.bytes != nil {
+				values = values[:0]
+				for b := v.bytes; len(b) > 0; {
+					n, size := protowire.ConsumeVarint(b)
+					if size < 0 {
+						return fmt.Errorf("pb: field 4: %w", protowire.ParseError(size))
+					}
+					values = append(values, n)
+					b = b[size:]
+				}
+			}
+			for _, n := range values {
+				if fragments < len(e.FragmentNeutrons) {
+					e.FragmentNeutrons[fragments] = int(int32(n))
+				}
+				fragments++
+			}
+		}
+		return nil
+	})
+	return e, err
+}
+
+// Results are aggregated results of simulation, as exchanged in Results message.
+type Results struct {
+	Parent   *isotope.Isotope
+	Seed     int64
+	Fissions int
+	Failures int
+
+	// Symbols maps element symbol to number of products.
+	Symbols map[string]int
+
+	// Isotopes maps isotope name to number of products.
+	Isotopes map[string]int
+
+	// Neutrons maps multiplicity to number of fissions.
+	Neutrons map[int]int
+
+	// Energy is total energy released in fissions in MeV.
+	Energy  float64
+	Elapsed time.Duration
+}
+
+// NewResults aggregates results of simulation.
+func NewResults(r *fission.Results) Results {
+	ns := r.NeutronStats()
+	res := Results{
+		Parent:   r.Parent,
+		Seed:     r.Seed,
+		Fissions: ns.Fissions,
+		Failures: r.Failures,
+		Symbols:  r.Tally.CountSymbols(),
+		Isotopes: make(map[string]int),
+		Neutrons: ns.Histogram,
+		Energy:   r.Energy,
+		Elapsed:  r.Elapsed,
+	}
+	for _, isos := range r.Tally.CountIsotopes() {
+		for name, n := range isos {
+			res.Isotopes[name] += n
+		}
+	}
+	return res
+}
+
+// Marshal encodes results as Results message. Counts are ordered by key, so encoding is deterministic.
+func (r Results) Marshal() []byte {
+	var b []byte
+	if r.Parent != nil {
+		b = appendMessage(b, 1, appendIsotope(nil, r.Parent))
+	}
+	b = appendVarint(b, 2, uint64(r.Seed))
+	b = appendVarint(b, 3, uint64(r.Fissions))
+	b = appendVarint(b, 4, uint64(r.Failures))
+	for _, counts := range []struct {
+		num protowire.Number
+		m   map[string]int
+	}{{5, r.Symbols}, {6, r.Isotopes}} {
+		for _, name := range count.SortedKeys(counts.m) {
+			c := protowire.AppendTag(nil, 1, protowire.BytesType)
+			c = protowire.AppendString(c, name)
+			c = appendVarint(c, 2, uint64(counts.m[name]))
+			b = appendMessage(b, counts.num, c)
+		}
+	}
+	for _, n := range count.SortedKeys(r.Neutrons) {
+		m := appendVarint(nil, 1, uint64(int64(n)))
+		m = appendVarint(m, 2, uint64(r.Neutrons[n]))
+		b = appendMessage(b, 7, m)
+	}
+	b = appendDouble(b, 8, r.Energy)
+	return appendVarint(b, 9, uint64(r.Elapsed))
+}
+
+// WriteTo writes encoded message to w.
+func (r Results) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(r.Marshal())
+	return int64(n), err
+}
+
+// Save saves results to results.pb file.
+func (r Results) Save(out isotope.OutputConfig) error {
+	return out.Save("results.pb", func(w io.Writer) error {
+		_, err := r.WriteTo(w)
+		return err
+	})
+}
+
+// UnmarshalResults decodes Results message.
+func UnmarshalResults(b []byte) (Results, error) {
+	r := Results{
+		Symbols:  make(map[string]int),
+		Isotopes: make(map[string]int),
+		Neutrons: make(map[int]int),
+	}
+	err := decode(b, func(num protowire.Number, v value) error {
+		var err error
+		switch num {
+		case 1:
+			r.Parent, err = UnmarshalIsotope(v.bytes)
+		case 2:
+			r.Seed = int64(v.varint)
+		case 3:
+			r.Fissions = int(v.varint)
+		case 4:
+			r.Failures = int(v.varint)
+		case 5, 6:
+			var name string
+			var count int
+			err = decode(v.bytes, func(num protowire.Number, v value) error {
+				switch num {
+				case 1:
+					name = string(v.bytes)
+				case 2:
+					count = int(v.varint)
+				}
+				return nil
+			})
+			if num == 5 {
+				r.Symbols[name] += count
+			} else {
+				r.Isotopes[name] += count
+			}
+		case 7:
+			var n, fissions int
+			err = decode(v.bytes, func(num protowire.Number, v value) error {
+				switch num {
+				case 1:
+					n = int(int32(v.varint))
+				case 2:
+					fissions = int(v.varint)
+				}
+				return nil
+			})
+			r.Neutrons[n] += fissions
+		case 8:
+			r.Energy = v.double()
+		case 9:
+			r.Elapsed = time.Duration(v.varint)
+		}
+		return err
+	})
+	return r, err
+}
+
+func appendIsotope(b []byte, iso *isotope.Isotope) []byte {
+	if iso.Symbol != "" {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendString(b, iso.Symbol)
+	}
+	b = appendVarint(b, 2, uint64(int64(iso.Number)))
+	b = appendVarint(b, 3, uint64(int64(iso.Mass)))
+	b = appendDouble(b, 4, iso.AtomicMass)
+	b = appendDouble(b, 5, iso.Binding)
+	b = appendDouble(b, 6, iso.Abundance)
+	if iso.SpinParity != "" {
+		b = protowire.AppendTag(b, 7, protowire.BytesType)
+		b = protowire.AppendString(b, iso.SpinParity)
+	}
+	return b
+}
+
+// appendVarint appends varint field, zero values are omitted as in proto3.
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func appendDouble(b []byte, num protowire.Number, v float64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, math.Float64bits(v))
+}
+
+func appendMessage(b []byte, num protowire.Number, m []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, m)
+}
+
+// value is decoded field value, only one of fields is set depending on wire type.
+type value struct {
+	varint  uint64
+	fixed64 uint64
+	bytes   []byte
+}
+
+func (v value) double() float64 {
+	return math.Float64frombits(v.fixed64)
+}
+
+// decode calls field for each field of message b. Unknown wire types are skipped.
+func decode(b []byte, field func(protowire.Number, value) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return fmt.Errorf("pb: %w", protowire.ParseError(n))
+		}
+		b = b[n:]
+
+		var v value
+		switch typ {
+		case protowire.VarintType:
+			v.varint, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed64Type:
+			v.fixed64, n = protowire.ConsumeFixed64(b)
+		case protowire.BytesType:
+			v.bytes, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return fmt.Errorf("pb: field %d: %w", num, protowire.ParseError(n))
+		}
+		b = b[n:]
+		if err := field(num, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package pb_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"physics/fission"
+	"physics/isotope"
+	"physics/pb"
+	"physics/server"
+)
+
+// schema compiles fission.proto, so messages are checked against descriptors which clients
+// generated from it use.
+func schema(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+	c := protocompile.Compiler{Resolver: &protocompile.SourceResolver{ImportPaths: []string{"."}}}
+	files, err := c.Compile(context.Background(), "fission.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files[0]
+}
+
+type message interface {
+	Marshal() []byte
+	Unmarshal([]byte) error
+}
+
+// isotopeMessage is Isotope message of MarshalIsotope and UnmarshalIsotope.
+type isotopeMessage struct {
+	*isotope.Isotope
+}
+
+func (m isotopeMessage) Marshal() []byte {
+	return pb.MarshalIsotope(m.Isotope)
+}
+
+func (m *isotopeMessage) Unmarshal(b []byte) (err error) {
+	m.Isotope, err = pb.UnmarshalIsotope(b)
+	return err
+}
+
+func TestMessagesMatchProto(t *testing.T) {
+	fd := schema(t)
+	u235 := &isotope.Isotope{Symbol: "U", Number: 92, Mass: 235, AtomicMass: 235.0439299,
+		Binding: 1783.87, Abundance: 0.7204, SpinParity: "7/2-"}
+	xe := &isotope.Isotope{Symbol: "Xe", Number: 54, Mass: 140}
+	sr := &isotope.Isotope{Symbol: "Sr", Number: 38, Mass: 94}
+
+	tests := []struct {
+		name    string
+		msg     message
+		decoded message
+		json    string
+	}{{
+		"Isotope", &isotopeMessage{u235}, &isotopeMessage{},
+		`{"symbol": "U", "atomicNumber": 92, "massNumber": 235, "atomicMass": 235.0439299,
+			"bindingEnergy": 1783.87, "abundance": 0.7204, "spinParity": "7/2-"}`,
+	}, {
+		"Event", &pb.Event{Event: fission.Event{Parent: u235, Products: isotope.Products{xe, sr}, Neutrons: 2, FragmentNeutrons: [2]int{1, 1}}},
+		&pb.Event{},
+		`{"parent": {"symbol": "U", "atomicNumber": 92, "massNumber": 235, "atomicMass": 235.0439299,
+			"bindingEnergy": 1783.87, "abundance": 0.7204, "spinParity": "7/2-"},
+			"products": [{"symbol": "Xe", "atomicNumber": 54, "massNumber": 140}, {"symbol": "Sr", "atomicNumber": 38, "massNumber": 94}],
+			"neutrons": 2, "fragmentNeutrons": [1, 1]}`,
+	}, {
+		"Results", &pb.Results{Parent: xe, Seed: -7, Fissions: 3, Failures: 1,
+			Symbols: map[string]int{"Xe": 3, "Sr": 3}, Isotopes: map[string]int{"Xe-140": 3, "Sr-94": 3},
+			Neutrons: map[int]int{2: 2, 3: 1}, Energy: 512.5, Elapsed: 3 * time.Second},
+		&pb.Results{},
+		`{"parent": {"symbol": "Xe", "atomicNumber": 54, "massNumber": 140}, "seed": "-7", "fissions": "3", "failures": "1",
+			"symbols": [{"name": "Sr", "count": "3"}, {"name": "Xe", "count": "3"}],
+			"isotopes": [{"name": "Sr-94", "count": "3"}, {"name": "Xe-140", "count": "3"}],
+			"neutrons": [{"neutrons": 2, "fissions": "2"}, {"neutrons": 3, "fissions": "1"}],
+			"energyMev": 512.5, "elapsedNs": "3000000000"}`,
+	}, {
+		"StartRequest", &pb.StartRequest{Config: fission.Config{Isotope: "Pu-239", Events: 1000, Seed: 5, BatchSize: 100}, WaitForStream: true},
+		&pb.StartRequest{},
+		`{"isotope": "Pu-239", "events": "1000", "seed": "5", "batchSize": 100, "waitForStream": true}`,
+	}, {
+		"Simulation", &pb.Simulation{ID: 4, Seed: 9, State: "running"}, &pb.Simulation{},
+		`{"id": 4, "seed": "9", "state": "running"}`,
+	}, {
+		"SimulationRef", &pb.SimulationRef{ID: 4}, &pb.SimulationRef{},
+		`{"id": 4}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := fd.Messages().ByName(protoreflect.Name(tt.name))
+			if md == nil {
+				t.Fatalf("fission.proto has no message %s", tt.name)
+			}
+			got := dynamicpb.NewMessage(md)
+			if err := proto.Unmarshal(tt.msg.Marshal(), got); err != nil {
+				t.Fatal(err)
+			}
+			unknown(t, got)
+			want := dynamicpb.NewMessage(md)
+			if err := protojson.Unmarshal([]byte(tt.json), want); err != nil {
+				t.Fatal(err)
+			}
+			if !proto.Equal(got, want) {
+				t.Errorf("decoded by fission.proto as %v, want %v", got, want)
+			}
+
+			b, err := proto.MarshalOptions{Deterministic: true}.Marshal(want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.decoded.Unmarshal(b); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tt.decoded, tt.msg) {
+				t.Errorf("message encoded by fission.proto decoded as %+v, want %+v", tt.decoded, tt.msg)
+			}
+		})
+	}
+}
+
+// unknown fails if m or its submessages have fields which fission.proto doesn't define
+// with their number and wire type.
+func unknown(t *testing.T, m protoreflect.Message) {
+	t.Helper()
+	if len(m.GetUnknown()) > 0 {
+		t.Errorf("%s has unknown fields %x", m.Descriptor().FullName(), m.GetUnknown())
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.Message() == nil:
+		case fd.IsList():
+			for i := range v.List().Len() {
+				unknown(t, v.List().Get(i).Message())
+			}
+		default:
+			unknown(t, v.Message())
+		}
+		return true
+	})
+}
+
+func TestServiceMatchesProto(t *testing.T) {
+	sd := schema(t).Services().ByName("Simulations")
+	info, ok := server.New().GRPC().GetServiceInfo()[string(sd.FullName())]
+	if !ok {
+		t.Fatalf("server doesn't serve %s", sd.FullName())
+	}
+	methods := make(map[string]bool)
+	for _, m := range info.Methods {
+		methods[m.Name] = true
+		md := sd.Methods().ByName(protoreflect.Name(m.Name))
+		switch {
+		case md == nil:
+			t.Errorf("fission.proto has no method %s", m.Name)
+		case md.IsStreamingClient() != m.IsClientStream || md.IsStreamingServer() != m.IsServerStream:
+			t.Errorf("method %s streams client %t server %t, fission.proto client %t server %t",
+				m.Name, m.IsClientStream, m.IsServerStream, md.IsStreamingClient(), md.IsStreamingServer())
+		}
+	}
+	for i := range sd.Methods().Len() {
+		if name := string(sd.Methods().Get(i).Name()); !methods[name] {
+			t.Errorf("server doesn't serve method %s of fission.proto", name)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package pb
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"physics/fission"
+	"physics/units"
+)
+
+// StartRequest is request of StartSimulation.
+type StartRequest struct {
+	Config fission.Config
+
+	// WaitForStream delays simulation until the first StreamEvents call.
+	WaitForStream bool
+}
+
+// Marshal encodes request as StartRequest message.
+func (m StartRequest) Marshal() []byte {
+	var b []byte
+	if m.Config.Isotope != "" {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendString(b, m.Config.Isotope)
+	}
+	b = appendVarint(b, 2, uint64(m.Config.Events))
+	b = appendVarint(b, 3, uint64(m.Config.Seed))
+	b = appendVarint(b, 4, uint64(int64(m.Config.BatchSize)))
+	if m.WaitForStream {
+		b = appendVarint(b, 5, 1)
+	}
+	return b
+}
+
+// Unmarshal decodes StartRequest message.
+func (m *StartRequest) Unmarshal(b []byte) error {
+	*m = StartRequest{}
+	return decode(b, func(num protowire.Number, v value) error {
+		switch num {
+		case 1:
+			m.Config.Isotope = string(v.bytes)
+		case 2:
+			m.Config.Events = units.Count(v.varint)
+		case 3:
+			m.Config.Seed = int64(v.varint)
+		case 4:
+			m.Config.BatchSize = int(int32(v.varint))
+		case 5:
+			m.WaitForStream = v.varint != 0
+		}
+		return nil
+	})
+}
+
+// Simulation is started simulation.
+type Simulation struct {
+	ID    int
+	Seed  int64
+	State string
+}
+
+// Marshal encodes simulation as Simulation message.
+func (m Simulation) Marshal() []byte {
+	b := appendVarint(nil, 1, uint64(int64(m.ID)))
+	b = appendVarint(b, 2, uint64(m.Seed))
+	if m.State != "" {
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendString(b, m.State)
+	}
+	return b
+}
+
+// Unmarshal decodes Simulation message.
+func (m *Simulation) Unmarshal(b []byte) error {
+	*m = Simulation{}
+	return decode(b, func(num protowire.Number, v value) error {
+		switch num {
+		case 1:
+			m.ID = int(int32(v.varint))
+		case 2:
+			m.Seed = int64(v.varint)
+		case 3:
+			m.State = string(v.bytes)
+		}
+		return nil
+	})
+}
+
+// SimulationRef identifies simulation.
+type SimulationRef struct {
+	ID int
+}
+
+// Marshal encodes reference as SimulationRef message.
+func (m SimulationRef) Marshal() []byte {
+	return appendVarint(nil, 1, uint64(int64(m.ID)))
+}
+
+// Unmarshal decodes SimulationRef message.
+func (m *SimulationRef) Unmarshal(b []byte) error {
+	*m = SimulationRef{}
+	return decode(b, func(num protowire.Number, v value) error {
+		if num == 1 {
+			m.ID = int(int32(v.varint))
+		}
+		return nil
+	})
+}
+
+// Event is fission event as Event message.
+type Event struct {
+	fission.Event
+}
+
+// Marshal encodes event, see MarshalEvent.
+func (m Event) Marshal() []byte {
+	return MarshalEvent(m.Event)
+}
+
+// Unmarshal decodes Event message.
+func (m *Event) Unmarshal(b []byte) error {
+	e, err := UnmarshalEvent(b)
+	m.Event = e
+	return err
+}
+
+// Unmarshal decodes Results message.
+func (r *Results) Unmarshal(b []byte) error {
+	res, err := UnmarshalResults(b)
+	*r = res
+	return err
+}
+
+type marshaler interface {
+	Marshal() []byte
+}
+
+type unmarshaler interface {
+	Unmarshal([]byte) error
+}
+
+// Codec is gRPC codec of messages of this package. Other messages are encoded with
+// standard protobuf implementation, so codec can replace default one.
+type Codec struct{}
+
+// Name implements encoding.Codec.
+func (Codec) Name() string {
+	return "proto"
+}
+
+// Marshal implements encoding.Codec.
+func (Codec) Marshal(v any) ([]byte, error) {
+	switch m := v.(type) {
+	case marshaler:
+		return m.Marshal(), nil
+	case proto.Message:
+		return proto.Marshal(m)
+	}
+	return nil, fmt.Errorf("pb: can't marshal %T", v)
+}
+
+// Unmarshal implements encoding.Codec.
+func (Codec) Unmarshal(data []byte, v any) error {
+	switch m := v.(type) {
+	case unmarshaler:
+		return m.Unmarshal(data)
+	case proto.Message:
+		return proto.Unmarshal(data, m)
+	}
+	return fmt.Errorf("pb: can't unmarshal %T", v)
+}
@@ -0,0 +1,139 @@
+// Package periodic renders periodic table of elements colored by yields of products, so it
+// shows at a glance which elements dominate products of fission.
+package periodic
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+
+	"physics/isotope"
+)
+
+// Symbols are symbols of elements by atomic number.
+var Symbols = [...]string{"",
+	"H", "He", "Li", "Be", "B", "C", "N", "O", "F", "Ne", "Na", "Mg", "Al", "Si", "P", "S", "Cl", "Ar",
+	"K", "Ca", "Sc", "Ti", "V", "Cr", "Mn", "Fe", "Co", "Ni", "Cu", "Zn", "Ga", "Ge", "As", "Se", "Br", "Kr",
+	"Rb", "Sr", "Y", "Zr", "Nb", "Mo", "Tc", "Ru", "Rh", "Pd", "Ag", "Cd", "In", "Sn", "Sb", "Te", "I", "Xe",
+	"Cs", "Ba", "La", "Ce", "Pr", "Nd", "Pm", "Sm", "Eu", "Gd", "Tb", "Dy", "Ho", "Er", "Tm", "Yb", "Lu",
+	"Hf", "Ta", "W", "Re", "Os", "Ir", "Pt", "Au", "Hg", "Tl", "Pb", "Bi", "Po", "At", "Rn",
+	"Fr", "Ra", "Ac", "Th", "Pa", "U", "Np", "Pu", "Am", "Cm", "Bk", "Cf", "Es", "Fm", "Md", "No", "Lr",
+	"Rf", "Db", "Sg", "Bh", "Hs", "Mt", "Ds", "Rg", "Cn", "Nh", "Fl", "Mc", "Lv", "Ts", "Og",
+}
+
+// Position returns row and column, from one, of element of atomic number in table of 18
+// groups. Lanthanides and actinides are in rows 9 and 10 below the table, from column 3.
+func Position(number int) (row, col int) {
+	switch {
+	case number == 1:
+		return 1, 1
+	case number == 2:
+		return 1, 18
+	case number <= 18:
+		row, i := 2+(number-3)/8, (number-3)%8
+		if i < 2 {
+			return row, i + 1
+		}
+		return row, i + 11
+	case number <= 54:
+		return 4 + (number-19)/18, (number-19)%18 + 1
+	}
+	start, row := 55, 6
+	if number >= 87 {
+		start, row = 87, 7
+	}
+	switch i := number - start; {
+	case i < 2:
+		return row, i + 1
+	case i < 17:
+		return row + 3, i + 1
+	default:
+		return row, i - 13
+	}
+}
+
+// Table is periodic table of yields of elements among products.
+type Table struct {
+	// Yields of elements per fission in percent by atomic number.
+	Yields map[int]float64
+}
+
+// FromCounts returns table of yields of counts of products of fissions, isotopes of an
+// element, e.g. H and D, share its yield.
+func FromCounts(counts []isotope.Count, fissions int) Table {
+	t := Table{Yields: make(map[int]float64)}
+	for _, c := range counts {
+		t.Yields[c.Isotope.Number] += 100 * float64(c.Count) / float64(max(fissions, 1))
+	}
+	return t
+}
+
+// Saves table to .svg file
+func (t Table) Save(out isotope.OutputConfig, opts isotope.ChartOptions) error {
+	return out.Save("periodic.svg", func(w io.Writer) error {
+		return t.WriteSVG(w, opts)
+	})
+}
+
+// cell is size of a cell of element in pixels, of default size of image.
+const cell = 50
+
+// WriteSVG writes svg of table to w. Elements are colored on logarithmic scale of their yields
+// from white to the first color of options, elements without products are grey.
+func (t Table) WriteSVG(out io.Writer, opts isotope.ChartOptions) error {
+	r, g, b := 0xd6, 0x27, 0x28
+	if len(opts.Colors) > 0 {
+		fmt.Sscanf(strings.TrimPrefix(opts.Colors[0], "#"), "%02x%02x%02x", &r, &g, &b)
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, y := range t.Yields {
+		if y > 0 {
+			lo, hi = min(lo, math.Log10(y)), max(hi, math.Log10(y))
+		}
+	}
+	title := "Yields of elements per fission"
+	if opts.Title != "" {
+		title = opts.Title
+	}
+	width, height := 18*cell+40, 10*cell+100
+	w, h := width, height
+	if opts.Width > 0 {
+		w = opts.Width
+	}
+	if opts.Height > 0 {
+		h = opts.Height
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n",
+		w, h, width, height)
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="white"/>`+"\n", width, height)
+	fmt.Fprintf(&sb, `<text x="%d" y="36" font-size="22" text-anchor="middle">%s</text>`+"\n", width/2, html.EscapeString(title))
+	for z := 1; z < len(Symbols); z++ {
+		row, col := Position(z)
+		x, y := 20+(col-1)*cell, 60+(row-1)*cell
+		if row > 7 {
+			y += cell / 2
+		}
+		fill := "#eeeeee"
+		label := ""
+		if v := t.Yields[z]; v > 0 {
+			f := 1.0
+			if hi > lo {
+				f = 0.15 + 0.85*(math.Log10(v)-lo)/(hi-lo)
+			}
+			blend := func(c int) int { return int(math.Round(255 + f*float64(c-255))) }
+			fill = fmt.Sprintf("#%02x%02x%02x", blend(r), blend(g), blend(b))
+			label = fmt.Sprintf("%.2g%%", v)
+		}
+		fmt.Fprintf(&sb, `<g><title>%s %s</title><rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="white"/>`,
+			Symbols[z], label, x, y, cell, cell, fill)
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="9">%d</text>`, x+3, y+11, z)
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="17" text-anchor="middle">%s</text>`, x+cell/2, y+31, Symbols[z])
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="8" text-anchor="middle">%s</text></g>`+"\n", x+cell/2, y+44, label)
+	}
+	sb.WriteString("</svg>\n")
+	_, err := io.WriteString(out, sb.String())
+	return err
+}
@@ -0,0 +1,141 @@
+//go:build !nochart
+
+// Package plot renders charts by gonum/plot, whose vector graphics are of publication quality.
+package plot
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"strings"
+
+	gonum "gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+
+	"physics/isotope"
+)
+
+// Renderer is isotope.Renderer of gonum/plot. Bars have error bars of values which have
+// errors and shares are bars of percent of total.
+type Renderer struct{}
+
+// Bars renders bar chart of values in format to w
+func (Renderer) Bars(w io.Writer, format isotope.ImageFormat, p isotope.Plot, opts isotope.ChartOptions) (err error) {
+	defer recoverPlot(&err)
+
+	pl := newPlot(p, opts)
+	values := make(plotter.Values, len(p.Values))
+	labels := make([]string, len(p.Values))
+	errs := make(plotter.YErrors, len(p.Values))
+	hasErrors := false
+	for i, v := range p.Values {
+		values[i], labels[i] = v.Value, v.Label
+		errs[i].Low, errs[i].High = v.Error, v.Error
+		hasErrors = hasErrors || v.Error > 0
+	}
+	bars, err := plotter.NewBarChart(values, vg.Points(max(2, min(20, 600/float64(max(1, len(values)))))))
+	if err != nil {
+		return err
+	}
+	bars.LineStyle.Width = 0
+	bars.Color = barColor(opts)
+	pl.Add(bars)
+	if hasErrors {
+		xys := make(plotter.XYs, len(values))
+		for i, v := range values {
+			xys[i] = plotter.XY{X: float64(i), Y: v}
+		}
+		e, err := plotter.NewYErrorBars(struct {
+			plotter.XYs
+			plotter.YErrors
+		}{xys, errs})
+		if err != nil {
+			return err
+		}
+		pl.Add(e)
+	}
+	pl.NominalX(labels...)
+	if len(labels) > 12 {
+		pl.X.Tick.Label.Rotation = math.Pi / 2
+		pl.X.Tick.Label.XAlign = draw.XRight
+		pl.X.Tick.Label.YAlign = draw.YCenter
+	}
+	if opts.YMax > opts.YMin {
+		pl.Y.Min, pl.Y.Max = opts.YMin, opts.YMax
+	}
+	width, height := size(opts, max(640, 120+len(values)*14), 480)
+	wt, err := pl.WriterTo(width, height, string(format))
+	if err != nil {
+		return err
+	}
+	_, err = wt.WriteTo(w)
+	return err
+}
+
+// Shares renders bar chart of values in percent of their total in format to w
+func (r Renderer) Shares(w io.Writer, format isotope.ImageFormat, p isotope.Plot, opts isotope.ChartOptions) error {
+	total := 0.0
+	for _, v := range p.Values {
+		total += v.Value
+	}
+	if total == 0 {
+		return r.Bars(w, format, p, opts)
+	}
+	shares := p
+	shares.YLabel = "share [%]"
+	shares.Values = make([]isotope.ChartValue, len(p.Values))
+	for i, v := range p.Values {
+		shares.Values[i] = isotope.ChartValue{Label: v.Label, Value: 100 * v.Value / total, Error: 100 * v.Error / total}
+	}
+	return r.Bars(w, format, shares, opts)
+}
+
+func newPlot(p isotope.Plot, opts isotope.ChartOptions) *gonum.Plot {
+	pl := gonum.New()
+	pl.Title.Text = p.Title
+	if opts.Title != "" {
+		pl.Title.Text = opts.Title
+	}
+	pl.X.Label.Text = p.XLabel
+	pl.Y.Label.Text = p.YLabel
+	if opts.FontSize > 0 {
+		size := vg.Points(opts.FontSize)
+		pl.Title.TextStyle.Font.Size = 1.2 * size
+		for _, a := range []*gonum.Axis{&pl.X, &pl.Y} {
+			a.Label.TextStyle.Font.Size = size
+			a.Tick.Label.Font.Size = 0.8 * size
+		}
+	}
+	pl.Add(plotter.NewGrid())
+	return pl
+}
+
+// size returns size of image of options in pixels, of 96 per inch, or default size.
+func size(opts isotope.ChartOptions, width, height int) (vg.Length, vg.Length) {
+	if opts.Width > 0 {
+		width = opts.Width
+	}
+	if opts.Height > 0 {
+		height = opts.Height
+	}
+	return vg.Length(width) * vg.Inch / 96, vg.Length(height) * vg.Inch / 96
+}
+
+// barColor returns the first color of options, or default blue.
+func barColor(opts isotope.ChartOptions) color.Color {
+	c := color.RGBA{R: 0x1f, G: 0x77, B: 0xb4, A: 0xff}
+	if len(opts.Colors) > 0 {
+		fmt.Sscanf(strings.TrimPrefix(opts.Colors[0], "#"), "%02x%02x%02x", &c.R, &c.G, &c.B)
+	}
+	return c
+}
+
+// recoverPlot turns panic of gonum/plot into error.
+func recoverPlot(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("%w: %v", isotope.ErrChartFailed, r)
+	}
+}
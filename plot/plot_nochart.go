@@ -0,0 +1,36 @@
+//go:build nochart
+
+// Package plot renders charts by gonum/plot, whose vector graphics are of publication quality.
+package plot
+
+import (
+	"io"
+
+	"physics/isotope"
+	"physics/spectrum"
+)
+
+// Charts are excluded from builds with nochart tag.
+
+// Renderer is isotope.Renderer of gonum/plot.
+type Renderer struct{}
+
+// Bars renders bar chart of values in format to w
+func (Renderer) Bars(w io.Writer, format isotope.ImageFormat, p isotope.Plot, opts isotope.ChartOptions) error {
+	return isotope.ErrChartsDisabled
+}
+
+// Shares renders bar chart of values in percent of their total in format to w
+func (Renderer) Shares(w io.Writer, format isotope.ImageFormat, p isotope.Plot, opts isotope.ChartOptions) error {
+	return isotope.ErrChartsDisabled
+}
+
+// SaveSegre saves nuclide chart of products to image file
+func SaveSegre(out isotope.OutputConfig, s *spectrum.Spectrum, opts isotope.ChartOptions) error {
+	return isotope.ErrChartsDisabled
+}
+
+// Segre renders nuclide chart of products in format to w
+func Segre(w io.Writer, format isotope.ImageFormat, s *spectrum.Spectrum, opts isotope.ChartOptions) error {
+	return isotope.ErrChartsDisabled
+}
@@ -0,0 +1,72 @@
+//go:build !nochart
+
+package plot
+
+import (
+	"errors"
+	"io"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/plot/palette/moreland"
+	"gonum.org/v1/plot/plotter"
+
+	"physics/isotope"
+	"physics/spectrum"
+)
+
+// SaveSegre saves nuclide chart of products to image file
+func SaveSegre(out isotope.OutputConfig, s *spectrum.Spectrum, opts isotope.ChartOptions) error {
+	return out.Save("segre"+out.Image.Ext(), func(w io.Writer) error {
+		return Segre(w, out.Image, s, opts)
+	})
+}
+
+// Segre renders nuclide chart of products in format to w, heatmap of logarithm of their counts
+// by number of neutrons and protons, the Segrè chart. Nuclides without products are blank.
+func Segre(w io.Writer, format isotope.ImageFormat, s *spectrum.Spectrum, opts isotope.ChartOptions) (err error) {
+	defer recoverPlot(&err)
+
+	if s.Total() == 0 {
+		return errors.New("nuclide chart: no products")
+	}
+	pl := newPlot(isotope.Plot{
+		Title:  "Nuclide chart of products (color is log10 of count)",
+		XLabel: "neutrons N", YLabel: "protons Z",
+	}, opts)
+	colors := moreland.SmoothBlueRed()
+	colors.SetMax(1)
+	h := plotter.NewHeatMap(grid{s.Chart()}, colors.Palette(64))
+	if h.Min == h.Max {
+		h.Max = h.Min + 1
+	}
+	pl.Add(h)
+	width, height := size(opts, 900, 640)
+	wt, err := pl.WriterTo(width, height, string(format))
+	if err != nil {
+		return err
+	}
+	_, err = wt.WriteTo(w)
+	return err
+}
+
+// grid is plotter.GridXYZ of matrix of nuclide chart, with logarithm of counts and NaN of
+// nuclides without products.
+type grid struct {
+	m *mat.Dense
+}
+
+func (g grid) Dims() (c, r int) {
+	r, c = g.m.Dims()
+	return c, r
+}
+
+func (g grid) Z(c, r int) float64 {
+	if v := g.m.At(r, c); v > 0 {
+		return math.Log10(v)
+	}
+	return math.NaN()
+}
+
+func (g grid) X(c int) float64 { return float64(c) }
+func (g grid) Y(r int) float64 { return float64(r) }
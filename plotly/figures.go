@@ -0,0 +1,74 @@
+package plotly
+
+import (
+	"fmt"
+	"math"
+
+	"physics/decay"
+	"physics/fission"
+	"physics/isotope"
+	"physics/spectrum"
+)
+
+// Activities returns figure of activities over time on logarithmic axes. Times without
+// activity are left out.
+func Activities(a *decay.Activities, opts isotope.ChartOptions) Figure {
+	var series []Series
+	for _, s := range a.Series {
+		l := Series{Name: s.Nuclide}
+		for j, t := range a.Times {
+			if s.Activity[j] > 0 {
+				l.X = append(l.X, t)
+				l.Y = append(l.Y, s.Activity[j])
+			}
+		}
+		series = append(series, l)
+	}
+	p := isotope.Plot{Title: "Activity after end of run", XLabel: "time [s]", YLabel: "activity [Bq]"}
+	f := Lines(p, opts, series...)
+	f.Layout.XAxis.Type, f.Layout.YAxis.Type = "log", "log"
+	return f
+}
+
+// Comparison returns figure of simulated and reference yields of elements by atomic number.
+func Comparison(c *fission.Comparison, opts isotope.ChartOptions) Figure {
+	ref, sim := Series{Name: "reference"}, Series{Name: "simulated"}
+	for _, d := range c.Elements {
+		ref.X, ref.Y = append(ref.X, float64(d.Number)), append(ref.Y, d.Reference)
+		sim.X, sim.Y = append(sim.X, float64(d.Number)), append(sim.Y, d.Simulated)
+	}
+	p := isotope.Plot{
+		Title:  fmt.Sprintf("Yields of elements of U-235 (chi-square %.4g for %d degrees of freedom)", c.ChiSquare, c.DegreesOfFreedom),
+		XLabel: "element", YLabel: "cumulative yield [%]",
+	}
+	f := Lines(p, opts, ref, sim)
+	for i := range f.Data {
+		f.Data[i].Mode = "lines+markers"
+	}
+	return f
+}
+
+// Segre returns heatmap of nuclide chart of products, logarithm of their counts by number of
+// neutrons and protons. Nuclides without products are blank.
+func Segre(s *spectrum.Spectrum, opts isotope.ChartOptions) Figure {
+	m := s.Chart()
+	rows, cols := m.Dims()
+	t := Trace{Type: "heatmap", Colorscale: "Hot", Z: make([][]*float64, rows)}
+	x, y := make([]float64, cols), make([]float64, rows)
+	for c := range x {
+		x[c] = float64(c)
+	}
+	for r := range rows {
+		y[r] = float64(r)
+		t.Z[r] = make([]*float64, cols)
+		for c := range cols {
+			if v := m.At(r, c); v > 0 {
+				v = math.Log10(v)
+				t.Z[r][c] = &v
+			}
+		}
+	}
+	t.X, t.Y = x, y
+	p := isotope.Plot{Title: "Nuclide chart of products (color is log10 of count)", XLabel: "neutrons N", YLabel: "protons Z"}
+	return Figure{Data: []Trace{t}, Layout: layout(p, opts)}
+}
@@ -0,0 +1,174 @@
+// Package plotly exports charts as Plotly figures, JSON of data and layout which plotly.js
+// renders in web pages and notebooks with hover and zoom.
+package plotly
+
+import (
+	"encoding/json"
+	"io"
+
+	"physics/isotope"
+)
+
+// Ext is file name extension of figures.
+const Ext = ".plotly.json"
+
+// Figure is Plotly figure.
+type Figure struct {
+	Data   []Trace `json:"data"`
+	Layout Layout  `json:"layout"`
+}
+
+// Trace is a plotted series of figure: "bar", "pie", "scatter" of lines or "heatmap".
+type Trace struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+	Mode string `json:"mode,omitempty"`
+
+	// X are labels of bars or values of lines, Y their values.
+	X      any        `json:"x,omitempty"`
+	Y      []float64  `json:"y,omitempty"`
+	ErrorY *ErrorBars `json:"error_y,omitempty"`
+
+	// Labels and Values of slices of pie, Hole makes it donut.
+	Labels []string  `json:"labels,omitempty"`
+	Values []float64 `json:"values,omitempty"`
+	Hole   float64   `json:"hole,omitempty"`
+
+	// Z are values of heatmap by row of Y and column of X, nil are blank.
+	Z          [][]*float64 `json:"z,omitempty"`
+	Colorscale string       `json:"colorscale,omitempty"`
+
+	Marker *Marker `json:"marker,omitempty"`
+}
+
+// ErrorBars are symmetric errors of values of trace.
+type ErrorBars struct {
+	Type    string    `json:"type"`
+	Array   []float64 `json:"array"`
+	Visible bool      `json:"visible"`
+}
+
+// Marker colors bars, slices or lines as "#rrggbb".
+type Marker struct {
+	Color  any      `json:"color,omitempty"`
+	Colors []string `json:"colors,omitempty"`
+}
+
+// Layout of figure.
+type Layout struct {
+	Title  Text `json:"title"`
+	XAxis  Axis `json:"xaxis"`
+	YAxis  Axis `json:"yaxis"`
+	Width  int  `json:"width,omitempty"`
+	Height int  `json:"height,omitempty"`
+}
+
+// Text is text of title.
+type Text struct {
+	Text string `json:"text,omitempty"`
+}
+
+// Axis of figure, Type is "log" for logarithmic axis.
+type Axis struct {
+	Title Text      `json:"title"`
+	Type  string    `json:"type,omitempty"`
+	Range []float64 `json:"range,omitempty"`
+}
+
+// Series is line of values y at x.
+type Series struct {
+	Name string
+	X, Y []float64
+}
+
+// Bars returns figure of bar chart of values of p, with error bars of values with errors.
+func Bars(p isotope.Plot, opts isotope.ChartOptions) Figure {
+	t := Trace{Type: "bar"}
+	labels := make([]string, len(p.Values))
+	errs := make([]float64, len(p.Values))
+	hasErrors := false
+	for i, v := range p.Values {
+		labels[i] = v.Label
+		t.Y = append(t.Y, v.Value)
+		errs[i] = v.Error
+		hasErrors = hasErrors || v.Error > 0
+	}
+	t.X = labels
+	if hasErrors {
+		t.ErrorY = &ErrorBars{Type: "data", Array: errs, Visible: true}
+	}
+	if len(opts.Colors) > 0 {
+		t.Marker = &Marker{Color: colors(opts, len(labels))}
+	}
+	return Figure{Data: []Trace{t}, Layout: layout(p, opts)}
+}
+
+// Shares returns figure of donut chart of values of p.
+func Shares(p isotope.Plot, opts isotope.ChartOptions) Figure {
+	t := Trace{Type: "pie", Hole: 0.4}
+	for _, v := range p.Values {
+		t.Labels = append(t.Labels, v.Label)
+		t.Values = append(t.Values, v.Value)
+	}
+	if len(opts.Colors) > 0 {
+		t.Marker = &Marker{Colors: colors(opts, len(t.Labels))}
+	}
+	return Figure{Data: []Trace{t}, Layout: layout(p, opts)}
+}
+
+// Lines returns figure of line chart of series, its values are labels of axes of p.
+func Lines(p isotope.Plot, opts isotope.ChartOptions, series ...Series) Figure {
+	f := Figure{Layout: layout(p, opts)}
+	for i, s := range series {
+		t := Trace{Type: "scatter", Mode: "lines", Name: s.Name, X: s.X, Y: s.Y}
+		if len(opts.Colors) > 0 {
+			t.Marker = &Marker{Color: opts.Colors[i%len(opts.Colors)]}
+		}
+		f.Data = append(f.Data, t)
+	}
+	return f
+}
+
+func layout(p isotope.Plot, opts isotope.ChartOptions) Layout {
+	l := Layout{Title: Text{p.Title}, XAxis: Axis{Title: Text{p.XLabel}}, YAxis: Axis{Title: Text{p.YLabel}},
+		Width: opts.Width, Height: opts.Height}
+	if opts.Title != "" {
+		l.Title.Text = opts.Title
+	}
+	if opts.YMax > opts.YMin {
+		l.YAxis.Range = []float64{opts.YMin, opts.YMax}
+	}
+	return l
+}
+
+// colors returns colors of options repeated for n values.
+func colors(opts isotope.ChartOptions, n int) []string {
+	cs := make([]string, n)
+	for i := range cs {
+		cs[i] = opts.Colors[i%len(opts.Colors)]
+	}
+	return cs
+}
+
+// WriteJSON writes json to w
+func (f Figure) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(f)
+}
+
+// Save saves figure to name with Ext
+func (f Figure) Save(out isotope.OutputConfig, name string) error {
+	return out.Save(name+Ext, f.WriteJSON)
+}
+
+// Renderer is isotope.Renderer which writes figures instead of images, whatever their format.
+type Renderer struct{}
+
+// Bars writes figure of bar chart to w
+func (Renderer) Bars(w io.Writer, format isotope.ImageFormat, p isotope.Plot, opts isotope.ChartOptions) error {
+	return Bars(p, opts).WriteJSON(w)
+}
+
+// Shares writes figure of donut chart to w
+func (Renderer) Shares(w io.Writer, format isotope.ImageFormat, p isotope.Plot, opts isotope.ChartOptions) error {
+	return Shares(p, opts).WriteJSON(w)
+}
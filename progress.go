@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"physics/fission"
+	"physics/units"
+)
+
+// progressBar draws progress of simulation on a single terminal line.
+type progressBar struct {
+	w     io.Writer
+	width int
+	last  time.Time
+
+	// latest progress, it's drawn with its line ended once simulation finishes.
+	latest *fission.Progress
+}
+
+// Minimal interval between redraws.
+const redrawInterval = 100 * time.Millisecond
+
+func (pb *progressBar) update(p fission.Progress) {
+	pb.latest = &p
+	if p.Done != p.Total && time.Since(pb.last) < redrawInterval {
+		return
+	}
+	pb.last = time.Now()
+	pb.draw(p)
+}
+
+// finish ends line of bar, so output which follows isn't drawn over it when simulation
+// stopped before doing all events.
+func (pb *progressBar) finish(fission.Finished) {
+	if pb.latest == nil {
+		return
+	}
+	pb.draw(*pb.latest)
+	fmt.Fprintln(pb.w)
+	pb.latest = nil
+}
+
+func (pb *progressBar) draw(p fission.Progress) {
+	filled := int(p.Fraction() * float64(pb.width))
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", pb.width-filled)
+	fmt.Fprintf(pb.w, "\r[%s] %3.0f%% %s/%s %.0f ev/s ETA %s ",
+		bar, 100*p.Fraction(), units.Count(p.Done), units.Count(p.Total),
+		p.Rate(), p.Remaining().Round(time.Second))
+}
+
+// isTerminal reports whether f is a character device, e.g. terminal.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
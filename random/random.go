@@ -0,0 +1,213 @@
+// Package random provides engines of random numbers of simulations. Engines are sources of
+// math/rand, so samplers draw from *rand.Rand of any engine through Rand.
+package random
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+	randv2 "math/rand/v2"
+
+	"physics/internal/enum"
+)
+
+// Rand is what samplers draw random numbers from, *rand.Rand implements it.
+type Rand interface {
+	Float64() float64
+	Intn(n int) int
+	NormFloat64() float64
+	Shuffle(n int, swap func(i, j int))
+}
+
+// Global is Rand of global source of math/rand, which is seeded randomly and safe for
+// concurrent use, for callers which don't need reproducible numbers.
+var Global Rand = global{}
+
+type global struct{}
+
+func (global) Float64() float64                   { return rand.Float64() }
+func (global) Intn(n int) int                     { return rand.Intn(n) }
+func (global) NormFloat64() float64               { return rand.NormFloat64() }
+func (global) Shuffle(n int, swap func(i, j int)) { rand.Shuffle(n, swap) }
+
+// Engine is generator of random numbers.
+type Engine int
+
+const (
+	// Go is source of math/rand, which simulations used before engines were pluggable.
+	Go Engine = iota
+
+	// PCG and ChaCha8 are generators of math/rand/v2, ChaCha8 is cryptographically strong.
+	PCG
+	ChaCha8
+
+	// Xoshiro is xoshiro256** of Blackman and Vigna, which is fast and passes BigCrush.
+	Xoshiro
+
+	// Crypto draws from crypto/rand. It can't be seeded, so runs aren't reproducible.
+	Crypto
+)
+
+var engines = enum.New[Engine]("random engine", "go", "pcg", "chacha8", "xoshiro", "crypto")
+
+// Engines returns names of engines.
+func Engines() []string {
+	return engines.Names()
+}
+
+// ParseEngine parses engine name, e.g. "pcg". Empty name is Go.
+func ParseEngine(s string) (Engine, error) {
+	return engines.Parse(s)
+}
+
+func (e Engine) String() string {
+	return engines.String(e)
+}
+
+// Set implements flag.Value.
+func (e *Engine) Set(str string) error {
+	return engines.Set(e, str)
+}
+
+// Type implements pflag.Value.
+func (e *Engine) Type() string {
+	return "engine"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (e Engine) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (e *Engine) UnmarshalText(text []byte) error {
+	return e.Set(string(text))
+}
+
+// Reproducible reports whether engine draws the same numbers of the same seed.
+func (e Engine) Reproducible() bool {
+	return e != Crypto
+}
+
+// Source returns source of engine seeded by seed.
+func (e Engine) Source(seed int64) rand.Source64 {
+	switch e {
+	case PCG:
+		return newV2Source(seed, func(s *SplitMix) randv2.Source {
+			return randv2.NewPCG(s.Next(), s.Next())
+		})
+	case ChaCha8:
+		return newV2Source(seed, func(s *SplitMix) randv2.Source {
+			var key [32]byte
+			for i := 0; i < len(key); i += 8 {
+				binary.LittleEndian.PutUint64(key[i:], s.Next())
+			}
+			return randv2.NewChaCha8(key)
+		})
+	case Xoshiro:
+		x := &xoshiro{}
+		x.Seed(seed)
+		return x
+	case Crypto:
+		return cryptoSource{}
+	}
+	return rand.NewSource(seed).(rand.Source64)
+}
+
+// New returns *rand.Rand of engine seeded by seed.
+func (e Engine) New(seed int64) *rand.Rand {
+	return rand.New(e.Source(seed))
+}
+
+// v2Source adapts generator of math/rand/v2 to source of math/rand. Seed of math/rand is
+// expanded into state of generator by splitmix64.
+type v2Source struct {
+	src     randv2.Source
+	newSeed func(*SplitMix) randv2.Source
+}
+
+func newV2Source(seed int64, newSeed func(*SplitMix) randv2.Source) *v2Source {
+	s := &v2Source{newSeed: newSeed}
+	s.Seed(seed)
+	return s
+}
+
+func (s *v2Source) Uint64() uint64 { return s.src.Uint64() }
+func (s *v2Source) Int63() int64   { return int64(s.src.Uint64() >> 1) }
+func (s *v2Source) Seed(seed int64) {
+	s.src = s.newSeed(SplitMix64(uint64(seed)))
+}
+
+// SplitMix is splitmix64 generator of Steele, Lea and Flood, which expands a seed into
+// well distributed seeds of other generators.
+type SplitMix uint64
+
+// SplitMix64 returns splitmix64 generator of seed.
+func SplitMix64(seed uint64) *SplitMix {
+	s := SplitMix(seed)
+	return &s
+}
+
+// Next returns next value of generator.
+func (s *SplitMix) Next() uint64 {
+	*s += 0x9e3779b97f4a7c15
+	z := uint64(*s)
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// Derive returns seed of stream of master seed, e.g. of worker of parallel run, which is
+// stream-th value of splitmix64 of master seed. Seeds of consecutive streams are uncorrelated,
+// unlike consecutive seeds themselves.
+func Derive(seed int64, stream int) int64 {
+	s := SplitMix64(uint64(seed) + uint64(stream)*0x9e3779b97f4a7c15)
+	return int64(s.Next())
+}
+
+// xoshiro is xoshiro256** generator.
+type xoshiro struct {
+	s [4]uint64
+}
+
+func (x *xoshiro) Seed(seed int64) {
+	sm := SplitMix64(uint64(seed))
+	for i := range x.s {
+		x.s[i] = sm.Next()
+	}
+}
+
+func (x *xoshiro) Uint64() uint64 {
+	s := &x.s
+	result := rotl(s[1]*5, 7) * 9
+	t := s[1] << 17
+	s[2] ^= s[0]
+	s[3] ^= s[1]
+	s[1] ^= s[2]
+	s[0] ^= s[3]
+	s[2] ^= t
+	s[3] = rotl(s[3], 45)
+	return result
+}
+
+func (x *xoshiro) Int63() int64 {
+	return int64(x.Uint64() >> 1)
+}
+
+func rotl(x uint64, k int) uint64 {
+	return (x << k) | (x >> (64 - k))
+}
+
+// cryptoSource draws from crypto/rand, which never fails.
+type cryptoSource struct{}
+
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	crand.Read(b[:])
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+func (c cryptoSource) Int63() int64 { return int64(c.Uint64() >> 1) }
+
+// Seed is ignored, crypto/rand can't be seeded.
+func (cryptoSource) Seed(int64) {}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"physics/fission"
+	"physics/isotope"
+	"physics/transport"
+)
+
+func reactionCmd() *cobra.Command {
+	var (
+		output         outputFlags
+		path           string
+		scriptPath     string
+		fissions       int
+		generationTime time.Duration
+		duration       time.Duration
+		doppler        transport.Doppler
+		format         string
+		nocharts       bool
+	)
+	cmd := &cobra.Command{
+		Use:   "reaction",
+		Short: "Follow neutron population of chain reaction while a scenario changes its reactivity",
+		Long: "Follow neutron population of chain reaction in transport of config generation by\n" +
+			"generation, while actions of scenario file insert reactivity or absorber, e.g.\n\n" +
+			"\tat 200ns insert absorber 0.05\n" +
+			"\tat 1us insert -2000 pcm\n",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := fission.DefaultConfig()
+			if path != "" {
+				var err error
+				if c, err = fission.LoadConfig(path); err != nil {
+					return err
+				}
+			}
+			f := cmd.Flags()
+			if f.Changed("doppler") || f.Changed("heat-capacity") || f.Changed("fission-scale") {
+				if c.Transport == nil {
+					c.Transport = &fission.TransportConfig{}
+				}
+				if d := c.Transport.Doppler; d != nil {
+					// flags which weren't given keep values of config
+					if !f.Changed("doppler") {
+						doppler.Coefficient = d.Coefficient
+					}
+					if !f.Changed("heat-capacity") {
+						doppler.HeatCapacity = d.HeatCapacity
+					}
+					if !f.Changed("fission-scale") {
+						doppler.Scale = d.Scale
+					}
+					doppler.Temperature, doppler.EnergyPerFission = d.Temperature, d.EnergyPerFission
+				}
+				c.Transport.Doppler = &doppler
+			}
+			out, err := output.config()
+			if err != nil {
+				return err
+			}
+			formats, err := isotope.ParseFormats(format)
+			if err != nil {
+				return err
+			}
+			script, err := loadScript(scriptPath)
+			if err != nil {
+				return err
+			}
+			r, err := c.Reaction(fissions, generationTime)
+			if err != nil {
+				return err
+			}
+			pop, err := fission.RunReaction(r, script, duration)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("generations: %d\n", r.Generation)
+			fmt.Printf("population:  %.4g neutrons at %s\n", r.Population(), r.Time)
+			if r.Temperature > 0 {
+				fmt.Printf("temperature: %.1f K\n", r.Temperature)
+			}
+			fmt.Printf("flux:        %.4g cm per neutron ± %.2f%%\n", pop.Flux.Mean, 100*pop.Flux.RelativeError)
+			for _, rate := range pop.Rates {
+				fmt.Printf("%-12s %.4g per neutron ± %.2f%%\n", rate.Reaction+":", rate.Mean, 100*rate.RelativeError)
+			}
+
+			for _, f := range formats {
+				if err := pop.Save(out, f); err != nil {
+					return err
+				}
+			}
+			if !nocharts {
+				if err := pop.SaveChart(out, isotope.ChartOptions{}); err != nil {
+					fmt.Fprintln(os.Stderr, "warning: charts not saved:", err)
+				}
+			}
+			return nil
+		},
+	}
+	output.add(cmd)
+	f := cmd.Flags()
+	f.StringVarP(&path, "config", "c", "", "simulation config file, its transport is followed")
+	f.StringVar(&scriptPath, "script", "", "scenario file of actions")
+	f.IntVar(&fissions, "fissions", 1000, "number of starting fissions, at most that many are followed in a generation")
+	f.DurationVar(&generationTime, "generation-time", 6*time.Nanosecond, "time between generations")
+	f.DurationVar(&duration, "duration", time.Microsecond, "length of chain reaction")
+	f.Float64Var(&doppler.Coefficient, "doppler", 0, "doppler coefficient of U-238 capture in 1/sqrt(K), e.g. 0.006")
+	f.Float64Var(&doppler.HeatCapacity, "heat-capacity", 6000, "heat capacity of fuel in J/K for doppler feedback")
+	f.Float64Var(&doppler.Scale, "fission-scale", 1e12, "number of fissions in fuel represented by a fission of chain reaction")
+	f.StringVar(&format, "format", "csv", "comma separated data formats: json, yaml, csv")
+	f.BoolVar(&nocharts, "nocharts", false, "skip chart rendering, only data files are saved")
+	return cmd
+}
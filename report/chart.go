@@ -0,0 +1,58 @@
+//go:build !nochart
+
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+
+	"github.com/wcharczuk/go-chart/v2"
+
+	"physics/isotope"
+)
+
+// renderLines renders series as lines over labels to inline SVG.
+func renderLines(title string, labels []string, series []Series) (svg template.HTML, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", isotope.ErrChartFailed, r)
+		}
+	}()
+
+	ticks := make([]chart.Tick, len(labels))
+	for i, l := range labels {
+		ticks[i] = chart.Tick{Value: float64(i), Label: l}
+	}
+	graph := chart.Chart{
+		Title:  title,
+		Width:  max(1200, 24*len(labels)),
+		Height: 500,
+		Background: chart.Style{
+			Padding: chart.Box{Top: 50, Left: 20, Right: 20},
+		},
+		XAxis: chart.XAxis{Ticks: ticks},
+	}
+	for i, s := range series {
+		xs := make([]float64, len(s.Values))
+		for j := range xs {
+			xs[j] = float64(j)
+		}
+		graph.Series = append(graph.Series, chart.ContinuousSeries{
+			Name:    s.Name,
+			XValues: xs,
+			YValues: s.Values,
+			Style: chart.Style{
+				StrokeColor: chart.GetDefaultColor(i),
+				StrokeWidth: 2,
+			},
+		})
+	}
+	graph.Elements = []chart.Renderable{chart.Legend(&graph)}
+
+	var buf bytes.Buffer
+	if err := graph.Render(chart.SVG, &buf); err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil
+}
@@ -0,0 +1,13 @@
+//go:build nochart
+
+package report
+
+import (
+	"html/template"
+
+	"physics/isotope"
+)
+
+func renderLines(title string, labels []string, series []Series) (template.HTML, error) {
+	return "", isotope.ErrChartsDisabled
+}
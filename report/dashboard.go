@@ -0,0 +1,156 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"path/filepath"
+	"sort"
+	"time"
+
+	_ "embed"
+
+	"physics/count"
+	"physics/fission"
+	"physics/isotope"
+)
+
+//go:embed dashboard.html.tmpl
+var dashboardPage string
+
+var dashboardTmpl = template.Must(template.New("dashboard").Funcs(template.FuncMap{
+	"percent": func(v float64) float64 { return 100 * v },
+	"sqrt":    math.Sqrt,
+}).Parse(dashboardPage))
+
+// Dashboard compares several saved runs side by side.
+type Dashboard struct {
+	Created time.Time
+	Runs    []Run
+
+	// Elements are symbols of products of any run, ordered by atomic number.
+	Elements []string
+
+	// Multiplicities are numbers of neutrons released in any run, in ascending order.
+	Multiplicities []int
+
+	Charts []Chart
+}
+
+// Run is a saved run.
+type Run struct {
+	Name     string
+	Bundle   fission.Bundle
+	Neutrons fission.NeutronStats
+}
+
+// Yield returns number of products of element per fission in percent.
+func (r Run) Yield(symbol string) float64 {
+	if r.Bundle.Fissions == 0 {
+		return 0
+	}
+	return 100 * float64(r.Bundle.Symbols[symbol]) / float64(r.Bundle.Fissions)
+}
+
+// Probability returns probability of element among products in percent.
+func (r Run) Probability(symbol string) float64 {
+	total := 0
+	for _, c := range r.Bundle.Symbols {
+		total += c
+	}
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(r.Bundle.Symbols[symbol]) / float64(total)
+}
+
+// NewDashboard loads runs saved in dirs, see fission.LoadBundle.
+func NewDashboard(dirs ...string) (*Dashboard, error) {
+	if len(dirs) == 0 {
+		return nil, errors.New("dashboard: no runs")
+	}
+	d := &Dashboard{Created: time.Now()}
+	symbols := make(map[string]bool)
+	multiplicities := make(map[int]bool)
+	for _, dir := range dirs {
+		b, err := fission.LoadBundle(dir)
+		if err != nil {
+			return nil, fmt.Errorf("dashboard: %w", err)
+		}
+		d.Runs = append(d.Runs, Run{Name: filepath.Base(filepath.Clean(dir)), Bundle: b, Neutrons: b.NeutronStats()})
+		for s := range b.Symbols {
+			symbols[s] = true
+		}
+		for n := range b.Neutrons {
+			multiplicities[n] = true
+		}
+	}
+	for s := range symbols {
+		d.Elements = append(d.Elements, s)
+	}
+	sort.Slice(d.Elements, func(i, j int) bool {
+		zi, _ := isotope.SymbolNumber(d.Elements[i])
+		zj, _ := isotope.SymbolNumber(d.Elements[j])
+		if zi != zj {
+			return zi < zj
+		}
+		return d.Elements[i] < d.Elements[j]
+	})
+	d.Multiplicities = count.SortedKeys(multiplicities)
+
+	charts := []struct {
+		title  string
+		labels []string
+		value  func(Run, int) float64
+	}{
+		{"Probability of elements (%)", d.Elements, func(r Run, i int) float64 { return r.Probability(d.Elements[i]) }},
+		{"Neutron multiplicity (%)", labels(d.Multiplicities), func(r Run, i int) float64 {
+			return 100 * r.Neutrons.Probability(d.Multiplicities[i])
+		}},
+	}
+	for _, c := range charts {
+		var series []Series
+		for _, r := range d.Runs {
+			s := Series{Name: r.Name}
+			for i := range c.labels {
+				s.Values = append(s.Values, c.value(r, i))
+			}
+			series = append(series, s)
+		}
+		svg, err := renderLines(c.title, c.labels, series)
+		if errors.Is(err, isotope.ErrChartsDisabled) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		d.Charts = append(d.Charts, Chart{Title: c.title, SVG: svg})
+	}
+	return d, nil
+}
+
+// Series are values of a run at each label of chart.
+type Series struct {
+	Name   string
+	Values []float64
+}
+
+// Saves to index.html file, so directory of output can be published as static site
+func (d *Dashboard) Save(out isotope.OutputConfig) error {
+	return out.Save("index.html", d.Write)
+}
+
+// Write writes html to w
+func (d *Dashboard) Write(w io.Writer) error {
+	return dashboardTmpl.Execute(w, d)
+}
+
+func labels(ns []int) []string {
+	ls := make([]string, len(ns))
+	for i, n := range ns {
+		ls[i] = fmt.Sprint(n)
+	}
+	return ls
+}
@@ -0,0 +1,94 @@
+// Package report renders results of a run as a single self-contained HTML file
+// with charts, tables of counts and run metadata.
+package report
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"html/template"
+	"io"
+	"time"
+
+	"physics/count"
+	"physics/fission"
+	"physics/isotope"
+)
+
+//go:embed report.html.tmpl
+var page string
+
+var tmpl = template.Must(template.New("report").Funcs(template.FuncMap{
+	"percent": func(v float64) float64 { return 100 * v },
+}).Parse(page))
+
+// Report is content of HTML report.
+type Report struct {
+	Summary  fission.Summary
+	Created  time.Time
+	Elements []Element
+	Isotopes []isotope.Count
+	Neutrons fission.NeutronStats
+
+	// Charts are embedded as inline SVG. There are none in builds with nochart tag.
+	Charts []Chart
+}
+
+// Element is number of products and probability in percent of a chemical element.
+type Element struct {
+	Symbol      string
+	Count       int
+	Probability float64
+}
+
+// Chart is a rendered chart.
+type Chart struct {
+	Title string
+	SVG   template.HTML
+}
+
+// New creates report of results. Error is returned if a chart can't be rendered.
+func New(r *fission.Results) (*Report, error) {
+	symbols := r.Tally.CountSymbols()
+	probs := symbols.Probabilities()
+	rep := &Report{
+		Summary:  r.Summary(),
+		Created:  time.Now(),
+		Isotopes: r.Tally.Counts(),
+		Neutrons: r.NeutronStats(),
+	}
+	for _, e := range count.Counter[string](symbols).TopN(0) {
+		rep.Elements = append(rep.Elements, Element{Symbol: e.Key, Count: e.Count, Probability: probs[e.Key].Probability})
+	}
+
+	charts := []struct {
+		title  string
+		render func(io.Writer, isotope.ImageFormat, isotope.ChartOptions) error
+	}{
+		{"Fission products", symbols.RenderChart},
+		{"Probability of occurence", probs.RenderChart},
+		{"Neutron multiplicity", rep.Neutrons.RenderChart},
+	}
+	for _, c := range charts {
+		var buf bytes.Buffer
+		err := c.render(&buf, isotope.SVG, isotope.ChartOptions{})
+		if errors.Is(err, isotope.ErrChartsDisabled) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		rep.Charts = append(rep.Charts, Chart{Title: c.title, SVG: template.HTML(buf.String())})
+	}
+	return rep, nil
+}
+
+// Saves to report.html file
+func (rep *Report) Save(out isotope.OutputConfig) error {
+	return out.Save("report.html", rep.Write)
+}
+
+// Write writes html to w
+func (rep *Report) Write(w io.Writer) error {
+	return tmpl.Execute(w, rep)
+}
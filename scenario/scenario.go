@@ -0,0 +1,218 @@
+// Package scenario describes time dependent actions of transient simulations,
+// so scenarios are defined by data instead of code.
+package scenario
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind of an action.
+type Kind string
+
+const (
+	// InsertReactivity adds reactivity (delta k / k) to the system.
+	InsertReactivity Kind = "insert"
+
+	// SetPower sets power relative to initial power.
+	SetPower Kind = "power"
+
+	// InsertAbsorber adds macroscopic capture cross section in 1/cm, e.g. of control rods,
+	// negative value removes absorber.
+	InsertAbsorber Kind = "absorber"
+)
+
+// Action is a change applied at a time of a transient.
+type Action struct {
+	At    time.Duration `json:"at"`
+	Kind  Kind          `json:"action"`
+	Value float64       `json:"value"`
+}
+
+// PCM is reactivity of one per cent mille.
+const PCM = 1e-5
+
+// Engine applies actions, implemented by kinetics and chain reaction simulations.
+type Engine interface {
+	InsertReactivity(rho float64)
+	SetPower(power float64)
+}
+
+// Absorber is engine which also changes absorption of its medium.
+type Absorber interface {
+	Engine
+	InsertAbsorber(sigma float64)
+}
+
+// Script is list of actions ordered by time.
+type Script []Action
+
+// New returns script of actions sorted by time.
+func New(actions ...Action) Script {
+	s := append(Script{}, actions...)
+	sort.SliceStable(s, func(i, j int) bool { return s[i].At < s[j].At })
+	return s
+}
+
+// Between returns actions which are due in time interval (from, to].
+func (s Script) Between(from, to time.Duration) []Action {
+	var due []Action
+	for _, a := range s {
+		if a.At > from && a.At <= to {
+			due = append(due, a)
+		}
+	}
+	return due
+}
+
+// Apply applies actions due in time interval (from, to] to engine. Absorber actions are
+// skipped by engines which aren't Absorber, see Check.
+func (s Script) Apply(e Engine, from, to time.Duration) {
+	for _, a := range s.Between(from, to) {
+		switch a.Kind {
+		case InsertReactivity:
+			e.InsertReactivity(a.Value)
+		case SetPower:
+			e.SetPower(a.Value)
+		case InsertAbsorber:
+			if ab, ok := e.(Absorber); ok {
+				ab.InsertAbsorber(a.Value)
+			}
+		}
+	}
+}
+
+// Check returns error if script has actions which engine can't apply.
+func (s Script) Check(e Engine) error {
+	for _, a := range s {
+		switch a.Kind {
+		case InsertReactivity, SetPower:
+		case InsertAbsorber:
+			if _, ok := e.(Absorber); !ok {
+				return fmt.Errorf("scenario: action %q at %s isn't supported by %T", a.Kind, a.At, e)
+			}
+		default:
+			return fmt.Errorf("scenario: unknown action %q at %s", a.Kind, a.At)
+		}
+	}
+	return nil
+}
+
+// Parse parses script with one action per line, e.g.
+//
+//	at 100s insert -500 pcm
+//	at 2h set power to 0
+//	at 10us insert absorber 0.02
+//
+// Lines starting with # are comments. Reactivity without unit is delta k / k.
+func Parse(r io.Reader) (Script, error) {
+	var actions []Action
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		a, err := ParseAction(text)
+		if err != nil {
+			return nil, fmt.Errorf("scenario line %d: %w", line, err)
+		}
+		actions = append(actions, a)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return New(actions...), nil
+}
+
+// ParseAction parses single action like "at 100s insert -500 pcm".
+func ParseAction(s string) (Action, error) {
+	words := strings.Fields(strings.ToLower(s))
+	if len(words) < 3 || words[0] != "at" {
+		return Action{}, fmt.Errorf("action %q: expected \"at <time> <action>\"", s)
+	}
+	at, err := time.ParseDuration(words[1])
+	if err != nil {
+		return Action{}, fmt.Errorf("action %q: %w", s, err)
+	}
+
+	words = words[2:]
+	if words[0] == "set" {
+		words = words[1:]
+	}
+	if len(words) < 2 {
+		return Action{}, fmt.Errorf("action %q: missing value", s)
+	}
+	kind := Kind(words[0])
+	words = words[1:]
+	if kind == InsertReactivity && words[0] == "absorber" {
+		kind, words = InsertAbsorber, words[1:]
+	}
+	if words[0] == "to" || words[0] == "by" {
+		words = words[1:]
+	}
+	if len(words) == 0 {
+		return Action{}, fmt.Errorf("action %q: missing value", s)
+	}
+	v, err := strconv.ParseFloat(words[0], 64)
+	if err != nil {
+		return Action{}, fmt.Errorf("action %q: invalid value %q", s, words[0])
+	}
+
+	switch kind {
+	case InsertReactivity:
+		if len(words) > 1 && words[1] == "pcm" {
+			v *= PCM
+		}
+	case SetPower, InsertAbsorber:
+	default:
+		return Action{}, fmt.Errorf("action %q: unknown action %q", s, kind)
+	}
+	return Action{At: at, Kind: kind, Value: v}, nil
+}
+
+// UnmarshalJSON accepts time as duration string like "100s" or number of seconds.
+func (a *Action) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		At    json.RawMessage `json:"at"`
+		Kind  Kind            `json:"action"`
+		Value float64         `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var at time.Duration
+	var s string
+	var seconds float64
+	switch {
+	case json.Unmarshal(raw.At, &s) == nil:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		at = d
+	case json.Unmarshal(raw.At, &seconds) == nil:
+		at = time.Duration(seconds * float64(time.Second))
+	default:
+		return fmt.Errorf("invalid action time %s", raw.At)
+	}
+	*a = Action{At: at, Kind: raw.Kind, Value: raw.Value}
+	return nil
+}
+
+// MarshalJSON writes time as number of seconds, which UnmarshalJSON reads back.
+func (a Action) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		At    float64 `json:"at"`
+		Kind  Kind    `json:"action"`
+		Value float64 `json:"value"`
+	}{a.At.Seconds(), a.Kind, a.Value})
+}
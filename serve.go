@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"physics/server"
+)
+
+func serveCmd() *cobra.Command {
+	var (
+		addr      string
+		grpcAddr  string
+		maxEvents int
+		retention time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve HTTP API to start simulations and fetch their results",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := server.New()
+			s.MaxEvents = maxEvents
+			s.Retention = retention
+			if grpcAddr != "" {
+				l, err := net.Listen("tcp", grpcAddr)
+				if err != nil {
+					return err
+				}
+				gs := s.GRPC()
+				go gs.Serve(l)
+				defer gs.Stop()
+				fmt.Printf("grpc listening on %s\n", grpcAddr)
+			}
+			fmt.Printf("listening on %s\n", addr)
+			return http.ListenAndServe(addr, s)
+		},
+	}
+	f := cmd.Flags()
+	f.StringVar(&addr, "addr", "localhost:8080", "address to listen on")
+	f.StringVar(&grpcAddr, "grpc-addr", "", "address of grpc service, disabled if empty")
+	f.IntVar(&maxEvents, "max-events", 10_000_000, "maximal number of events of a simulation, 0 is unlimited")
+	f.DurationVar(&retention, "retention", 24*time.Hour, "how long finished simulations are kept, 0 keeps them forever")
+	return cmd
+}
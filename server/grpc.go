@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"physics/fission"
+	"physics/pb"
+)
+
+// GRPC returns gRPC server of Simulations service defined in pb/fission.proto.
+// It shares simulations with HTTP API.
+func (s *Server) GRPC(opts ...grpc.ServerOption) *grpc.Server {
+	gs := grpc.NewServer(append([]grpc.ServerOption{grpc.ForceServerCodec(pb.Codec{})}, opts...)...)
+	gs.RegisterService(&simulationsService, s)
+	return gs
+}
+
+// simulationsServer is implemented by Server.
+type simulationsServer interface {
+	startSimulation(context.Context, *pb.StartRequest) (*pb.Simulation, error)
+	streamEvents(*pb.SimulationRef, grpc.ServerStream) error
+	getResults(context.Context, *pb.SimulationRef) (*pb.Results, error)
+}
+
+var simulationsService = grpc.ServiceDesc{
+	ServiceName: "fissionmc.Simulations",
+	HandlerType: (*simulationsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "StartSimulation", Handler: unary("StartSimulation", simulationsServer.startSimulation)},
+		{MethodName: "GetResults", Handler: unary("GetResults", simulationsServer.getResults)},
+	},
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "StreamEvents",
+		ServerStreams: true,
+		Handler: func(srv any, stream grpc.ServerStream) error {
+			ref := new(pb.SimulationRef)
+			if err := stream.RecvMsg(ref); err != nil {
+				return err
+			}
+			return srv.(simulationsServer).streamEvents(ref, stream)
+		},
+	}},
+	Metadata: "fission.proto",
+}
+
+// unary returns handler of unary method m of name.
+func unary[Req, Resp any](name string, m func(simulationsServer, context.Context, *Req) (*Resp, error)) func(any, context.Context, func(any) error, grpc.UnaryServerInterceptor) (any, error) {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		req := new(Req)
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+		handler := func(ctx context.Context, req any) (any, error) {
+			return m(srv.(simulationsServer), ctx, req.(*Req))
+		}
+		if interceptor == nil {
+			return handler(ctx, req)
+		}
+		return interceptor(ctx, req, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/fissionmc.Simulations/" + name}, handler)
+	}
+}
+
+func (s *Server) startSimulation(ctx context.Context, req *pb.StartRequest) (*pb.Simulation, error) {
+	c := fission.DefaultConfig()
+	if req.Config.Isotope != "" {
+		c.Isotope = req.Config.Isotope
+	}
+	if req.Config.Events > 0 {
+		c.Events = req.Config.Events
+	}
+	c.Seed = req.Config.Seed
+	c.BatchSize = req.Config.BatchSize
+
+	j, err := s.create(c, req.WaitForStream)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	st := j.status()
+	return &pb.Simulation{ID: st.ID, Seed: st.Config.Seed, State: string(st.State)}, nil
+}
+
+func (s *Server) streamEvents(ref *pb.SimulationRef, stream grpc.ServerStream) error {
+	j := s.lookup(ref.ID)
+	if j == nil {
+		return status.Errorf(codes.NotFound, "simulation %d not found", ref.ID)
+	}
+	sub := j.subscribe()
+	defer j.unsubscribe(sub)
+	for {
+		select {
+		case msg, ok := <-sub.messages:
+			if !ok {
+				return nil
+			}
+			if e, ok := msg.(fission.Event); ok {
+				if err := stream.SendMsg(&pb.Event{Event: e}); err != nil {
+					return err
+				}
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+func (s *Server) getResults(ctx context.Context, ref *pb.SimulationRef) (*pb.Results, error) {
+	j := s.lookup(ref.ID)
+	if j == nil {
+		return nil, status.Errorf(codes.NotFound, "simulation %d not found", ref.ID)
+	}
+	res, err := j.finished()
+	switch {
+	case errors.Is(err, errRunning):
+		return nil, status.Error(codes.Unavailable, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	r := pb.NewResults(res)
+	return &r, nil
+}
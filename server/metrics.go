@@ -0,0 +1,101 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"physics/fission"
+	"physics/internal/bus"
+)
+
+// metrics are Prometheus metrics of all simulations of server.
+type metrics struct {
+	registry     *prometheus.Registry
+	fissions     prometheus.Counter
+	failures     prometheus.Counter
+	neutrons     prometheus.Counter
+	products     *prometheus.CounterVec
+	multiplicity prometheus.Histogram
+	rate         *prometheus.GaugeVec
+	running      prometheus.Gauge
+}
+
+func newMetrics() *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		fissions: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "fission_mc_fissions_total",
+			Help: "Number of simulated fissions.",
+		}),
+		failures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "fission_mc_failures_total",
+			Help: "Number of fissions which didn't produce known isotopes.",
+		}),
+		neutrons: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "fission_mc_neutrons_emitted_total",
+			Help: "Number of neutrons released in fissions.",
+		}),
+		products: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "fission_mc_products_total",
+			Help: "Number of fission products of chemical element.",
+		}, []string{"element"}),
+		multiplicity: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "fission_mc_neutron_multiplicity",
+			Help:    "Number of neutrons released per fission.",
+			Buckets: prometheus.LinearBuckets(0, 1, 8),
+		}),
+		rate: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "fission_mc_events_per_second",
+			Help: "Throughput of running simulation.",
+		}, []string{"simulation"}),
+		running: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "fission_mc_simulations_running",
+			Help: "Number of running simulations.",
+		}),
+	}
+	m.registry.MustRegister(m.fissions, m.failures, m.neutrons, m.products, m.multiplicity, m.rate, m.running,
+		prometheus.NewGoCollector(), prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+	return m
+}
+
+func (m *metrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// observe updates metrics from simulation of j. Counters are updated after every
+// hundredth of events from tally of job, so simulation isn't slowed down.
+func (m *metrics) observe(j *job) {
+	var synced tally
+	synced.symbols = make(map[string]int)
+	sync := func() {
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		m.fissions.Add(float64(j.tally.fissions - synced.fissions))
+		m.failures.Add(float64(j.tally.failures - synced.failures))
+		m.neutrons.Add(float64(j.tally.neutrons - synced.neutrons))
+		for s, c := range j.tally.symbols {
+			if d := c - synced.symbols[s]; d > 0 {
+				m.products.WithLabelValues(s).Add(float64(d))
+				synced.symbols[s] = c
+			}
+		}
+		synced.fissions, synced.failures, synced.neutrons = j.tally.fissions, j.tally.failures, j.tally.neutrons
+	}
+
+	id := strconv.Itoa(j.id)
+	b := j.sim.Bus()
+	bus.Subscribe(b, func(e fission.Event) {
+		m.multiplicity.Observe(float64(e.Neutrons))
+	})
+	j.sim.OnProgress(func(p fission.Progress) {
+		sync()
+		m.rate.WithLabelValues(id).Set(p.Rate())
+	})
+	bus.Subscribe(b, func(fission.Finished) {
+		sync()
+		m.rate.DeleteLabelValues(id)
+	})
+}
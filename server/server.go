@@ -0,0 +1,412 @@
+// Package server exposes simulations over HTTP, so they can be started, polled and their
+// results fetched by web frontends or notebooks.
+//
+//	POST /simulations                   start simulation of fission.Config in body
+//	GET  /simulations                   list simulations
+//	GET  /simulations/{id}              status of simulation
+//	DELETE /simulations/{id}            cancel simulation, its partial results are kept
+//	GET  /simulations/{id}/results      summary, counts and neutron statistics
+//	GET  /simulations/{id}/charts/{name} chart "products", "probs" or "neutrons", ?format=svg
+//	GET  /simulations/{id}/events       WebSocket stream of events and rolling aggregates
+//	GET  /metrics                       Prometheus metrics
+//
+// Simulation started with ?wait=stream waits for the first client of its event stream.
+// Finished simulations are removed once they are older than retention of server.
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"physics/fission"
+	"physics/isotope"
+)
+
+// State of a simulation.
+type State string
+
+const (
+	Pending State = "pending"
+	Running State = "running"
+	Done    State = "done"
+	Failed  State = "failed"
+
+	// Cancelled simulation has results of events simulated before it was cancelled.
+	Cancelled State = "cancelled"
+)
+
+// Server runs simulations requested over HTTP. Zero value isn't usable, use New.
+type Server struct {
+	// MaxEvents limits number of events of a simulation, unlimited if zero.
+	MaxEvents int
+
+	// Retention is how long simulation and its results are kept after it finished,
+	// forever if zero.
+	Retention time.Duration
+
+	mu      sync.Mutex
+	jobs    map[int]*job
+	next    int
+	mux     *http.ServeMux
+	metrics *metrics
+}
+
+// job is a simulation started by request.
+type job struct {
+	id      int
+	config  fission.Config
+	created time.Time
+
+	mu        sync.Mutex
+	progress  fission.Progress
+	results   *fission.Results
+	err       error
+	started   bool
+	cancelled bool
+	ended     time.Time
+	tally     tally
+	subs      map[*subscriber]struct{}
+
+	sim     *fission.Simulation
+	ctx     context.Context
+	cancel  context.CancelFunc
+	once    sync.Once
+	running prometheus.Gauge
+}
+
+// Status of a simulation as returned by server.
+type Status struct {
+	ID      int            `json:"id"`
+	State   State          `json:"state"`
+	Config  fission.Config `json:"config"`
+	Created time.Time      `json:"created"`
+	Done    int            `json:"done"`
+	Total   int            `json:"total"`
+	Rate    float64        `json:"events_per_second"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// Results of a finished simulation as returned by server.
+type Results struct {
+	Summary  fission.Summary      `json:"summary"`
+	Symbols  map[string]int       `json:"symbols"`
+	Neutrons fission.NeutronStats `json:"neutrons"`
+}
+
+// New creates server.
+func New() *Server {
+	s := &Server{jobs: make(map[int]*job), next: 1, mux: http.NewServeMux(), metrics: newMetrics()}
+	s.mux.HandleFunc("POST /simulations", s.start)
+	s.mux.HandleFunc("GET /simulations", s.list)
+	s.mux.HandleFunc("GET /simulations/{id}", s.status)
+	s.mux.HandleFunc("DELETE /simulations/{id}", s.stop)
+	s.mux.HandleFunc("GET /simulations/{id}/results", s.results)
+	s.mux.HandleFunc("GET /simulations/{id}/charts/{name}", s.chart)
+	s.mux.HandleFunc("GET /simulations/{id}/events", s.events)
+	s.mux.Handle("GET /metrics", s.metrics.handler())
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) start(w http.ResponseWriter, r *http.Request) {
+	c := fission.DefaultConfig()
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil && !errors.Is(err, io.EOF) {
+		httpError(w, http.StatusBadRequest, err)
+		return
+	}
+	j, err := s.create(c, r.URL.Query().Get("wait") == "stream")
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err)
+		return
+	}
+	w.Header().Set("Location", fmt.Sprintf("/simulations/%d", j.id))
+	writeJSON(w, http.StatusAccepted, j.status())
+}
+
+// create adds simulation of c. Unless wait is true, simulation is started immediately.
+func (s *Server) create(c fission.Config, wait bool) (*job, error) {
+	if s.MaxEvents > 0 && int(c.Events) > s.MaxEvents {
+		return nil, fmt.Errorf("events %d exceed limit %d", c.Events, s.MaxEvents)
+	}
+	// events, progress and metrics are published by a single simulation
+	if c.Workers > 1 {
+		return nil, fmt.Errorf("simulation can't be split among %d workers by server", c.Workers)
+	}
+	sim, err := c.Simulation()
+	if err != nil {
+		return nil, err
+	}
+	c.Seed = sim.Seed
+
+	s.mu.Lock()
+	s.prune()
+	j := newJob(s.next, c, sim)
+	j.running = s.metrics.running
+	s.metrics.observe(j)
+	s.jobs[j.id] = j
+	s.next++
+	s.mu.Unlock()
+
+	if !wait {
+		j.start()
+	}
+	return j, nil
+}
+
+// prune removes simulations which finished more than retention ago, s.mu must be held.
+func (s *Server) prune() {
+	if s.Retention <= 0 {
+		return
+	}
+	for id, j := range s.jobs {
+		if j.expired(s.Retention) {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+// expired reports whether simulation finished more than retention ago.
+func (j *job) expired(retention time.Duration) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return !j.ended.IsZero() && time.Since(j.ended) > retention
+}
+
+func newJob(id int, c fission.Config, sim *fission.Simulation) *job {
+	ctx, cancel := context.WithCancel(context.Background())
+	j := &job{
+		id:      id,
+		config:  c,
+		created: time.Now(),
+		sim:     sim,
+		ctx:     ctx,
+		cancel:  cancel,
+		tally:   tally{symbols: make(map[string]int)},
+		subs:    make(map[*subscriber]struct{}),
+	}
+	j.observe()
+	return j
+}
+
+// start runs simulation in background, only the first call has effect.
+func (j *job) start() {
+	j.once.Do(func() {
+		j.mu.Lock()
+		j.started = true
+		j.mu.Unlock()
+		go j.run()
+	})
+}
+
+func (j *job) run() {
+	if j.running != nil {
+		j.running.Inc()
+		defer j.running.Dec()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			j.mu.Lock()
+			j.err = fmt.Errorf("simulation failed: %v", r)
+			j.mu.Unlock()
+		}
+		j.finish()
+	}()
+	results, err := j.sim.Run(j.ctx)
+	j.mu.Lock()
+	j.results = results
+	j.cancelled = err != nil
+	j.mu.Unlock()
+}
+
+// stop cancels simulation. Pending simulation is started, so that it finishes
+// with no events and its stream is closed.
+func (j *job) stop() {
+	j.cancel()
+	j.start()
+}
+
+func (j *job) status() Status {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	st := Status{
+		ID:      j.id,
+		State:   Pending,
+		Config:  j.config,
+		Created: j.created,
+		Done:    j.progress.Done,
+		Total:   int(j.config.Events),
+		Rate:    j.progress.Rate(),
+	}
+	switch {
+	case j.err != nil:
+		st.State = Failed
+		st.Error = j.err.Error()
+	case j.results != nil && j.cancelled:
+		st.State = Cancelled
+	case j.results != nil:
+		st.State = Done
+	case j.started:
+		st.State = Running
+	}
+	return st
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.prune()
+	jobs := make([]*job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		jobs = append(jobs, j)
+	}
+	s.mu.Unlock()
+	sort.Slice(jobs, func(a, b int) bool { return jobs[a].id < jobs[b].id })
+
+	statuses := make([]Status, len(jobs))
+	for i, j := range jobs {
+		statuses[i] = j.status()
+	}
+	writeJSON(w, http.StatusOK, statuses)
+}
+
+func (s *Server) status(w http.ResponseWriter, r *http.Request) {
+	if j := s.job(w, r); j != nil {
+		writeJSON(w, http.StatusOK, j.status())
+	}
+}
+
+func (s *Server) stop(w http.ResponseWriter, r *http.Request) {
+	if j := s.job(w, r); j != nil {
+		j.stop()
+		writeJSON(w, http.StatusAccepted, j.status())
+	}
+}
+
+func (s *Server) results(w http.ResponseWriter, r *http.Request) {
+	res := s.finished(w, r)
+	if res == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, Results{
+		Summary:  res.Summary(),
+		Symbols:  res.Tally.CountSymbols(),
+		Neutrons: res.NeutronStats(),
+	})
+}
+
+func (s *Server) chart(w http.ResponseWriter, r *http.Request) {
+	res := s.finished(w, r)
+	if res == nil {
+		return
+	}
+	format, err := isotope.ParseImageFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var render func(io.Writer, isotope.ImageFormat, isotope.ChartOptions) error
+	switch r.PathValue("name") {
+	case "products":
+		render = res.Tally.CountSymbols().RenderChart
+	case "probs":
+		render = res.Tally.CountProbabilities().RenderChart
+	case "neutrons":
+		render = res.NeutronStats().RenderChart
+	default:
+		httpError(w, http.StatusNotFound, fmt.Errorf("unknown chart %q", r.PathValue("name")))
+		return
+	}
+
+	// render fully before writing headers, so failed chart is an error response
+	var buf bytes.Buffer
+	if err := render(&buf, format, isotope.ChartOptions{}); err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, isotope.ErrChartsDisabled) {
+			code = http.StatusNotImplemented
+		}
+		httpError(w, code, err)
+		return
+	}
+	contentType := "image/png"
+	if format == isotope.SVG {
+		contentType = "image/svg+xml"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Write(buf.Bytes())
+}
+
+// job returns job of request or writes error response if it doesn't exist.
+func (s *Server) job(w http.ResponseWriter, r *http.Request) *job {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	j := s.lookup(id)
+	if err != nil || j == nil {
+		httpError(w, http.StatusNotFound, fmt.Errorf("simulation %q not found", r.PathValue("id")))
+		return nil
+	}
+	return j
+}
+
+func (s *Server) lookup(id int) *job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune()
+	return s.jobs[id]
+}
+
+// finished returns results of job of request or writes error response if it hasn't finished.
+func (s *Server) finished(w http.ResponseWriter, r *http.Request) *fission.Results {
+	j := s.job(w, r)
+	if j == nil {
+		return nil
+	}
+	res, err := j.finished()
+	switch {
+	case errors.Is(err, errRunning):
+		httpError(w, http.StatusConflict, err)
+	case err != nil:
+		httpError(w, http.StatusInternalServerError, err)
+	}
+	return res
+}
+
+var errRunning = errors.New("simulation is still running")
+
+// finished returns results of simulation, or errRunning if it hasn't finished.
+func (j *job) finished() (*fission.Results, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.err != nil {
+		return nil, j.err
+	}
+	if j.results == nil {
+		return nil, fmt.Errorf("simulation %d: %w", j.id, errRunning)
+	}
+	return j.results, nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", " ")
+	enc.Encode(v)
+}
+
+func httpError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,202 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"physics/fission"
+	"physics/internal/bus"
+)
+
+// StreamEvent is a fission event sent to stream clients.
+type StreamEvent struct {
+	Type     string   `json:"type"`
+	Parent   string   `json:"parent"`
+	Products []string `json:"products"`
+	Neutrons int      `json:"neutrons"`
+}
+
+func newStreamEvent(e fission.Event) StreamEvent {
+	msg := StreamEvent{Type: "event", Parent: e.Parent.Name(), Neutrons: e.Neutrons}
+	for _, p := range e.Products {
+		msg.Products = append(msg.Products, p.Name())
+	}
+	return msg
+}
+
+// Aggregate are rolling tallies sent to stream clients after every hundredth of events.
+// Final aggregate is sent once simulation has ended.
+type Aggregate struct {
+	Type     string         `json:"type"`
+	Done     int            `json:"done"`
+	Total    int            `json:"total"`
+	Fissions int            `json:"fissions"`
+	Failures int            `json:"failures"`
+	Nu       float64        `json:"nu"`
+	Symbols  map[string]int `json:"symbols"`
+	Rate     float64        `json:"events_per_second"`
+
+	// Dropped is number of events not sent to this client because it was too slow.
+	Dropped int  `json:"dropped"`
+	Final   bool `json:"final"`
+}
+
+// tally is rolling tally of a running simulation.
+type tally struct {
+	fissions int
+	failures int
+	neutrons int
+	symbols  map[string]int
+}
+
+// subscriber is a stream client. Messages are fission.Event and Aggregate.
+type subscriber struct {
+	messages chan any
+	dropped  int
+}
+
+// Buffer of messages of a client. Events are dropped once half of it is full,
+// so aggregates still fit.
+const streamBuffer = 4096
+
+// observe tallies events of simulation and broadcasts them to stream clients.
+func (j *job) observe() {
+	b := j.sim.Bus()
+	bus.Subscribe(b, func(e fission.Event) {
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		j.tally.fissions++
+		j.tally.neutrons += e.Neutrons
+		for _, p := range e.Products {
+			j.tally.symbols[p.Symbol]++
+		}
+		for sub := range j.subs {
+			if len(sub.messages) < streamBuffer/2 {
+				sub.messages <- e
+			} else {
+				sub.dropped++
+			}
+		}
+	})
+	bus.Subscribe(b, func(fission.Failure) {
+		j.mu.Lock()
+		j.tally.failures++
+		j.mu.Unlock()
+	})
+	j.sim.OnProgress(func(p fission.Progress) {
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		j.progress = p
+		for sub := range j.subs {
+			select {
+			case sub.messages <- j.aggregate(sub, false):
+			default:
+			}
+		}
+	})
+}
+
+// aggregate returns rolling tallies for sub, j.mu must be held.
+func (j *job) aggregate(sub *subscriber, final bool) Aggregate {
+	a := Aggregate{
+		Type:     "aggregate",
+		Done:     j.progress.Done,
+		Total:    int(j.config.Events),
+		Fissions: j.tally.fissions,
+		Failures: j.tally.failures,
+		Symbols:  make(map[string]int, len(j.tally.symbols)),
+		Rate:     j.progress.Rate(),
+		Dropped:  sub.dropped,
+		Final:    final,
+	}
+	if a.Fissions > 0 {
+		a.Nu = float64(j.tally.neutrons) / float64(a.Fissions)
+	}
+	for s, c := range j.tally.symbols {
+		a.Symbols[s] = c
+	}
+	return a
+}
+
+// finish records when simulation ended, sends final aggregate to stream clients and
+// closes their streams.
+func (j *job) finish() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.ended = time.Now()
+	for sub := range j.subs {
+		j.close(sub)
+	}
+}
+
+// close sends final aggregate to sub and removes it, j.mu must be held.
+func (j *job) close(sub *subscriber) {
+	select {
+	case sub.messages <- j.aggregate(sub, true):
+	default:
+	}
+	close(sub.messages)
+	delete(j.subs, sub)
+}
+
+// subscribe adds stream client. Client of finished simulation gets only final aggregate.
+func (j *job) subscribe() *subscriber {
+	sub := &subscriber{messages: make(chan any, streamBuffer)}
+	j.mu.Lock()
+	j.subs[sub] = struct{}{}
+	if j.results != nil || j.err != nil {
+		j.close(sub)
+	}
+	j.mu.Unlock()
+	j.start()
+	return sub
+}
+
+func (j *job) unsubscribe(sub *subscriber) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, ok := j.subs[sub]; ok {
+		close(sub.messages)
+		delete(j.subs, sub)
+	}
+}
+
+var upgrader = websocket.Upgrader{
+	// Streams are read only, so they can be consumed by pages of any origin.
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+func (s *Server) events(w http.ResponseWriter, r *http.Request) {
+	j := s.job(w, r)
+	if j == nil {
+		return
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	sub := j.subscribe()
+	go func() {
+		// client closed stream
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				j.unsubscribe(sub)
+				return
+			}
+		}
+	}()
+	for msg := range sub.messages {
+		if e, ok := msg.(fission.Event); ok {
+			msg = newStreamEvent(e)
+		}
+		if err := conn.WriteJSON(msg); err != nil {
+			j.unsubscribe(sub)
+			break
+		}
+	}
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+}
@@ -0,0 +1,694 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"physics/broker"
+	"physics/count"
+	"physics/decay"
+	"physics/decay/bateman"
+	"physics/eventlog"
+	"physics/fission"
+	"physics/internal/bus"
+	"physics/isotope"
+	"physics/kinematics"
+	_ "physics/pb" // registers protobuf format of results
+	"physics/periodic"
+	"physics/plot"
+	"physics/plotly"
+	"physics/random"
+	"physics/report"
+	"physics/spectrum"
+	"physics/store"
+	"physics/units"
+)
+
+type simulateFlags struct {
+	config     string
+	isotope    string
+	fuel       string
+	spectrum   isotope.Spectrum
+	structures string
+	events     units.Count
+	converge   fission.Convergence
+	weighting  fission.Weighting
+	seed       int64
+	workers    int
+	rng        random.Engine
+	policy     isotope.FragmentPolicy
+	neutrons   isotope.Multiplicity
+	model      isotope.NeutronModel
+	charge     isotope.ChargeModel
+	yields     string
+	emission   kinematics.Emission
+	directions bool
+	geometry   string
+	size       float64
+	albedo     float64
+	chains     int
+	output     outputFlags
+	nocharts   bool
+	renderer   string
+	plotly     bool
+	segre      bool
+	periodic   bool
+	format     string
+	parquet    string
+	ndjson     string
+	eventlog   string
+	replay     string
+	report     bool
+	reference  bool
+	bootstrap  int
+	ratios     []string
+	inventory  bool
+	fissions   float64
+	activity   []string
+	until      units.Duration
+	cooling    units.Duration
+	distance   float64
+	db         string
+	nats       string
+	subject    string
+	progress   bool
+	tui        bool
+	snapshot   int
+	animate    bool
+
+	checkpoint      string
+	checkpointEvery units.Count
+	resume          string
+}
+
+func simulateCmd() *cobra.Command {
+	var fl simulateFlags
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Run simulation and save counts, charts and run bundle",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := fission.DefaultConfig()
+			f := cmd.Flags()
+			if fl.config != "" {
+				e, err := loadExperiment(fl.config)
+				if err != nil {
+					return err
+				}
+				if err := e.Output.apply(f); err != nil {
+					return fmt.Errorf("%s: %w", fl.config, err)
+				}
+				c = e.Config
+			}
+			if f.Changed("isotope") {
+				c.Isotope = fl.isotope
+			}
+			if f.Changed("fuel") {
+				fuel, err := fission.ParseFuel(fl.fuel)
+				if err != nil {
+					return err
+				}
+				c.Fuel = fuel
+			}
+			if f.Changed("structures") {
+				structures, err := fission.ParseStructures(fl.structures)
+				if err != nil {
+					return err
+				}
+				c.Structures = structures
+			}
+			if f.Changed("spectrum") {
+				c.Spectrum = fl.spectrum
+			}
+			if f.Changed("events") {
+				c.Events = fl.events
+			}
+			if f.Changed("converge-change") || f.Changed("converge-error") || f.Changed("converge-window") || f.Changed("converge-top") {
+				if c.Convergence == nil {
+					c.Convergence = &fission.Convergence{}
+				}
+				if f.Changed("converge-change") {
+					c.Convergence.Change = fl.converge.Change
+				}
+				if f.Changed("converge-error") {
+					c.Convergence.RelativeError = fl.converge.RelativeError
+				}
+				if f.Changed("converge-window") {
+					c.Convergence.Window = fl.converge.Window
+				}
+				if f.Changed("converge-top") {
+					c.Convergence.Top = fl.converge.Top
+				}
+			}
+			if f.Changed("implicit-capture") || f.Changed("mass-bins") {
+				if c.Weighting == nil {
+					c.Weighting = &fission.Weighting{}
+				}
+				if f.Changed("implicit-capture") {
+					c.Weighting.ImplicitCapture = fl.weighting.ImplicitCapture
+				}
+				if f.Changed("mass-bins") {
+					c.Weighting.MassBins = fl.weighting.MassBins
+				}
+			}
+			if f.Changed("seed") {
+				c.Seed = fl.seed
+			}
+			if f.Changed("workers") {
+				c.Workers = fl.workers
+			}
+			if f.Changed("rng") {
+				c.RNG = fl.rng
+			}
+			if f.Changed("fragments") {
+				c.FragmentPolicy = fl.policy
+			}
+			if f.Changed("neutron-model") {
+				c.NeutronModel = fl.model
+			}
+			if f.Changed("charge-model") {
+				c.ChargeModel = fl.charge
+			}
+			if f.Changed("yields") {
+				c.Yields = fl.yields
+			}
+			if f.Changed("directions") {
+				c.Directions = fl.directions
+			}
+			if f.Changed("emission") {
+				c.Emission = fl.emission
+			}
+			if f.Changed("geometry") || f.Changed("size") || f.Changed("albedo") || f.Changed("chains") {
+				if c.Transport == nil {
+					c.Transport = &fission.TransportConfig{}
+				}
+				if f.Changed("geometry") {
+					c.Transport.Geometry = fl.geometry
+				}
+				if f.Changed("size") {
+					c.Transport.Size = fl.size
+				}
+				if f.Changed("albedo") {
+					c.Transport.Albedo = fl.albedo
+				}
+				if f.Changed("chains") {
+					c.Transport.Chains = fl.chains
+				}
+			}
+			if f.Changed("multiplicity") {
+				c.Multiplicity = fl.neutrons
+			}
+			return simulate(cmd.Context(), c, fl)
+		},
+	}
+	fl.events = fission.DefaultConfig().Events
+	fl.checkpointEvery = 1000000
+	fl.until = units.Duration(100 * 365 * 24 * time.Hour)
+	f := cmd.Flags()
+	f.StringVarP(&fl.config, "config", "c", "", "experiment config file (json, yaml or toml), other flags override its values")
+	f.StringVar(&fl.isotope, "isotope", fission.DefaultConfig().Isotope, "isotope to fission, e.g. U-235 or Pu239")
+	f.StringVar(&fl.fuel, "fuel", "", "nuclides absorbing neutrons with relative atoms instead of isotope, e.g. U-235:0.03,U-238:0.97")
+	f.StringVar(&fl.structures, "structures", "", "structural materials capturing fractions of neutrons, e.g. Fe:0.05,H2O:0.1, of "+strings.Join(fission.Materials(), ", "))
+	f.Var(&fl.spectrum, "spectrum", "spectrum of neutrons absorbed in fuel, chooses cross sections: thermal or fast")
+	f.Var(&fl.events, "events", "number of fissions, e.g. 10000 or 1M")
+	f.Float64Var(&fl.converge.Change, "converge-change", 0, "stop before --events once probabilities of top elements change less than percent over window, e.g. 0.1")
+	f.Float64Var(&fl.converge.RelativeError, "converge-error", 0, "stop before --events once relative standard errors of probabilities of top elements are less, e.g. 0.01")
+	f.IntVar(&fl.converge.Window, "converge-window", 1000, "number of events between convergence checks")
+	f.IntVar(&fl.converge.Top, "converge-top", 20, "number of the most probable elements checked for convergence")
+	f.BoolVar(&fl.weighting.ImplicitCapture, "implicit-capture", false, "fission every neutron absorbed by fuel weighted by probability of fission, and save weighted yields")
+	f.IntVar(&fl.weighting.MassBins, "mass-bins", 0, "stratify masses of heavier fragment into bins drawn in turn, and save weighted yields")
+	f.Int64Var(&fl.seed, "seed", 0, "seed of random numbers, random if zero")
+	f.Var(&fl.rng, "rng", "engine of random numbers: "+strings.Join(random.Engines(), ", ")+", crypto isn't reproducible by seed")
+	f.IntVar(&fl.workers, "workers", 0, "split events among workers with seeds derived from --seed, merged results don't depend on scheduling")
+	f.Var(&fl.policy, "fragments", "policy for fragments without equivalent isotope: reject, retry, keep or nearest")
+	f.Var(&fl.model, "neutron-model", "how neutrons are drawn: independent of fragments or by sawtooth of their masses")
+	f.StringVar(&fl.yields, "yields", "", "draw fragments from independent yields of ENDF-6 file, e.g. of JEFF library")
+	f.Var(&fl.charge, "charge-model", "how charges of fragments are drawn: proportional to their masses or by wahl Zp systematics")
+	f.Var(&fl.neutrons, "multiplicity", "comma separated probabilities of 0, 1, 2... neutrons released in fission, evaluated for isotope by default")
+	f.BoolVar(&fl.directions, "directions", false, "sample velocity vectors of products and neutrons")
+	f.Var(&fl.emission, "emission", "angular emission of neutrons with --directions: isotropic or from fragments")
+	f.StringVar(&fl.geometry, "geometry", "", "follow neutrons through geometry of fast uranium: infinite, sphere or slab")
+	f.Float64Var(&fl.size, "size", 0, "radius of sphere or thickness of slab in cm")
+	f.Float64Var(&fl.albedo, "albedo", 0, "fraction of escaping neutrons returned by reflector around geometry")
+	f.IntVar(&fl.chains, "chains", 0, "number of fission chains followed after simulation for chain length statistics")
+	fl.output.add(cmd)
+	f.BoolVar(&fl.nocharts, "nocharts", false, "skip chart rendering, only data files are saved")
+	f.BoolVar(&fl.plotly, "plotly", false, "save Plotly figure json of every chart, also with --nocharts")
+	f.BoolVar(&fl.segre, "segre", false, "save chart of nuclides of products by neutron and atomic number")
+	f.BoolVar(&fl.periodic, "periodic", false, "save svg of periodic table colored by yields of elements")
+	f.StringVar(&fl.renderer, "renderer", "gochart", "library which draws charts of elements, isotopes, probabilities and neutrons: gochart or gonum")
+	f.StringVar(&fl.format, "format", "json", "comma separated data formats: json, yaml, csv, protobuf")
+	f.StringVar(&fl.parquet, "parquet", "", "write every fission event to parquet file")
+	f.StringVar(&fl.eventlog, "eventlog", "", "write every fission event to binary event log file, which events command inspects")
+	f.StringVar(&fl.replay, "replay", "", "recompute results from fissions of binary event log instead of simulating them, other flags should match recorded run, no run bundle is saved")
+	f.StringVar(&fl.ndjson, "ndjson", "", "stream every fission event to newline delimited json file")
+	f.BoolVar(&fl.inventory, "inventory", false, "save inventory of radionuclides of products with their activities")
+	f.Float64Var(&fl.fissions, "inventory-fissions", 0, "number of fissions of inventory, products are scaled from fissions of simulation")
+	f.Var(&fl.cooling, "cooling", "time after run of inventory, e.g. 30d")
+	f.Float64Var(&fl.distance, "dose-distance", 0, "save dose rate of gammas of inventory at distance in m")
+	f.StringSliceVar(&fl.activity, "activity", nil, "save activity over time after run of comma separated nuclides of inventory, e.g. I-131,Cs-137,Sr-90")
+	f.Var(&fl.until, "activity-until", "end of time of activity after run, from a minute")
+	f.BoolVar(&fl.reference, "reference", false, "compare yields of elements with reference cumulative yields of thermal fission of U-235")
+	f.IntVar(&fl.bootstrap, "bootstrap", 0, "resample batches of events n times to estimate errors of yields of top elements and of --ratio")
+	f.StringSliceVar(&fl.ratios, "ratio", nil, "comma separated ratios of yields of elements estimated by --bootstrap, e.g. Cs/Sr,Xe/Kr")
+	f.BoolVar(&fl.report, "report", false, "save html report with charts and tables of results")
+	f.StringVar(&fl.db, "db", "", "store events and counts in sqlite database")
+	f.StringVar(&fl.nats, "nats", "", "publish every fission event to NATS server at url, e.g. nats://localhost:4222")
+	f.StringVar(&fl.subject, "nats-subject", broker.DefaultSubject, "NATS subject of events")
+	f.IntVar(&fl.snapshot, "snapshot", 0, "save time series of aggregates snapshotted every n events")
+	f.BoolVar(&fl.animate, "animate", false, "save animated gif of convergence of probabilities of elements of --snapshot series")
+	f.BoolVar(&fl.tui, "tui", false, "show live terminal dashboard of yields and neutron multiplicity")
+	f.StringVar(&fl.checkpoint, "checkpoint", "", "save checkpoint file periodically and on interrupt, so run can be resumed")
+	f.Var(&fl.checkpointEvery, "checkpoint-every", "number of events between checkpoints")
+	f.StringVar(&fl.resume, "resume", "", "resume simulation from checkpoint file, its config replaces other simulation flags")
+	f.BoolVar(&fl.progress, "progress", isTerminal(os.Stderr), "show progress bar with estimated remaining time")
+	return cmd
+}
+
+// simulate runs simulation of config and saves its outputs. When ctx is cancelled, e.g. by interrupt,
+// results of events simulated so far are saved and error of ctx is returned.
+func simulate(ctx context.Context, config fission.Config, fl simulateFlags) error {
+	out, err := fl.output.config()
+	if err != nil {
+		return err
+	}
+	formats, err := isotope.ParseFormats(fl.format)
+	if err != nil {
+		return err
+	}
+	renderer, err := parseRenderer(fl.renderer)
+	if err != nil {
+		return err
+	}
+	var ratios [][2]string
+	for _, r := range fl.ratios {
+		num, den, ok := strings.Cut(r, "/")
+		if !ok || num == "" || den == "" {
+			return fmt.Errorf("ratio %q isn't of two elements, e.g. Cs/Sr", r)
+		}
+		ratios = append(ratios, [2]string{num, den})
+	}
+
+	var replay *eventlog.Reader
+	if fl.replay != "" {
+		switch {
+		case fl.resume != "" || fl.checkpoint != "":
+			return errors.New("simulate: --replay can't be used with --resume or --checkpoint")
+		case fl.tui:
+			return errors.New("simulate: --replay can't be used with --tui")
+		case fl.eventlog == fl.replay:
+			return errors.New("simulate: --eventlog would overwrite replayed event log")
+		}
+		if replay, err = eventlog.Open(fl.replay); err != nil {
+			return err
+		}
+		defer replay.Close()
+		config.Events = units.Count(replay.Len())
+	}
+
+	if fl.animate && fl.snapshot <= 0 {
+		return errors.New("simulate: --animate needs --snapshot")
+	}
+
+	if config.Workers > 1 {
+		switch {
+		case fl.replay != "" || fl.resume != "" || fl.checkpoint != "":
+			return errors.New("simulate: --workers can't be used with --replay, --resume or --checkpoint")
+		case fl.tui || fl.snapshot > 0:
+			return errors.New("simulate: --workers can't be used with --tui or --snapshot")
+		case fl.parquet != "" || fl.ndjson != "" || fl.eventlog != "" || fl.nats != "" || fl.db != "":
+			return errors.New("simulate: --workers can't record events, which are simulated in parallel")
+		}
+	}
+
+	var cp *fission.Checkpoint
+	if fl.resume != "" {
+		if cp, err = fission.LoadCheckpoint(fl.resume); err != nil {
+			return err
+		}
+		config = cp.Config
+		if fl.checkpoint == "" {
+			fl.checkpoint = fl.resume
+		}
+	}
+	sim, err := config.Simulation()
+	if err != nil {
+		return err
+	}
+	if cp != nil {
+		if err := sim.Resume(cp); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "resuming %s after %d of %d events\n", fl.resume, cp.Done, config.Events)
+	}
+	if fl.checkpoint != "" {
+		sim.OnCheckpoint(int(fl.checkpointEvery), func(cp *fission.Checkpoint) {
+			if err := cp.Save(fl.checkpoint); err != nil {
+				fmt.Fprintln(os.Stderr, "warning: checkpoint not saved:", err)
+			}
+		})
+	}
+	if fl.parquet != "" {
+		closeEvents, err := writeEvents(sim, fl.parquet, func(w io.Writer) eventWriter {
+			return eventlog.NewParquetWriter(w)
+		})
+		if err != nil {
+			return err
+		}
+		defer closeEvents()
+	}
+	if fl.ndjson != "" {
+		closeStream, err := writeEvents(sim, fl.ndjson, func(w io.Writer) eventWriter {
+			return eventlog.NewNDJSONWriter(w)
+		})
+		if err != nil {
+			return err
+		}
+		defer closeStream()
+	}
+	if fl.eventlog != "" {
+		f, err := os.Create(fl.eventlog)
+		if err != nil {
+			return err
+		}
+		w, err := eventlog.NewWriter(f)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		defer publishEvents(sim, w, f)()
+	}
+	if fl.nats != "" {
+		p, err := broker.DialNATS(fl.nats, fl.subject)
+		if err != nil {
+			return err
+		}
+		defer publishEvents(sim, p)()
+	}
+	if fl.db != "" {
+		st, err := store.Open(fl.db)
+		if err != nil {
+			return err
+		}
+		defer st.Close()
+		rec, err := st.Record(sim)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := rec.Err(); err != nil {
+				fmt.Fprintln(os.Stderr, "warning: run not stored:", err)
+			}
+		}()
+	}
+	var series *fission.Series
+	if fl.snapshot > 0 {
+		series = sim.Snapshots(fl.snapshot)
+	}
+	var results *fission.Results
+	var stopped error
+	if fl.tui {
+		if results, stopped = runTUI(ctx, sim); results == nil {
+			return stopped
+		}
+	} else {
+		if fl.progress {
+			bar := &progressBar{w: os.Stderr, width: 40}
+			sim.OnProgress(bar.update)
+			bus.Subscribe(sim.Bus(), bar.finish)
+		}
+		switch {
+		case replay != nil:
+			results, stopped = sim.Replay(ctx, replay)
+		case config.Workers > 1:
+			if results, stopped = fission.RunParallel(ctx, config); results == nil {
+				return stopped
+			}
+		default:
+			results, stopped = sim.Run(ctx)
+		}
+	}
+	if stopped != nil {
+		fmt.Fprintf(os.Stderr, "warning: simulation stopped after %d events: %v\n", results.Summary().Events, stopped)
+	}
+	products := &results.Tally
+	symbols := products.CountSymbols()
+	probs := products.CountProbabilities()
+	groups := products.CountIsotopes()
+	neutrons := results.NeutronStats()
+	nuclides := spectrum.FromCounts(products.Counts())
+	var inventory *decay.Inventory
+	var activities *decay.Activities
+	var dose *decay.Dose
+	var comparison *fission.Comparison
+	if fl.reference {
+		if comparison, err = results.CompareWithReference(); err != nil {
+			return err
+		}
+	}
+	if fl.inventory || len(fl.activity) > 0 || fl.distance > 0 {
+		inventory = results.Inventory(fl.fissions, time.Duration(fl.cooling).Seconds())
+	}
+	if fl.distance > 0 {
+		if dose, err = inventory.Dose(fl.distance); err != nil {
+			return err
+		}
+	}
+	if len(fl.activity) > 0 {
+		atoms := results.Atoms(fl.fissions)
+		if activities, err = bateman.Activities(atoms, fl.activity, decay.Minute, time.Duration(fl.until).Seconds(), 100); err != nil {
+			return err
+		}
+	}
+
+	if err := results.Save(out, formats...); err != nil {
+		return err
+	}
+	for _, f := range formats {
+		if f == isotope.Protobuf {
+			continue
+		}
+		var savers []func(isotope.OutputConfig, isotope.Format) error
+		if series != nil {
+			savers = append(savers, series.Save)
+		}
+		if fl.inventory {
+			savers = append(savers, inventory.Save)
+		}
+		if activities != nil {
+			savers = append(savers, activities.Save)
+		}
+		if dose != nil {
+			savers = append(savers, dose.Save)
+		}
+		if comparison != nil {
+			savers = append(savers, comparison.Save)
+		}
+		for _, save := range savers {
+			if err := save(out, f); err != nil {
+				return err
+			}
+		}
+	}
+
+	// deferred calls run in reverse, so inventory is printed after summary
+	if comparison != nil {
+		defer printComparison(comparison)
+	}
+	if results.Weighted != nil {
+		defer printWeighted(results.Weighted)
+	}
+	if fl.bootstrap > 0 {
+		defer printBootstrap(results, fl.bootstrap, ratios, config.RNG.New(results.Seed))
+	}
+	if dose != nil {
+		defer printDose(dose)
+	}
+	if fl.inventory {
+		defer printInventory(inventory)
+	}
+	if config.Expect != nil {
+		checks := config.Expect.Check(results)
+		defer func() {
+			fmt.Println()
+			fission.Report(os.Stdout, checks)
+		}()
+		if !fission.Passed(checks) && stopped == nil {
+			stopped = errors.New("simulate: expectations failed")
+		}
+	}
+	defer fmt.Print(results.Summary())
+
+	if fl.report {
+		rep, err := report.New(results)
+		if err == nil {
+			err = rep.Save(out)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "warning: report not saved:", err)
+		}
+	}
+
+	if fl.plotly {
+		opts := isotope.ChartOptions{}
+		figures := map[string]plotly.Figure{
+			"products": plotly.Bars(symbols.Plot(opts), opts),
+			"probs":    plotly.Shares(probs.Plot(opts), opts),
+			"neutrons": plotly.Bars(neutrons.Plot(opts), opts),
+			"segre":    plotly.Segre(nuclides, opts),
+		}
+		for symbol := range groups {
+			figures["charts/"+symbol] = plotly.Bars(groups.Plot(symbol, opts), opts)
+		}
+		if activities != nil {
+			figures["activity"] = plotly.Activities(activities, opts)
+		}
+		if comparison != nil {
+			figures["reference"] = plotly.Comparison(comparison, opts)
+		}
+		for name, f := range figures {
+			if err := f.Save(out, name); err != nil {
+				return err
+			}
+		}
+	}
+	if fl.nocharts {
+		return stopped
+	}
+	for _, save := range []func(isotope.OutputConfig, isotope.ChartOptions) error{symbols.SaveChart, probs.SaveChart, groups.SaveChart, neutrons.SaveChart} {
+		if err := save(out, isotope.ChartOptions{Renderer: renderer}); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: charts not saved:", err)
+			break
+		}
+	}
+	if fl.animate {
+		if err := series.SaveAnimation(out, isotope.ChartOptions{}); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: animation not saved:", err)
+		}
+	}
+	if fl.periodic {
+		if err := periodic.FromCounts(products.Counts(), results.Fissions).Save(out, isotope.ChartOptions{}); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: periodic table not saved:", err)
+		}
+	}
+	if fl.segre {
+		if err := plot.SaveSegre(out, nuclides, isotope.ChartOptions{}); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: nuclide chart not saved:", err)
+		}
+	}
+	if activities != nil {
+		if err := activities.SaveChart(out, isotope.ChartOptions{}); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: activity chart not saved:", err)
+		}
+	}
+	if comparison != nil {
+		if err := comparison.SaveChart(out, isotope.ChartOptions{}); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: reference chart not saved:", err)
+		}
+	}
+	return stopped
+}
+
+// eventWriter is a file format of event records.
+type eventWriter interface {
+	Write(eventlog.Record) error
+	Close() error
+}
+
+// writeEvents writes events of simulation to file at path as they happen.
+// File is compressed if path ends with .gz or .zst.
+// Returned function must be called after simulation has finished.
+func writeEvents(sim *fission.Simulation, path string, format func(io.Writer) eventWriter) (func(), error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	cw, err := isotope.CompressionOf(path).Writer(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return publishEvents(sim, format(cw), cw, f), nil
+}
+
+// publishEvents writes events of simulation to w as they happen. Returned function
+// must be called after simulation has finished, it closes w and then closers.
+func publishEvents(sim *fission.Simulation, w eventWriter, closers ...io.Closer) func() {
+	var werr error
+	bus.Subscribe(sim.Bus(), func(e fission.Event) {
+		if werr == nil {
+			r := eventlog.NewRecord(e.Parent, e.Products, e.Neutrons)
+			r.KineticEnergy = e.KineticEnergy
+			werr = w.Write(r)
+		}
+	})
+	return func() {
+		if err := w.Close(); werr == nil {
+			werr = err
+		}
+		for _, c := range closers {
+			if err := c.Close(); werr == nil {
+				werr = err
+			}
+		}
+		if werr != nil {
+			fmt.Fprintln(os.Stderr, "warning: events not saved:", werr)
+		}
+	}
+}
+
+// printInventory prints total activity of inventory and its ten most active radionuclides.
+func printInventory(inv *decay.Inventory) {
+	fmt.Printf("inventory: %.4g Bq\n", inv.Activity)
+	for _, a := range inv.Top(10) {
+		fmt.Printf("  %-8s %12.4g Bq %7.3f%%\n", a.Nuclide, a.Activity, 100*a.Fraction)
+	}
+}
+
+// printDose prints dose rate and its five largest contributions.
+func printDose(d *decay.Dose) {
+	fmt.Printf("dose rate at %g m: %.4g µSv/h\n", d.Distance, d.DoseRate)
+	for _, n := range d.Top(5) {
+		fmt.Printf("  %-8s %12.4g µSv/h %7.3f%%\n", n.Nuclide, n.DoseRate, 100*n.Fraction)
+	}
+}
+
+// printComparison prints chi-square of comparison with reference yields and five largest deviations.
+func printComparison(c *fission.Comparison) {
+	fmt.Printf("reference yields: chi-square %.4g for %d degrees of freedom, %.3f%% of products not compared\n",
+		c.ChiSquare, c.DegreesOfFreedom, c.Other)
+	for _, d := range c.Largest(5) {
+		fmt.Printf("  %-3s %8.3f%% simulated %8.3f%% reference %+8.3f%%\n", d.Symbol, d.Simulated, d.Reference, d.Deviation)
+	}
+}
+
+// printWeighted prints ten largest weighted yields with their relative errors.
+func printWeighted(w *fission.Weighted) {
+	yields := w.Yields()
+	fmt.Printf("weighted yields of %d events:\n", w.Histories)
+	for _, y := range yields[:min(10, len(yields))] {
+		fmt.Printf("  %-8s %7.3f%% ± %.2f%%\n", y.Isotope, y.Mean, 100*y.RelativeError)
+	}
+}
+
+// printBootstrap prints yields of five most common elements and ratios of yields with their
+// errors estimated by n bootstrap replicas drawn from rng.
+func printBootstrap(r *fission.Results, n int, ratios [][2]string, rng random.Rand) {
+	b := r.Bootstrap(n, rng)
+	fmt.Printf("bootstrap of %d batches, %d replicas:\n", len(r.Batches), len(b.Replicas))
+	for _, e := range count.Counter[string](r.Tally.CountSymbols()).TopN(5) {
+		s := b.Estimate(fission.ElementYield(e.Key))
+		fmt.Printf("  %-7s %9.4f%% ± %.4f%% (%.4f%% - %.4f%%)\n", e.Key, s.Mean, s.StdErr, s.Lower, s.Upper)
+	}
+	for _, ratio := range ratios {
+		s := b.Estimate(fission.Ratio(ratio[0], ratio[1]))
+		fmt.Printf("  %-7s %10.4f ± %.4f (%.4f - %.4f)\n", ratio[0]+"/"+ratio[1], s.Mean, s.StdErr, s.Lower, s.Upper)
+	}
+}
@@ -0,0 +1,165 @@
+// Package spectrum converts fission products and event logs to gonum vectors and matrices,
+// e.g. mass spectrum or matrix of products by atomic and mass number, so that they can be
+// fitted or analysed further with gonum.
+package spectrum
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+
+	"physics/eventlog"
+	"physics/isotope"
+)
+
+// Spectrum is number of products of each atomic and mass number.
+type Spectrum struct {
+	counts map[isotope.ZA]float64
+
+	// largest atomic and mass numbers of products
+	number, mass int
+}
+
+// New creates empty spectrum.
+func New() *Spectrum {
+	return &Spectrum{counts: make(map[isotope.ZA]float64)}
+}
+
+// FromProducts returns spectrum of products.
+func FromProducts(prods isotope.Products) *Spectrum {
+	s := New()
+	for _, p := range prods {
+		s.Add(p.ZA(), 1)
+	}
+	return s
+}
+
+// FromCounts returns spectrum of counts of isotopes, e.g. of Accumulator of fission.Results.
+func FromCounts(counts []isotope.Count) *Spectrum {
+	s := New()
+	for _, c := range counts {
+		s.Add(c.Isotope.ZA(), float64(c.Count))
+	}
+	return s
+}
+
+// FromLog returns spectrum of fragments of records of event log selected by filter.
+func FromLog(r *eventlog.Reader, f eventlog.Filter) (*Spectrum, error) {
+	s := New()
+	err := r.Range(0, r.Len(), func(_ int, rec eventlog.Record) bool {
+		if f.Match(rec) {
+			s.Add(rec.First, 1)
+			s.Add(rec.Second, 1)
+		}
+		return true
+	})
+	return s, err
+}
+
+// Add adds n products of nuclide za, products without mass are left out.
+func (s *Spectrum) Add(za isotope.ZA, n float64) {
+	if za.Mass <= 0 {
+		return
+	}
+	s.counts[za] += n
+	s.number = max(s.number, za.Number)
+	s.mass = max(s.mass, za.Mass)
+}
+
+// Total returns number of products.
+func (s *Spectrum) Total() float64 {
+	total := 0.0
+	for _, n := range s.counts {
+		total += n
+	}
+	return total
+}
+
+// Masses returns mass spectrum, number of products of each mass number, which is index of vector.
+func (s *Spectrum) Masses() *mat.VecDense {
+	v := mat.NewVecDense(s.mass+1, nil)
+	for za, n := range s.counts {
+		v.SetVec(za.Mass, v.AtVec(za.Mass)+n)
+	}
+	return v
+}
+
+// Numbers returns number of products of each atomic number, which is index of vector.
+func (s *Spectrum) Numbers() *mat.VecDense {
+	v := mat.NewVecDense(s.number+1, nil)
+	for za, n := range s.counts {
+		v.SetVec(za.Number, v.AtVec(za.Number)+n)
+	}
+	return v
+}
+
+// Matrix returns Z-A matrix, number of products of atomic number of row and mass number of column.
+func (s *Spectrum) Matrix() *mat.Dense {
+	m := mat.NewDense(s.number+1, s.mass+1, nil)
+	for za, n := range s.counts {
+		m.Set(za.Number, za.Mass, n)
+	}
+	return m
+}
+
+// Chart returns matrix of nuclide chart, number of products of atomic number of row and neutron
+// number of column.
+func (s *Spectrum) Chart() *mat.Dense {
+	neutrons := 0
+	for za := range s.counts {
+		neutrons = max(neutrons, za.Mass-za.Number)
+	}
+	m := mat.NewDense(s.number+1, neutrons+1, nil)
+	for za, n := range s.counts {
+		if za.Mass >= za.Number {
+			m.Set(za.Number, za.Mass-za.Number, n)
+		}
+	}
+	return m
+}
+
+// Yields returns mass spectrum normalized to yields in percent of products.
+func (s *Spectrum) Yields() *mat.VecDense {
+	v := s.Masses()
+	if total := s.Total(); total > 0 {
+		v.ScaleVec(100/total, v)
+	}
+	return v
+}
+
+// MassMoments returns mean mass number of products and its standard deviation.
+func (s *Spectrum) MassMoments() (mean, std float64) {
+	masses, weights := indices(s.Masses())
+	return stat.MeanStdDev(masses, weights)
+}
+
+// indices returns indices of vector and its elements, which are their weights.
+func indices(v *mat.VecDense) (x, weights []float64) {
+	for i := range v.Len() {
+		x = append(x, float64(i))
+		weights = append(weights, v.AtVec(i))
+	}
+	return x, weights
+}
+
+// Columns are names of columns of Events.
+var Columns = []string{"first_z", "first_a", "second_z", "second_a", "neutrons", "energy", "first_tke", "second_tke"}
+
+// Events returns matrix of records of event log selected by filter, row of each record with
+// Columns, e.g. for principal component analysis with stat.PC. It returns nil if no record
+// is selected.
+func Events(r *eventlog.Reader, f eventlog.Filter) (*mat.Dense, error) {
+	var data []float64
+	err := r.Range(0, r.Len(), func(_ int, rec eventlog.Record) bool {
+		if f.Match(rec) {
+			data = append(data,
+				float64(rec.First.Number), float64(rec.First.Mass),
+				float64(rec.Second.Number), float64(rec.Second.Mass),
+				float64(rec.Neutrons), rec.Energy, rec.KineticEnergy[0], rec.KineticEnergy[1])
+		}
+		return true
+	})
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+	return mat.NewDense(len(data)/len(Columns), len(Columns), data), nil
+}
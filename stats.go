@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/spf13/cobra"
+
+	"physics/count"
+	"physics/fission"
+)
+
+func statsCmd() *cobra.Command {
+	var top int
+	cmd := &cobra.Command{
+		Use:   "stats <run directory>",
+		Short: "Print statistics of a saved run",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := fission.LoadBundle(args[0])
+			if err != nil {
+				return err
+			}
+			ns := b.NeutronStats()
+			fmt.Printf("isotope:   %s\n", b.Config.Isotope)
+			fmt.Printf("seed:      %d\n", b.Config.Seed)
+			fmt.Printf("fissions:  %d (%d failed)\n", b.Fissions, b.Failures)
+			fmt.Printf("nu:        %.4f ± %.4f\n", ns.Mean, math.Sqrt(ns.Variance))
+			for _, n := range ns.Multiplicities() {
+				fmt.Printf("  %d: %6.2f%%\n", n, 100*ns.Probability(n))
+			}
+
+			fmt.Println("yields per fission:")
+			for _, e := range count.Counter[string](b.Symbols).TopN(top) {
+				fmt.Printf("  %-3s %6d %6.2f%%\n", e.Key, e.Count, 100*float64(e.Count)/float64(max(b.Fissions, 1)))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&top, "top", 10, "number of elements listed, 0 lists all")
+	return cmd
+}
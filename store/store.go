@@ -0,0 +1,280 @@
+// Package store persists fission events and aggregated counts in SQLite database,
+// so results of long experiments can be accumulated and queried incrementally.
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"physics/eventlog"
+	"physics/fission"
+	"physics/internal/bus"
+	"physics/isotope"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id INTEGER PRIMARY KEY,
+	isotope TEXT NOT NULL,
+	seed INTEGER NOT NULL,
+	created TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS events (
+	run INTEGER NOT NULL REFERENCES runs(id),
+	event INTEGER NOT NULL,
+	parent_z INTEGER, parent_a INTEGER,
+	first_z INTEGER, first_a INTEGER,
+	second_z INTEGER, second_a INTEGER,
+	neutrons INTEGER,
+	energy REAL,
+	PRIMARY KEY (run, event)
+);
+CREATE TABLE IF NOT EXISTS counts (
+	run INTEGER NOT NULL REFERENCES runs(id),
+	symbol TEXT NOT NULL,
+	isotope TEXT NOT NULL,
+	count INTEGER NOT NULL,
+	PRIMARY KEY (run, isotope)
+);
+`
+
+// Store is SQLite database of runs.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens or creates database file at path.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Run is a stored simulation run.
+type Run struct {
+	ID      int64
+	Isotope string
+	Seed    int64
+	Created time.Time
+	Events  int
+}
+
+// NewRun creates run of fissions of isotope.
+func (s *Store) NewRun(iso *isotope.Isotope, seed int64) (int64, error) {
+	res, err := s.db.Exec(`INSERT INTO runs (isotope, seed, created) VALUES (?, ?, ?)`,
+		iso.Name(), seed, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// AddEvents stores records of run with their event indices.
+func (s *Store) AddEvents(run int64, events []int, records []eventlog.Record) error {
+	return s.tx(func(tx *sql.Tx) error { return addEvents(tx, run, events, records) })
+}
+
+// AddCounts adds isotope counts of products to run.
+func (s *Store) AddCounts(run int64, prods isotope.Products) error {
+	return s.tx(func(tx *sql.Tx) error { return addCounts(tx, run, prods) })
+}
+
+// Add stores records of run with their event indices and adds counts of their products
+// in one transaction, so counts always match stored events.
+func (s *Store) Add(run int64, events []int, records []eventlog.Record, prods isotope.Products) error {
+	return s.tx(func(tx *sql.Tx) error {
+		if err := addEvents(tx, run, events, records); err != nil {
+			return err
+		}
+		return addCounts(tx, run, prods)
+	})
+}
+
+func addEvents(tx *sql.Tx, run int64, events []int, records []eventlog.Record) error {
+	stmt, err := tx.Prepare(`INSERT INTO events VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for i, r := range records {
+		_, err := stmt.Exec(run, events[i], r.Parent.Number, r.Parent.Mass, r.First.Number, r.First.Mass,
+			r.Second.Number, r.Second.Mass, r.Neutrons, r.Energy)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addCounts(tx *sql.Tx, run int64, prods isotope.Products) error {
+	stmt, err := tx.Prepare(`INSERT INTO counts VALUES (?, ?, ?, ?)
+		ON CONFLICT (run, isotope) DO UPDATE SET count = count + excluded.count`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, c := range prods.Counts() {
+		if _, err := stmt.Exec(run, c.Isotope.Symbol, c.Isotope.Name(), c.Count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Runs returns all stored runs with their number of events.
+func (s *Store) Runs() ([]Run, error) {
+	rows, err := s.db.Query(`SELECT r.id, r.isotope, r.seed, r.created, COUNT(e.event)
+		FROM runs r LEFT JOIN events e ON e.run = r.id GROUP BY r.id ORDER BY r.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []Run
+	for rows.Next() {
+		var r Run
+		var created string
+		if err := rows.Scan(&r.ID, &r.Isotope, &r.Seed, &created, &r.Events); err != nil {
+			return nil, err
+		}
+		r.Created, _ = time.Parse(time.RFC3339, created)
+		runs = append(runs, r)
+	}
+	return runs, rows.Err()
+}
+
+// SymbolCounts returns element symbol counts summed over runs, or over all runs if none are given.
+func (s *Store) SymbolCounts(runs ...int64) (map[string]int, error) {
+	return s.counts("symbol", runs)
+}
+
+// IsotopeCounts returns isotope counts summed over runs, or over all runs if none are given.
+func (s *Store) IsotopeCounts(runs ...int64) (map[string]int, error) {
+	return s.counts("isotope", runs)
+}
+
+// Events returns at most limit events of run with index from or greater. Captures and failures
+// aren't stored, so indices of stored events may have gaps.
+func (s *Store) Events(run int64, from, limit int) ([]eventlog.Record, error) {
+	rows, err := s.db.Query(`SELECT parent_z, parent_a, first_z, first_a, second_z, second_a, neutrons, energy
+		FROM events WHERE run = ? AND event >= ? ORDER BY event LIMIT ?`, run, from, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []eventlog.Record
+	for rows.Next() {
+		var r eventlog.Record
+		err := rows.Scan(&r.Parent.Number, &r.Parent.Mass, &r.First.Number, &r.First.Mass,
+			&r.Second.Number, &r.Second.Mass, &r.Neutrons, &r.Energy)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+func (s *Store) counts(column string, runs []int64) (map[string]int, error) {
+	query := fmt.Sprintf(`SELECT %s, SUM(count) FROM counts`, column)
+	var args []any
+	if len(runs) > 0 {
+		query += ` WHERE run IN (?` + strings.Repeat(",?", len(runs)-1) + `)`
+		for _, r := range runs {
+			args = append(args, r)
+		}
+	}
+	query += fmt.Sprintf(` GROUP BY %s`, column)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var key string
+		var n int
+		if err := rows.Scan(&key, &n); err != nil {
+			return nil, err
+		}
+		counts[key] = n
+	}
+	return counts, rows.Err()
+}
+
+func (s *Store) tx(fn func(*sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Recorder stores events and counts of a simulation batch by batch.
+type Recorder struct {
+	store   *Store
+	run     int64
+	events  []int
+	records []eventlog.Record
+	prods   isotope.Products
+	err     error
+}
+
+// Record creates run of simulation and stores its events as it runs.
+func (s *Store) Record(sim *fission.Simulation) (*Recorder, error) {
+	run, err := s.NewRun(sim.Isotope, sim.Seed)
+	if err != nil {
+		return nil, err
+	}
+	r := &Recorder{store: s, run: run}
+	bus.Subscribe(sim.Bus(), func(e fission.Event) {
+		r.events = append(r.events, e.Index)
+		r.records = append(r.records, eventlog.NewRecord(e.Parent, e.Products, e.Neutrons))
+		r.prods = append(r.prods, e.Products...)
+	})
+	bus.Subscribe(sim.Bus(), func(fission.BatchEnd) { r.flush() })
+	bus.Subscribe(sim.Bus(), func(fission.Finished) { r.flush() })
+	return r, nil
+}
+
+// Run returns id of recorded run.
+func (r *Recorder) Run() int64 {
+	return r.run
+}
+
+// Err returns first error of storing events.
+func (r *Recorder) Err() error {
+	return r.err
+}
+
+func (r *Recorder) flush() {
+	if r.err != nil || len(r.records) == 0 {
+		return
+	}
+	if r.err = r.store.Add(r.run, r.events, r.records, r.prods); r.err != nil {
+		return
+	}
+	r.events, r.records, r.prods = r.events[:0], r.records[:0], r.prods[:0]
+}
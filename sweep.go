@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"physics/fission"
+)
+
+func sweepSeedsCmd() *cobra.Command {
+	var (
+		path     string
+		runs     int
+		parallel int
+		out      string
+	)
+	cmd := &cobra.Command{
+		Use:   "sweep-seeds",
+		Short: "Run the same config with different seeds and write merged results",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := fission.DefaultConfig()
+			if path != "" {
+				var err error
+				if c, err = fission.LoadConfig(path); err != nil {
+					return err
+				}
+			}
+			sr, err := fission.SweepSeeds(cmd.Context(), c, runs, parallel)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%d runs, %d fissions, nu = %.4f ± %.4f\n", len(sr.Seeds), sr.Fissions, sr.Nu.Mean, sr.Nu.StdErr)
+			if ext := filepath.Ext(out); ext == ".yaml" || ext == ".yml" {
+				return sr.SaveYAML(out)
+			}
+			return sr.SaveJson(out)
+		},
+	}
+	f := cmd.Flags()
+	f.StringVarP(&path, "config", "c", "", "simulation config file")
+	f.IntVarP(&runs, "runs", "n", 10, "number of seeds")
+	f.IntVar(&parallel, "parallel", 1, "number of runs at a time")
+	f.StringVarP(&out, "out", "o", "sweep.json", "merged results file, .yaml extension saves yaml")
+	return cmd
+}
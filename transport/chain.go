@@ -0,0 +1,93 @@
+package transport
+
+import (
+	"physics/kinematics"
+	"physics/random"
+)
+
+const (
+	// MaxGenerations and MaxChainFissions are limits of a fission chain, chain which reaches
+	// either of them is divergent.
+	MaxGenerations   = 100
+	MaxChainFissions = 1000
+)
+
+// Chain is fission chain started by a single fission.
+type Chain struct {
+	// Generations is number of generations of fissions, the first fission is the first generation.
+	Generations int
+
+	// Fissions is number of fissions of all generations.
+	Fissions int
+
+	// Divergent chain was stopped at limit of its generations or fissions of a generation.
+	Divergent bool
+}
+
+// Chain follows fission chain started by fission anywhere in geometry, in which every fission
+// releases number of neutrons drawn by neutrons. Chain doesn't change tally of transport.
+func (t *Transport) Chain(neutrons func(random.Rand) int) Chain {
+	c := Chain{Generations: 1, Fissions: 1}
+	sites := []kinematics.Vector{t.Geometry.Sample(t.rng)}
+	var next []kinematics.Vector
+	for {
+		next = next[:0]
+		for _, site := range sites {
+			for i, n := 0, neutrons(t.rng); i < n; i++ {
+				if outcome, p, _, _ := t.follow(site, kinematics.Isotropic(t.rng)); outcome == Fissioned {
+					next = append(next, p)
+				}
+			}
+			if len(next) >= MaxChainFissions {
+				break
+			}
+		}
+		if len(next) == 0 {
+			return c
+		}
+		c.Generations++
+		c.Fissions += len(next)
+		if c.Generations >= MaxGenerations || len(next) >= MaxChainFissions {
+			c.Divergent = true
+			return c
+		}
+		sites, next = next, sites
+	}
+}
+
+// ChainStats are statistics of fission chains.
+type ChainStats struct {
+	Chains    int `json:"chains" yaml:"chains"`
+	Divergent int `json:"divergent" yaml:"divergent"`
+
+	// MeanGenerations and MeanFissions are means of chains which died out.
+	MeanGenerations float64 `json:"mean_generations" yaml:"mean_generations"`
+	MeanFissions    float64 `json:"mean_fissions" yaml:"mean_fissions"`
+
+	// MaxGenerations is length of the longest chain which died out.
+	MaxGenerations int `json:"max_generations" yaml:"max_generations"`
+}
+
+// DivergentFraction returns fraction of chains which diverged.
+func (s ChainStats) DivergentFraction() float64 {
+	return ratio(s.Divergent, s.Chains)
+}
+
+// Chains follows n fission chains and returns their statistics.
+func (t *Transport) Chains(n int, neutrons func(random.Rand) int) ChainStats {
+	s := ChainStats{Chains: n}
+	generations, fissions := 0, 0
+	for i := 0; i < n; i++ {
+		c := t.Chain(neutrons)
+		if c.Divergent {
+			s.Divergent++
+			continue
+		}
+		generations += c.Generations
+		fissions += c.Fissions
+		s.MaxGenerations = max(s.MaxGenerations, c.Generations)
+	}
+	s.MeanGenerations = ratio(generations, n-s.Divergent)
+	s.MeanFissions = ratio(fissions, n-s.Divergent)
+	return s
+}
@@ -0,0 +1,109 @@
+package transport
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	// RoomTemperature is temperature in K at which cross sections are given.
+	RoomTemperature = 293.15
+
+	// DefaultEnergyPerFission is energy in MeV deposited in fuel by a fission.
+	DefaultEnergyPerFission = 200.0
+
+	// joulesPerMeV converts MeV to J.
+	joulesPerMeV = 1.602176634e-13
+)
+
+// Doppler is fuel temperature feedback of chain reaction. Energy of fissions heats fuel
+// adiabatically and U-238 capture cross section grows with temperature by Doppler broadening
+// of its resonances, σ(T) = σ(T₀) (1 + γ (√T - √T₀)).
+type Doppler struct {
+	// Coefficient γ in 1/√K, about 0.006 for U-238 in oxide fuel.
+	Coefficient float64 `json:"coefficient" yaml:"coefficient" toml:"coefficient"`
+
+	// Temperature of fuel at start in K, RoomTemperature if zero.
+	Temperature float64 `json:"temperature,omitempty" yaml:"temperature,omitempty" toml:"temperature,omitempty"`
+
+	// HeatCapacity of fuel in J/K.
+	HeatCapacity float64 `json:"heat_capacity" yaml:"heat_capacity" toml:"heat_capacity"`
+
+	// Scale is number of fissions in fuel represented by a fission of chain reaction.
+	Scale float64 `json:"scale" yaml:"scale" toml:"scale"`
+
+	// EnergyPerFission deposited in fuel in MeV, DefaultEnergyPerFission if zero.
+	EnergyPerFission float64 `json:"energy_per_fission,omitempty" yaml:"energy_per_fission,omitempty" toml:"energy_per_fission,omitempty"`
+}
+
+// Validate returns error if feedback has no heat capacity or scale, or negative temperature.
+func (d Doppler) Validate() error {
+	if d.HeatCapacity <= 0 {
+		return errors.New("transport: doppler feedback needs positive heat capacity")
+	}
+	if d.Scale <= 0 {
+		return errors.New("transport: doppler feedback needs positive scale of fissions")
+	}
+	if d.Temperature < 0 || d.EnergyPerFission < 0 {
+		return errors.New("transport: doppler feedback has negative temperature or energy")
+	}
+	return nil
+}
+
+// Factor returns ratio of capture cross section at temperature in K to capture at
+// temperature of start.
+func (d Doppler) Factor(temperature float64) float64 {
+	return 1 + d.Coefficient*(math.Sqrt(temperature)-math.Sqrt(d.start()))
+}
+
+// Heating returns increase of temperature in K caused by fissions of chain reaction.
+func (d Doppler) Heating(fissions float64) float64 {
+	energy := d.EnergyPerFission
+	if energy == 0 {
+		energy = DefaultEnergyPerFission
+	}
+	return fissions * d.Scale * energy * joulesPerMeV / d.HeatCapacity
+}
+
+func (d Doppler) start() float64 {
+	if d.Temperature == 0 {
+		return RoomTemperature
+	}
+	return d.Temperature
+}
+
+// Partial returns macroscopic cross sections of constituents of material which are
+// nuclide of name.
+func (m Material) Partial(name string) Medium {
+	var med Medium
+	for i, n := range m.NumberDensities() {
+		if nuc := m.Constituents[i].Nuclide; nuc.Name == name {
+			med.Scattering += n * nuc.Scattering
+			med.Capture += n * nuc.Capture
+			med.Fission += n * nuc.Fission
+		}
+	}
+	return med
+}
+
+// AttachDoppler heats fuel by fissions of every generation and changes capture of medium,
+// of which capture is capture cross section of U-238 in 1/cm at temperature of start.
+func (r *Reaction) AttachDoppler(d Doppler, capture float64) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	r.doppler = &d
+	r.capture = capture
+	r.Temperature = d.start()
+	return nil
+}
+
+// heat deposits energy of fissions and updates capture of medium to temperature.
+func (r *Reaction) heat(fissions float64) {
+	if r.doppler == nil {
+		return
+	}
+	before := r.doppler.Factor(r.Temperature)
+	r.Temperature += r.doppler.Heating(fissions)
+	r.t.Medium.Capture = max(0, r.t.Medium.Capture+r.capture*(r.doppler.Factor(r.Temperature)-before))
+}
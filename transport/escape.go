@@ -0,0 +1,103 @@
+package transport
+
+import "math"
+
+// Absorption returns macroscopic absorption cross section, capture and fission.
+func (m Medium) Absorption() float64 {
+	return m.Capture + m.Fission
+}
+
+// KInfinity returns multiplication factor of infinite medium, in which each fission
+// releases nu neutrons on average.
+func (m Medium) KInfinity(nu float64) float64 {
+	if m.Absorption() == 0 {
+		return 0
+	}
+	return nu * m.Fission / m.Absorption()
+}
+
+// MeanChord returns mean chord length of geometry in cm, which is 4V/S for convex body,
+// +Inf for infinite medium.
+func MeanChord(g Geometry) float64 {
+	switch g := g.(type) {
+	case Sphere:
+		return 4 * g.Radius / 3
+	case Slab:
+		return 2 * g.Thickness
+	}
+	return math.Inf(1)
+}
+
+// EscapeProbability returns probability that neutron born uniformly and isotropically in
+// geometry of medium escapes before its first collision. It's exact for sphere and slab,
+// for other geometries it's rational approximation of Wigner.
+func EscapeProbability(g Geometry, m Medium) float64 {
+	total := m.Total()
+	switch g := g.(type) {
+	case Infinite:
+		return 0
+	case Sphere:
+		// Case, de Hoffmann and Placzek
+		a := total * g.Radius
+		if a < 1e-4 {
+			return 1 - 3*a/4
+		}
+		return 3 / (8 * a * a * a) * (2*a*a - 1 + (1+2*a)*math.Exp(-2*a))
+	case Slab:
+		tau := total * g.Thickness
+		if tau < 1e-8 {
+			return 1
+		}
+		return (1 - 2*expint(3, tau)) / (2 * tau)
+	}
+	return WignerEscape(g, m)
+}
+
+// WignerEscape returns rational approximation 1/(1 + Σl) of first flight escape probability,
+// where l is mean chord of geometry.
+func WignerEscape(g Geometry, m Medium) float64 {
+	return 1 / (1 + m.Total()*MeanChord(g))
+}
+
+// expint returns exponential integral E_n(x) for x > 0.
+func expint(n int, x float64) float64 {
+	e := expint1(x)
+	for k := 1; k < n; k++ {
+		e = (math.Exp(-x) - x*e) / float64(k)
+	}
+	return e
+}
+
+// expint1 returns exponential integral E_1(x) for x > 0, by series for small x and by
+// continued fraction otherwise.
+func expint1(x float64) float64 {
+	const euler = 0.57721566490153286
+	if x <= 1 {
+		sum, term := 0.0, 1.0
+		for k := 1; k < 100; k++ {
+			term *= -x / float64(k)
+			sum += term / float64(k)
+			if math.Abs(term) < 1e-17 {
+				break
+			}
+		}
+		return -euler - math.Log(x) - sum
+	}
+	// modified Lentz's method
+	b := x + 1
+	c := 1 / 1e-300
+	d := 1 / b
+	h := d
+	for i := 1; i < 200; i++ {
+		an := -float64(i * i)
+		b += 2
+		d = 1 / (an*d + b)
+		c = b + an/c
+		del := c * d
+		h *= del
+		if math.Abs(del-1) < 1e-16 {
+			break
+		}
+	}
+	return h * math.Exp(-x)
+}
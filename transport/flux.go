@@ -0,0 +1,112 @@
+package transport
+
+import (
+	"math"
+	"sort"
+)
+
+// Estimate is mean score per neutron history with its relative statistical error, which is
+// standard error of the mean divided by the mean.
+type Estimate struct {
+	Mean          float64 `json:"mean" yaml:"mean"`
+	RelativeError float64 `json:"relative_error" yaml:"relative_error"`
+}
+
+// Score sums scores of histories and their squares, so their mean is estimated with its error.
+type Score struct {
+	sum, squares float64
+}
+
+// Add adds score x of a history.
+func (s *Score) Add(x float64) {
+	s.sum += x
+	s.squares += x * x
+}
+
+// Estimate returns mean score of histories, which are also those which didn't score.
+func (s Score) Estimate(histories int) Estimate {
+	if histories == 0 {
+		return Estimate{}
+	}
+	n := float64(histories)
+	mean := s.sum / n
+	e := Estimate{Mean: mean}
+	if histories > 1 && mean != 0 {
+		variance := max(0, s.squares/n-mean*mean) / (n - 1)
+		e.RelativeError = math.Sqrt(variance) / math.Abs(mean)
+	}
+	return e
+}
+
+// Rate is estimated number of reactions of nuclide per neutron, Nuclide is "total" for
+// reactions of all nuclides of medium.
+type Rate struct {
+	Nuclide  string `json:"nuclide" yaml:"nuclide"`
+	Reaction string `json:"reaction" yaml:"reaction"`
+	Estimate `yaml:",inline"`
+}
+
+// FluxTally estimates flux by track length estimator, the distance travelled by neutrons,
+// and reaction rates of nuclides as products of their macroscopic cross sections and track
+// length. Every history of neutron is one score, so statistical errors are known.
+type FluxTally struct {
+	Histories int
+
+	track Score
+	rates map[string]*[2]Score // capture and fission of each nuclide
+}
+
+// Total is name of nuclide of rates of all nuclides of medium.
+const Total = "total"
+
+// score scores history of neutron which travelled length in cm through medium m, whose
+// nuclides are partial media of its nuclides by name.
+func (f *FluxTally) score(length float64, m Medium, nuclides map[string]Medium) {
+	if f.rates == nil {
+		f.rates = make(map[string]*[2]Score)
+	}
+	f.Histories++
+	f.track.Add(length)
+	add := func(name string, m Medium) {
+		r, ok := f.rates[name]
+		if !ok {
+			r = new([2]Score)
+			f.rates[name] = r
+		}
+		r[0].Add(m.Capture * length)
+		r[1].Add(m.Fission * length)
+	}
+	add(Total, m)
+	for name, n := range nuclides {
+		add(name, n)
+	}
+}
+
+// Flux returns track length per neutron in cm, which is flux integrated over volume of
+// geometry per neutron released.
+func (f FluxTally) Flux() Estimate {
+	return f.track.Estimate(f.Histories)
+}
+
+// Rates returns rates of capture and fission per neutron of each nuclide, by nuclide with
+// total first.
+func (f FluxTally) Rates() []Rate {
+	names := make([]string, 0, len(f.rates))
+	for name := range f.rates {
+		if name != Total {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := f.rates[Total]; ok {
+		names = append([]string{Total}, names...)
+	}
+	var rates []Rate
+	for _, name := range names {
+		r := f.rates[name]
+		rates = append(rates,
+			Rate{Nuclide: name, Reaction: "capture", Estimate: r[0].Estimate(f.Histories)},
+			Rate{Nuclide: name, Reaction: "fission", Estimate: r[1].Estimate(f.Histories)})
+	}
+	return rates
+}
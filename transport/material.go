@@ -0,0 +1,116 @@
+package transport
+
+import "errors"
+
+// Avogadro is Avogadro constant in 1/mol multiplied by barn in cm², so number density of
+// material of density in g/cm³ and molar mass in g/mol is in atoms/(barn*cm).
+const Avogadro = 0.602214076
+
+// Nuclide holds one-group microscopic cross sections of a nuclide in barns.
+type Nuclide struct {
+	Name string `json:"name" yaml:"name" toml:"name"`
+
+	// MolarMass in g/mol.
+	MolarMass float64 `json:"molar_mass" yaml:"molar_mass" toml:"molar_mass"`
+
+	Scattering float64 `json:"scattering" yaml:"scattering" toml:"scattering"`
+	Capture    float64 `json:"capture" yaml:"capture" toml:"capture"`
+	Fission    float64 `json:"fission" yaml:"fission" toml:"fission"`
+}
+
+var (
+	// U235 and U238 have cross sections averaged over spectrum of fission neutrons.
+	U235 = Nuclide{Name: "U-235", MolarMass: 235.0439, Scattering: 4.55, Capture: 0.12, Fission: 1.28}
+	U238 = Nuclide{Name: "U-238", MolarMass: 238.0508, Scattering: 4.80, Capture: 0.07, Fission: 0.31}
+)
+
+// Constituent is nuclide with its fraction of atoms of material.
+type Constituent struct {
+	Nuclide Nuclide `json:"nuclide" yaml:"nuclide" toml:"nuclide"`
+	Atoms   float64 `json:"atoms" yaml:"atoms" toml:"atoms"`
+}
+
+// Material is homogeneous mixture of nuclides.
+type Material struct {
+	Name string `json:"name" yaml:"name" toml:"name"`
+
+	// Density in g/cm³.
+	Density float64 `json:"density" yaml:"density" toml:"density"`
+
+	// Constituents with relative numbers of atoms, they needn't sum to one.
+	Constituents []Constituent `json:"constituents" yaml:"constituents" toml:"constituents"`
+}
+
+// Uranium returns uranium metal of density 18.74 g/cm³ with atom fraction enrichment of U-235.
+func Uranium(enrichment float64) Material {
+	return Material{
+		Name:    "U",
+		Density: 18.74,
+		Constituents: []Constituent{
+			{Nuclide: U235, Atoms: enrichment},
+			{Nuclide: U238, Atoms: 1 - enrichment},
+		},
+	}
+}
+
+// MolarMass returns mean molar mass of atoms of material in g/mol.
+func (m Material) MolarMass() float64 {
+	atoms, mass := 0.0, 0.0
+	for _, c := range m.Constituents {
+		atoms += c.Atoms
+		mass += c.Atoms * c.Nuclide.MolarMass
+	}
+	if atoms == 0 {
+		return 0
+	}
+	return mass / atoms
+}
+
+// NumberDensity returns number of atoms of material in atoms/(barn*cm).
+func (m Material) NumberDensity() float64 {
+	mass := m.MolarMass()
+	if mass == 0 {
+		return 0
+	}
+	return m.Density * Avogadro / mass
+}
+
+// NumberDensities returns number density of each constituent in atoms/(barn*cm),
+// in order of constituents.
+func (m Material) NumberDensities() []float64 {
+	atoms := 0.0
+	for _, c := range m.Constituents {
+		atoms += c.Atoms
+	}
+	n := m.NumberDensity()
+	ds := make([]float64, len(m.Constituents))
+	for i, c := range m.Constituents {
+		if atoms > 0 {
+			ds[i] = n * c.Atoms / atoms
+		}
+	}
+	return ds
+}
+
+// Medium returns macroscopic cross sections of material.
+func (m Material) Medium() Medium {
+	var med Medium
+	for i, n := range m.NumberDensities() {
+		nuc := m.Constituents[i].Nuclide
+		med.Scattering += n * nuc.Scattering
+		med.Capture += n * nuc.Capture
+		med.Fission += n * nuc.Fission
+	}
+	return med
+}
+
+// Validate returns error if material has no density or no atoms.
+func (m Material) Validate() error {
+	if m.Density <= 0 {
+		return errors.New("transport: material density must be positive")
+	}
+	if m.MolarMass() <= 0 {
+		return errors.New("transport: material has no constituents")
+	}
+	return nil
+}
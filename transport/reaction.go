@@ -0,0 +1,218 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"physics/kinematics"
+	"physics/random"
+	"physics/scenario"
+)
+
+// Reaction is chain reaction followed generation by generation, which starts with fissions
+// anywhere in geometry. If a generation has more than Max fissions, Max of them are followed
+// with proportionally higher weight, so population can grow without bounds.
+// Reaction implements scenario.Engine and scenario.Absorber, so scripts can change its
+// reactivity and absorption, e.g. by withdrawal of control rods or scram.
+type Reaction struct {
+	t        *Transport
+	neutrons func(random.Rand) int
+
+	// GenerationTime is time between generations of fissions.
+	GenerationTime time.Duration
+
+	// Max is the largest number of fissions followed in a generation.
+	Max int
+
+	// Reactivity is reactivity inserted by scripts, delta k / k, which changes number of
+	// neutrons released in fission.
+	Reactivity float64
+
+	// Absorber is macroscopic capture cross section in 1/cm added to medium by scripts.
+	Absorber float64
+
+	// Temperature of fuel in K, if Doppler feedback is attached.
+	Temperature float64
+
+	Time       time.Duration
+	Generation int
+
+	// Flux tallies track length and reaction rates of whole medium of neutrons followed,
+	// medium changes with absorber and temperature.
+	Flux FluxTally
+
+	doppler *Doppler
+	capture float64 // U-238 capture of medium at temperature of start
+
+	sites   []kinematics.Vector
+	weight  float64
+	initial float64 // population of the first generation
+	last    float64 // population of the last generation
+
+	// neutrons released and histories which induced fission, since absorber was changed,
+	// estimate multiplication of medium without inserted reactivity
+	released, fissions, histories, induced int
+}
+
+// Generation is state of chain reaction after a generation.
+type Generation struct {
+	Generation int `json:"generation" yaml:"generation"`
+
+	// Time in s.
+	Time float64 `json:"time" yaml:"time"`
+
+	// Population is number of neutrons released in generation.
+	Population float64 `json:"population" yaml:"population"`
+
+	// Multiplication is ratio of population to population of previous generation, zero for
+	// the first generation.
+	Multiplication float64 `json:"multiplication" yaml:"multiplication"`
+
+	Reactivity float64 `json:"reactivity" yaml:"reactivity"`
+	Absorber   float64 `json:"absorber" yaml:"absorber"`
+
+	// Temperature of fuel in K at the end of generation, zero without Doppler feedback.
+	Temperature float64 `json:"temperature,omitempty" yaml:"temperature,omitempty"`
+}
+
+// NewReaction creates chain reaction of transport starting with fissions fissions, which
+// release number of neutrons drawn by neutrons and are generation time apart. At most
+// fissions are followed in a generation. Reaction doesn't change tally of transport.
+func (t *Transport) NewReaction(fissions int, neutrons func(random.Rand) int, generationTime time.Duration) (*Reaction, error) {
+	if fissions <= 0 {
+		return nil, fmt.Errorf("transport: reaction needs positive number of fissions, got %d", fissions)
+	}
+	if generationTime <= 0 {
+		return nil, errors.New("transport: generation time isn't positive")
+	}
+	r := &Reaction{t: t, neutrons: neutrons, GenerationTime: generationTime, Max: fissions, weight: 1}
+	for range fissions {
+		r.sites = append(r.sites, t.Geometry.Sample(t.rng))
+	}
+	return r, nil
+}
+
+// Population returns number of neutrons released in the last generation, number of
+// fissions before the first generation.
+func (r *Reaction) Population() float64 {
+	if r.Generation == 0 {
+		return r.weight * float64(len(r.sites))
+	}
+	return r.last
+}
+
+// InsertReactivity adds reactivity rho. Reactivity is inserted by changing mean number
+// of neutrons released in fission by factor 1 / (1 - ρk) of multiplication k of medium,
+// so that 1/k decreases by ρ. The factor is at most 10.
+func (r *Reaction) InsertReactivity(rho float64) {
+	r.Reactivity += rho
+}
+
+// InsertAbsorber adds capture cross section sigma in 1/cm to medium, negative sigma
+// removes absorber. Capture of medium doesn't become negative.
+func (r *Reaction) InsertAbsorber(sigma float64) {
+	sigma = max(sigma, -r.t.Medium.Capture)
+	r.t.Medium.Capture += sigma
+	r.Absorber += sigma
+	r.released, r.fissions, r.histories, r.induced = 0, 0, 0, 0
+}
+
+// SetPower changes weight of fissions of the next generation as if population of the last
+// generation was power times population of the first generation, or number of starting
+// fissions before it. Chain reaction stops if power isn't positive.
+func (r *Reaction) SetPower(power float64) {
+	if power <= 0 {
+		r.sites, r.last = nil, 0
+		return
+	}
+	initial := r.initial
+	if r.Generation == 0 {
+		initial = r.weight * float64(len(r.sites))
+	}
+	current := r.Population()
+	if current == 0 {
+		return
+	}
+	r.weight *= power * initial / current
+	r.last = power * initial
+}
+
+// factor returns multiplier of number of neutrons released in fission, which inserts reactivity.
+func (r *Reaction) factor() float64 {
+	if r.Reactivity == 0 || r.histories == 0 || r.fissions == 0 {
+		return 1
+	}
+	k := float64(r.released) / float64(r.fissions) * float64(r.induced) / float64(r.histories)
+	return 1 / max(1-r.Reactivity*k, 0.1)
+}
+
+// Next follows the next generation and returns its state. Population stays zero when chain
+// reaction dies out.
+func (r *Reaction) Next() Generation {
+	f := r.factor()
+	r.heat(r.weight * float64(len(r.sites)))
+	var next []kinematics.Vector
+	population := 0.0
+	for _, site := range r.sites {
+		n := r.neutrons(r.t.rng)
+		r.released += n
+		r.fissions++
+		if f != 1 {
+			// stochastic rounding keeps mean of scaled number of neutrons
+			x := f * float64(n)
+			n = int(x)
+			if r.t.rng.Float64() < x-float64(n) {
+				n++
+			}
+		}
+		population += float64(n)
+		for range n {
+			outcome, p, _, length := r.t.follow(site, kinematics.Isotropic(r.t.rng))
+			r.Flux.score(length, r.t.Medium, nil)
+			r.histories++
+			if outcome == Fissioned {
+				r.induced++
+				next = append(next, p)
+			}
+		}
+	}
+	population *= r.weight
+	if len(next) > r.Max {
+		r.t.rng.Shuffle(len(next), func(i, j int) { next[i], next[j] = next[j], next[i] })
+		r.weight *= float64(len(next)) / float64(r.Max)
+		next = next[:r.Max]
+	}
+
+	g := Generation{Generation: r.Generation + 1, Population: population, Reactivity: r.Reactivity, Absorber: r.Absorber, Temperature: r.Temperature}
+	if r.Generation == 0 {
+		r.initial = population
+	}
+	if r.last > 0 {
+		g.Multiplication = population / r.last
+	}
+	r.sites = next
+	r.last = population
+	r.Generation++
+	r.Time += r.GenerationTime
+	g.Time = r.Time.Seconds()
+	return g
+}
+
+// Run follows generations for duration, applying actions of script when they are due, and
+// returns state after every generation. Actions due at start or before it are applied first.
+// Run returns early when chain reaction dies out.
+func (r *Reaction) Run(script scenario.Script, duration time.Duration) []Generation {
+	script.Apply(r, math.MinInt64, r.Time)
+	var gens []Generation
+	for end := r.Time + duration; r.Time < end; {
+		from := r.Time
+		gens = append(gens, r.Next())
+		script.Apply(r, from, r.Time)
+		if len(r.sites) == 0 {
+			break
+		}
+	}
+	return gens
+}
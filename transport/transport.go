@@ -0,0 +1,337 @@
+// Package transport follows neutrons released in fission through simple geometries of
+// a homogeneous medium, until they escape, are captured or induce the next fission.
+package transport
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"physics/kinematics"
+	"physics/random"
+)
+
+// Medium is homogeneous material with one-speed macroscopic cross sections in 1/cm.
+type Medium struct {
+	Scattering float64 `json:"scattering" yaml:"scattering" toml:"scattering"`
+	Capture    float64 `json:"capture" yaml:"capture" toml:"capture"`
+	Fission    float64 `json:"fission" yaml:"fission" toml:"fission"`
+}
+
+// FastUranium is uranium metal enriched to 94% of U-235, like Godiva assembly.
+var FastUranium = Uranium(0.94).Medium()
+
+// Total returns total macroscopic cross section.
+func (m Medium) Total() float64 {
+	return m.Scattering + m.Capture + m.Fission
+}
+
+// MeanFreePath returns mean distance between collisions in cm.
+func (m Medium) MeanFreePath() float64 {
+	return 1 / m.Total()
+}
+
+// Validate returns error if cross sections are negative or there are no collisions.
+func (m Medium) Validate() error {
+	if m.Scattering < 0 || m.Capture < 0 || m.Fission < 0 {
+		return fmt.Errorf("transport: negative cross section in %+v", m)
+	}
+	if m.Total() <= 0 {
+		return fmt.Errorf("transport: medium has no cross sections")
+	}
+	return nil
+}
+
+// Geometry is a region of medium surrounded by vacuum.
+type Geometry interface {
+	// Distance returns distance from position p inside geometry along unit direction u
+	// to its boundary, +Inf if there is none.
+	Distance(p, u kinematics.Vector) float64
+
+	// Sample returns uniformly distributed position inside geometry.
+	Sample(rng random.Rand) kinematics.Vector
+}
+
+// Infinite is medium without boundary, so no neutron escapes.
+type Infinite struct{}
+
+func (Infinite) Distance(p, u kinematics.Vector) float64 {
+	return math.Inf(1)
+}
+
+func (Infinite) Sample(rng random.Rand) kinematics.Vector {
+	return kinematics.Vector{}
+}
+
+// Sphere of radius in cm centered at origin.
+type Sphere struct {
+	Radius float64
+}
+
+func (s Sphere) Distance(p, u kinematics.Vector) float64 {
+	// solves |p + d u| = radius for positive d
+	b := p.Dot(u)
+	c := p.Dot(p) - s.Radius*s.Radius
+	return max(0, -b+math.Sqrt(max(0, b*b-c)))
+}
+
+func (s Sphere) Sample(rng random.Rand) kinematics.Vector {
+	return kinematics.Isotropic(rng).Scale(s.Radius * math.Cbrt(rng.Float64()))
+}
+
+func (s Sphere) Normal(p kinematics.Vector) kinematics.Vector {
+	if n := p.Norm(); n > 0 {
+		return p.Scale(1 / n)
+	}
+	return kinematics.Vector{Z: 1}
+}
+
+// Slab of thickness in cm between planes z = ±thickness/2, infinite in x and y.
+type Slab struct {
+	Thickness float64
+}
+
+func (s Slab) Distance(p, u kinematics.Vector) float64 {
+	switch {
+	case u.Z > 0:
+		return max(0, (s.Thickness/2-p.Z)/u.Z)
+	case u.Z < 0:
+		return max(0, (-s.Thickness/2-p.Z)/u.Z)
+	}
+	return math.Inf(1)
+}
+
+func (s Slab) Sample(rng random.Rand) kinematics.Vector {
+	return kinematics.Vector{Z: s.Thickness * (rng.Float64() - 0.5)}
+}
+
+func (s Slab) Normal(p kinematics.Vector) kinematics.Vector {
+	if p.Z < 0 {
+		return kinematics.Vector{Z: -1}
+	}
+	return kinematics.Vector{Z: 1}
+}
+
+// Bounded is geometry with boundary, which can be surrounded by reflector.
+type Bounded interface {
+	Geometry
+
+	// Normal returns outward unit normal of boundary at position p of boundary.
+	Normal(p kinematics.Vector) kinematics.Vector
+}
+
+// ParseGeometry returns geometry "infinite", "sphere" of radius size or "slab" of thickness
+// size, in cm.
+func ParseGeometry(shape string, size float64) (Geometry, error) {
+	switch strings.ToLower(strings.TrimSpace(shape)) {
+	case "", "infinite":
+		return Infinite{}, nil
+	case "sphere":
+		if size <= 0 {
+			return nil, fmt.Errorf("transport: sphere needs positive radius, got %g", size)
+		}
+		return Sphere{Radius: size}, nil
+	case "slab":
+		if size <= 0 {
+			return nil, fmt.Errorf("transport: slab needs positive thickness, got %g", size)
+		}
+		return Slab{Thickness: size}, nil
+	}
+	return nil, fmt.Errorf("transport: unknown geometry %q", shape)
+}
+
+// Outcome is how history of a neutron ended.
+type Outcome int
+
+const (
+	Escaped Outcome = iota
+	Captured
+	Fissioned
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Escaped:
+		return "escaped"
+	case Captured:
+		return "captured"
+	case Fissioned:
+		return "fissioned"
+	}
+	return fmt.Sprintf("Outcome(%d)", int(o))
+}
+
+// Tally counts outcomes of neutron histories.
+type Tally struct {
+	// Fissions is number of fissions which released Neutrons.
+	Fissions int `json:"fissions" yaml:"fissions"`
+	Neutrons int `json:"neutrons" yaml:"neutrons"`
+
+	Escaped  int `json:"escaped" yaml:"escaped"`
+	Captured int `json:"captured" yaml:"captured"`
+	Induced  int `json:"induced" yaml:"induced"`
+
+	// Reflected is number of times neutrons were returned by reflector.
+	Reflected int `json:"reflected" yaml:"reflected"`
+}
+
+// KEff returns effective multiplication factor, number of fissions induced per fission.
+func (t Tally) KEff() float64 {
+	return ratio(t.Induced, t.Fissions)
+}
+
+// Leakage returns fraction of neutrons which escaped.
+func (t Tally) Leakage() float64 {
+	return ratio(t.Escaped, t.Neutrons)
+}
+
+// CaptureFraction returns fraction of neutrons which were captured.
+func (t Tally) CaptureFraction() float64 {
+	return ratio(t.Captured, t.Neutrons)
+}
+
+// FissionFraction returns fraction of neutrons which induced fission.
+func (t Tally) FissionFraction() float64 {
+	return ratio(t.Induced, t.Neutrons)
+}
+
+// Reflections returns mean number of times a neutron was returned by reflector.
+func (t Tally) Reflections() float64 {
+	return ratio(t.Reflected, t.Neutrons)
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+// bankSize is number of fission sites kept as sources of next fissions.
+const bankSize = 10000
+
+// Transport follows neutrons through geometry of medium.
+type Transport struct {
+	Geometry Geometry
+	Medium   Medium
+
+	// Albedo is fraction of neutrons reaching boundary which reflector around geometry
+	// returns back, no neutron is returned if it's zero. Infinite geometry has no boundary.
+	Albedo float64
+
+	Tally Tally
+
+	// Nuclides are partial media of nuclides of medium by name, Flux tallies their reaction
+	// rates besides rates of whole medium.
+	Nuclides map[string]Medium
+	Flux     FluxTally
+
+	rng   random.Rand
+	sites []kinematics.Vector // sites of induced fissions, the oldest are replaced
+	next  int
+}
+
+// New creates transport of neutrons in geometry of medium, drawing random numbers from rng.
+func New(g Geometry, m Medium, rng random.Rand) *Transport {
+	return &Transport{Geometry: g, Medium: m, rng: rng}
+}
+
+// Fission follows neutrons released by a fission. Fission happens at a site of fission
+// induced before, or anywhere in geometry until there is none. Directions of neutrons
+// are isotropic unless velocities of neutrons are given.
+func (t *Transport) Fission(neutrons int, velocities []kinematics.Vector) {
+	var site kinematics.Vector
+	if len(t.sites) > 0 {
+		site = t.sites[t.rng.Intn(len(t.sites))]
+	} else {
+		site = t.Geometry.Sample(t.rng)
+	}
+	t.Tally.Fissions++
+	for i := 0; i < neutrons; i++ {
+		var u kinematics.Vector
+		if i < len(velocities) && velocities[i].Norm() > 0 {
+			u = velocities[i].Scale(1 / velocities[i].Norm())
+		} else {
+			u = kinematics.Isotropic(t.rng)
+		}
+		t.Tally.Neutrons++
+		outcome, p, reflected, length := t.follow(site, u)
+		t.Tally.Reflected += reflected
+		t.Flux.score(length, t.Medium, t.Nuclides)
+		switch outcome {
+		case Escaped:
+			t.Tally.Escaped++
+		case Captured:
+			t.Tally.Captured++
+		case Fissioned:
+			t.Tally.Induced++
+			t.bank(p)
+		}
+	}
+}
+
+// Follow follows history of neutron starting at position p in direction u and returns its
+// outcome with position where it ended. Scattering is isotropic.
+func (t *Transport) Follow(p, u kinematics.Vector) (Outcome, kinematics.Vector) {
+	outcome, p, _, _ := t.follow(p, u)
+	return outcome, p
+}
+
+// follow follows neutron like Follow and also returns number of its reflections and length
+// of its track in cm.
+func (t *Transport) follow(p, u kinematics.Vector) (Outcome, kinematics.Vector, int, float64) {
+	total := t.Medium.Total()
+	reflected := 0
+	length := 0.0
+	for {
+		d := -math.Log(1-t.rng.Float64()) / total
+		if b := t.Geometry.Distance(p, u); d >= b {
+			p = p.Add(u.Scale(b))
+			length += b
+			g, ok := t.Geometry.(Bounded)
+			if !ok || t.Albedo <= 0 || t.rng.Float64() >= t.Albedo {
+				return Escaped, p, reflected, length
+			}
+			reflected++
+			u = reenter(t.rng, g.Normal(p))
+			continue
+		}
+		p = p.Add(u.Scale(d))
+		length += d
+		switch x := t.rng.Float64() * total; {
+		case x < t.Medium.Capture:
+			return Captured, p, reflected, length
+		case x < t.Medium.Capture+t.Medium.Fission:
+			return Fissioned, p, reflected, length
+		}
+		u = kinematics.Isotropic(t.rng)
+	}
+}
+
+// reenter returns direction of neutron returned through boundary of outward normal n,
+// distributed by cosine to the inward normal as from a diffuse reflector.
+func reenter(rng random.Rand, n kinematics.Vector) kinematics.Vector {
+	in := n.Scale(-1)
+	// any direction which isn't parallel to the normal gives a perpendicular axis
+	a := kinematics.Vector{X: 1}
+	if math.Abs(in.X) > 0.9 {
+		a = kinematics.Vector{Y: 1}
+	}
+	e1 := a.Add(in.Scale(-a.Dot(in)))
+	e1 = e1.Scale(1 / e1.Norm())
+	e2 := in.Cross(e1)
+
+	mu := math.Sqrt(rng.Float64())
+	phi := 2 * math.Pi * rng.Float64()
+	s := math.Sqrt(1 - mu*mu)
+	return in.Scale(mu).Add(e1.Scale(s * math.Cos(phi))).Add(e2.Scale(s * math.Sin(phi)))
+}
+
+func (t *Transport) bank(p kinematics.Vector) {
+	if len(t.sites) < bankSize {
+		t.sites = append(t.sites, p)
+		return
+	}
+	t.sites[t.next] = p
+	t.next = (t.next + 1) % bankSize
+}
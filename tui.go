@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"physics/count"
+	"physics/fission"
+	"physics/internal/bus"
+)
+
+// liveStats are tallies updated by simulation and read by terminal UI.
+type liveStats struct {
+	mu       sync.Mutex
+	symbols  count.Counter[string]
+	neutrons count.Counter[int]
+	fissions int
+	failures int
+	progress fission.Progress
+}
+
+type (
+	tickMsg     time.Time
+	finishedMsg struct{}
+)
+
+// liveModel is terminal UI showing yields, neutron multiplicity and throughput while simulation runs.
+type liveModel struct {
+	stats *liveStats
+	done  bool
+	width int
+}
+
+// Number of elements shown.
+const liveElements = 15
+
+// runTUI runs simulation while showing live terminal UI. UI stays open after simulation
+// ends until it's closed, closing it earlier stops simulation like cancelling ctx does.
+// Results are nil if UI fails.
+func runTUI(ctx context.Context, sim *fission.Simulation) (*fission.Results, error) {
+	stats := &liveStats{symbols: count.New[string](), neutrons: count.New[int]()}
+	bus.Subscribe(sim.Bus(), func(e fission.Event) {
+		stats.mu.Lock()
+		defer stats.mu.Unlock()
+		stats.fissions++
+		stats.neutrons.Add(e.Neutrons, 1)
+		for _, p := range e.Products {
+			stats.symbols.Add(p.Symbol, 1)
+		}
+	})
+	bus.Subscribe(sim.Bus(), func(fission.Failure) {
+		stats.mu.Lock()
+		stats.failures++
+		stats.mu.Unlock()
+	})
+	sim.OnProgress(func(p fission.Progress) {
+		stats.mu.Lock()
+		stats.progress = p
+		stats.mu.Unlock()
+	})
+
+	p := tea.NewProgram(liveModel{stats: stats, width: 80}, tea.WithOutput(os.Stderr))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type run struct {
+		results *fission.Results
+		err     error
+	}
+	done := make(chan run, 1)
+	go func() {
+		results, err := sim.Run(ctx)
+		done <- run{results, err}
+		p.Send(finishedMsg{})
+	}()
+	_, err := p.Run()
+	cancel()
+	r := <-done
+	if err != nil {
+		return nil, err
+	}
+	return r.results, r.err
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(200*time.Millisecond, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
+
+func (m liveModel) Init() tea.Cmd {
+	return tick()
+}
+
+func (m liveModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+	case tickMsg:
+		return m, tick()
+	case finishedMsg:
+		m.done = true
+	}
+	return m, nil
+}
+
+func (m liveModel) View() string {
+	s := m.stats
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var b strings.Builder
+	p := s.progress
+	status := fmt.Sprintf("ETA %s", p.Remaining().Round(time.Second))
+	if m.done {
+		status = "done, press q to save results"
+	}
+	fmt.Fprintf(&b, "%d/%d events (%.0f%%), %d failed, %.0f events/s, %s\n\n",
+		p.Done, p.Total, 100*p.Fraction(), s.failures, p.Rate(), status)
+
+	width := max(m.width-20, 10)
+	top := s.symbols.TopN(liveElements)
+	// bars of yields are scaled to the most common element
+	b.WriteString("Yield per fission\n")
+	for _, e := range top {
+		y := float64(e.Count) / float64(max(s.fissions, 1))
+		fmt.Fprintf(&b, "%-3s %6.2f%% %s\n", e.Key, 100*y, bar(float64(e.Count)/float64(top[0].Count), width))
+	}
+
+	b.WriteString("\nNeutron multiplicity\n")
+	for _, n := range count.SortedKeys(s.neutrons) {
+		f := float64(s.neutrons[n]) / float64(max(s.fissions, 1))
+		fmt.Fprintf(&b, "%3d %6.2f%% %s\n", n, 100*f, bar(f, width))
+	}
+	return b.String()
+}
+
+// bar returns horizontal bar of fraction f of width.
+func bar(f float64, width int) string {
+	n := min(int(f*float64(width)), width)
+	return strings.Repeat("█", max(n, 0))
+}
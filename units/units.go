@@ -0,0 +1,263 @@
+// Package units parses human friendly numbers with units used in configs and flags,
+// like "10M" events, "30d" of cooling or "4GiB" of memory.
+package units
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Count is a number of things, e.g. events, parsed from "10000", "1e7", "10M" or "2.5k events".
+type Count int64
+
+// Duration is time.Duration that also accepts days, weeks and years, e.g. "30d" or "1y2w".
+type Duration time.Duration
+
+// Bytes is a size in bytes parsed from "4GiB", "512MB" or "1.5G".
+type Bytes int64
+
+var countSuffixes = map[string]float64{
+	"":  1,
+	"k": 1e3, "K": 1e3,
+	"M": 1e6,
+	"G": 1e9, "B": 1e9,
+	"T": 1e12,
+}
+
+// ParseCount parses count like "10M". Trailing word, e.g. "events", is ignored.
+func ParseCount(s string) (Count, error) {
+	num, suffix := splitNumber(firstWord(s))
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid count %q", s)
+	}
+	m, ok := countSuffixes[suffix]
+	if !ok {
+		return 0, fmt.Errorf("invalid count %q: unknown suffix %q", s, suffix)
+	}
+	v *= m
+	if v < 0 || v > math.MaxInt64 || v != math.Trunc(v) {
+		return 0, fmt.Errorf("invalid count %q: not a whole non-negative number", s)
+	}
+	return Count(v), nil
+}
+
+var durationUnits = map[string]time.Duration{
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+	"y": time.Duration(365.25 * 24 * float64(time.Hour)),
+}
+
+// ParseDuration parses duration like time.ParseDuration with additional
+// units "d" (day), "w" (week) and "y" (julian year).
+func ParseDuration(s string) (Duration, error) {
+	str := strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+	if str == "" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	var total time.Duration
+	rest := str
+	for rest != "" {
+		num, tail := splitNumber(rest)
+		unit := tail
+		if i := strings.IndexFunc(tail, func(r rune) bool { return unicode.IsDigit(r) || r == '.' }); i >= 0 {
+			unit, tail = tail[:i], tail[i:]
+		} else {
+			tail = ""
+		}
+		if d, ok := durationUnits[unit]; ok {
+			v, err := strconv.ParseFloat(num, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			total += time.Duration(v * float64(d))
+		} else {
+			d, err := time.ParseDuration(num + unit)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			total += d
+		}
+		rest = tail
+	}
+	return Duration(total), nil
+}
+
+var byteUnits = map[string]float64{
+	"": 1, "b": 1,
+	"k": 1e3, "kb": 1e3, "kib": 1 << 10,
+	"m": 1e6, "mb": 1e6, "mib": 1 << 20,
+	"g": 1e9, "gb": 1e9, "gib": 1 << 30,
+	"t": 1e12, "tb": 1e12, "tib": 1 << 40,
+}
+
+// ParseBytes parses size like "4GiB". Decimal units (kB, MB, GB) are powers of 1000
+// and binary units (KiB, MiB, GiB) powers of 1024.
+func ParseBytes(s string) (Bytes, error) {
+	num, unit := splitNumber(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	m, ok := byteUnits[strings.ToLower(unit)]
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q: unknown unit %q", s, unit)
+	}
+	if v < 0 {
+		return 0, fmt.Errorf("invalid size %q: negative", s)
+	}
+	return Bytes(v * m), nil
+}
+
+// splitNumber splits leading number from its suffix. Exponent of a number is kept, so "1e7" is a number.
+func splitNumber(s string) (string, string) {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.' || s[i] == '_') {
+		i++
+	}
+	if i+1 < len(s) && (s[i] == 'e' || s[i] == 'E') && (s[i+1] >= '0' && s[i+1] <= '9' || s[i+1] == '-' || s[i+1] == '+') {
+		i++
+		if s[i] == '-' || s[i] == '+' {
+			i++
+		}
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+	}
+	return strings.ReplaceAll(s[:i], "_", ""), strings.TrimSpace(s[i:])
+}
+
+func firstWord(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return ""
+	}
+	// "10 M events" keeps the suffix separated by space
+	if len(fields) > 1 {
+		if _, ok := countSuffixes[fields[1]]; ok {
+			return fields[0] + fields[1]
+		}
+	}
+	return fields[0]
+}
+
+// String formats count with SI suffix when exact, e.g. "10M".
+func (c Count) String() string {
+	for _, s := range []string{"T", "G", "M", "k"} {
+		m := int64(countSuffixes[s])
+		if int64(c) >= m && int64(c)%m == 0 {
+			return strconv.FormatInt(int64(c)/m, 10) + s
+		}
+	}
+	return strconv.FormatInt(int64(c), 10)
+}
+
+// Set implements flag.Value.
+func (c *Count) Set(s string) error {
+	v, err := ParseCount(s)
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
+
+// Type implements pflag.Value.
+func (c *Count) Type() string {
+	return "count"
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *Count) UnmarshalText(text []byte) error {
+	return c.Set(string(text))
+}
+
+// UnmarshalJSON accepts both numbers and strings.
+func (c *Count) UnmarshalJSON(data []byte) error {
+	return c.Set(string(bytes.Trim(data, `"`)))
+}
+
+// String formats duration, whole days are formatted with "d" unit.
+func (d Duration) String() string {
+	td := time.Duration(d)
+	day := durationUnits["d"]
+	if td >= day && td%day == 0 {
+		return strconv.FormatInt(int64(td/day), 10) + "d"
+	}
+	return td.String()
+}
+
+// Set implements flag.Value.
+func (d *Duration) Set(s string) error {
+	v, err := ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// Type implements pflag.Value.
+func (d *Duration) Type() string {
+	return "duration"
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(text []byte) error {
+	return d.Set(string(text))
+}
+
+// UnmarshalJSON accepts duration string or number of seconds.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err == nil {
+		*d = Duration(seconds * float64(time.Second))
+		return nil
+	}
+	return d.Set(string(bytes.Trim(data, `"`)))
+}
+
+// String formats size with binary unit when exact, e.g. "4GiB".
+func (b Bytes) String() string {
+	for _, u := range []string{"TiB", "GiB", "MiB", "KiB"} {
+		m := int64(byteUnits[strings.ToLower(u)])
+		if int64(b) >= m && int64(b)%m == 0 {
+			return strconv.FormatInt(int64(b)/m, 10) + u
+		}
+	}
+	return strconv.FormatInt(int64(b), 10) + "B"
+}
+
+// Set implements flag.Value.
+func (b *Bytes) Set(s string) error {
+	v, err := ParseBytes(s)
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+// Type implements pflag.Value.
+func (b *Bytes) Type() string {
+	return "bytes"
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *Bytes) UnmarshalText(text []byte) error {
+	return b.Set(string(text))
+}
+
+// UnmarshalJSON accepts both numbers and strings.
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	return b.Set(string(bytes.Trim(data, `"`)))
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"physics/fission"
+)
+
+func verifyCmd() *cobra.Command {
+	var tolerance float64
+	cmd := &cobra.Command{
+		Use:   "verify <run directory>",
+		Short: "Re-execute a stored run and check that its tallies match",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := args[0]
+			bundle, err := fission.LoadBundle(dir)
+			if err != nil {
+				return err
+			}
+			mismatches, err := bundle.Verify(cmd.Context(), tolerance)
+			if err != nil {
+				return err
+			}
+			for _, m := range mismatches {
+				fmt.Println(m)
+			}
+			if len(mismatches) > 0 {
+				return fmt.Errorf("run %s is not reproducible: %d tallies differ", dir, len(mismatches))
+			}
+			fmt.Printf("run %s reproduced: %d fissions, seed %d\n", dir, bundle.Fissions, bundle.Config.Seed)
+			return nil
+		},
+	}
+	cmd.Flags().Float64Var(&tolerance, "tolerance", 0, "allowed relative difference of tallies")
+	return cmd
+}
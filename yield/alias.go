@@ -0,0 +1,83 @@
+package yield
+
+import (
+	"fmt"
+
+	"physics/random"
+)
+
+// AliasSampler draws fission products with probability proportional to their yields by
+// alias method of Walker and Vose, in constant time of one uniform integer and one uniform
+// float regardless of number of products.
+type AliasSampler struct {
+	products []Product
+
+	// probability of product of each column, otherwise its alias is drawn
+	probability []float64
+	alias       []int
+}
+
+// NewAliasSampler creates alias sampler of ground and isomeric state products of a table.
+func NewAliasSampler(t *Table) (*AliasSampler, error) {
+	s := &AliasSampler{}
+	var sum float64
+	for _, p := range t.Products {
+		if p.Yield <= 0 {
+			continue
+		}
+		sum += p.Yield
+		s.products = append(s.products, p)
+	}
+	n := len(s.products)
+	if n == 0 {
+		return nil, fmt.Errorf("yield table of %d-%d has no positive yields", t.Number, t.Mass)
+	}
+
+	// columns of average height one, those below are filled by aliases of those above
+	s.probability = make([]float64, n)
+	s.alias = make([]int, n)
+	var small, large []int
+	for i, p := range s.products {
+		s.probability[i] = p.Yield * float64(n) / sum
+		if s.probability[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		l, g := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		s.alias[l] = g
+		s.probability[g] -= 1 - s.probability[l]
+		if s.probability[g] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, g)
+		}
+	}
+	// what remains is one up to rounding
+	for _, i := range append(small, large...) {
+		s.probability[i] = 1
+	}
+	return s, nil
+}
+
+// Len returns number of products of sampler.
+func (s *AliasSampler) Len() int {
+	return len(s.products)
+}
+
+// Sample returns random product drawn from rng.
+func (s *AliasSampler) Sample(rng random.Rand) Product {
+	i, u := rng.Intn(len(s.products)), rng.Float64()
+	if u >= s.probability[i] {
+		i = s.alias[i]
+	}
+	return s.products[i]
+}
+
+// SampleFragment returns atomic and mass number of random product drawn from rng.
+func (s *AliasSampler) SampleFragment(rng random.Rand) (int, int) {
+	p := s.Sample(rng)
+	return p.Number, p.Mass
+}
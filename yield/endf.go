@@ -0,0 +1,161 @@
+package yield
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Fission product yield data file number.
+const yieldFile = 8
+
+// ParseENDF parses fission yield sections (MF=8, MT=454 and MT=459) of an ENDF-6 formatted data.
+// JEFF yield libraries are distributed in the same format. Other sections are skipped.
+func ParseENDF(r io.Reader) ([]*Table, error) {
+	p := &parser{scanner: bufio.NewScanner(r)}
+
+	var tables []*Table
+	for p.next() {
+		if p.mf != yieldFile || (Kind(p.mt) != Independent && Kind(p.mt) != Cumulative) {
+			continue
+		}
+		ts, err := p.section()
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, ts...)
+	}
+	if err := p.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+// Open parses fission yields from ENDF-6 file at path.
+func Open(path string) ([]*Table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseENDF(f)
+}
+
+type parser struct {
+	scanner *bufio.Scanner
+	line    int
+	text    string
+	mf, mt  int
+}
+
+// next reads next record and its MF and MT numbers.
+func (p *parser) next() bool {
+	if !p.scanner.Scan() {
+		return false
+	}
+	p.line++
+	p.text = p.scanner.Text()
+	p.mf = atoi(field(p.text, 70, 72))
+	p.mt = atoi(field(p.text, 72, 75))
+	return true
+}
+
+// section reads yields of all energies from current HEAD record.
+func (p *parser) section() ([]*Table, error) {
+	kind := Kind(p.mt)
+	head, err := p.floats()
+	if err != nil {
+		return nil, err
+	}
+	za := int(head[0])
+	energies := int(head[2])
+
+	var tables []*Table
+	for i := 0; i < energies; i++ {
+		if !p.next() {
+			return nil, p.errorf("unexpected end of data, %d of %d energies read", i, energies)
+		}
+		cont, err := p.floats()
+		if err != nil {
+			return nil, err
+		}
+		n, products := int(cont[4]), int(cont[5])
+		if n != 4*products {
+			return nil, p.errorf("list of %d values does not contain %d products", n, products)
+		}
+		values := make([]float64, 0, n)
+		for len(values) < n {
+			if !p.next() {
+				return nil, p.errorf("unexpected end of data in list")
+			}
+			v, err := p.floats()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v...)
+		}
+
+		t := &Table{Number: za / 1000, Mass: za % 1000, Kind: kind, Energy: cont[0]}
+		for j := 0; j < products; j++ {
+			v := values[4*j:]
+			t.Products = append(t.Products, Product{
+				Number:      int(v[0]) / 1000,
+				Mass:        int(v[0]) % 1000,
+				State:       int(v[1]),
+				Yield:       v[2],
+				Uncertainty: v[3],
+			})
+		}
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+// floats parses six 11 character wide fields of current record.
+func (p *parser) floats() ([]float64, error) {
+	values := make([]float64, 6)
+	for i := range values {
+		s := field(p.text, 11*i, 11*(i+1))
+		v, err := parseFloat(s)
+		if err != nil {
+			return nil, p.errorf("invalid number %q", s)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("endf line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+// parseFloat parses ENDF number which may omit exponent letter, e.g. "9.223500+4".
+func parseFloat(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if !strings.ContainsAny(s, "eE") {
+		if i := strings.LastIndexAny(s, "+-"); i > 0 {
+			s = s[:i] + "e" + s[i:]
+		}
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func field(line string, from, to int) string {
+	if from >= len(line) {
+		return ""
+	}
+	if to > len(line) {
+		to = len(line)
+	}
+	return strings.TrimSpace(line[from:to])
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,63 @@
+package yield
+
+import (
+	"physics/isotope"
+	"physics/random"
+)
+
+// Model is fission model which draws fragments from independent yields of tables of parents,
+// with isotope.SimpleModel for neutrons, energy and parents without table. Fragment drawn
+// from yields is either fragment, the other one is what remains of compound nucleus after
+// neutrons are released, so both have measured charge and mass.
+type Model struct {
+	isotope.SimpleModel
+
+	samplers map[isotope.ZA]*AliasSampler
+}
+
+// Thermal and Fast are energies in eV of incident neutrons of yields of thermal and fast fission.
+const (
+	Thermal = 0.0253
+	Fast    = 5e5
+)
+
+// NewModel creates model of independent yields of each parent of tables, at incident
+// energy in eV closest to energy.
+func NewModel(tables []*Table, energy float64) (*Model, error) {
+	m := &Model{samplers: make(map[isotope.ZA]*AliasSampler)}
+	for _, t := range tables {
+		za := isotope.ZA{Number: t.Number, Mass: t.Mass}
+		if _, ok := m.samplers[za]; ok || t.Kind != Independent {
+			continue
+		}
+		t, _ = Find(tables, t.Number, t.Mass, Independent, energy)
+		s, err := NewAliasSampler(t)
+		if err != nil {
+			return nil, err
+		}
+		m.samplers[za] = s
+	}
+	return m, nil
+}
+
+// Has reports whether model has yields of parent.
+func (m *Model) Has(parent isotope.ZA) bool {
+	_, ok := m.samplers[parent]
+	return ok
+}
+
+// SampleFragments draws heavier fragment of compound nucleus from yields of its parent, which
+// absorbed neutron, with weight one, so strata of opts aren't used.
+func (m *Model) SampleFragments(rng random.Rand, compound isotope.Isotope, neutrons int, opts isotope.Options) (isotope.ZA, float64) {
+	s, ok := m.samplers[isotope.ZA{Number: compound.Number, Mass: compound.Mass - 1}]
+	if !ok {
+		return m.SimpleModel.SampleFragments(rng, compound, neutrons, opts)
+	}
+	p := s.Sample(rng)
+	f := isotope.ZA{Number: p.Number, Mass: p.Mass}
+	other := isotope.ZA{Number: compound.Number - p.Number, Mass: compound.Mass - neutrons - p.Mass}
+	if other.Mass > f.Mass {
+		return other, 1
+	}
+	return f, 1
+}
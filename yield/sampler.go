@@ -0,0 +1,48 @@
+package yield
+
+import (
+	"fmt"
+	"sort"
+
+	"physics/random"
+)
+
+// Sampler draws fission products with probability proportional to their yields.
+type Sampler struct {
+	products   []Product
+	cumulative []float64
+}
+
+// NewSampler creates sampler of ground and isomeric state products of a table.
+func NewSampler(t *Table) (*Sampler, error) {
+	s := &Sampler{}
+	var sum float64
+	for _, p := range t.Products {
+		if p.Yield <= 0 {
+			continue
+		}
+		sum += p.Yield
+		s.products = append(s.products, p)
+		s.cumulative = append(s.cumulative, sum)
+	}
+	if len(s.products) == 0 {
+		return nil, fmt.Errorf("yield table of %d-%d has no positive yields", t.Number, t.Mass)
+	}
+	return s, nil
+}
+
+// Sample returns random product drawn from rng.
+func (s *Sampler) Sample(rng random.Rand) Product {
+	x := rng.Float64() * s.cumulative[len(s.cumulative)-1]
+	i := sort.SearchFloat64s(s.cumulative, x)
+	if i == len(s.products) {
+		i--
+	}
+	return s.products[i]
+}
+
+// SampleFragment returns atomic and mass number of random product drawn from rng.
+func (s *Sampler) SampleFragment(rng random.Rand) (int, int) {
+	p := s.Sample(rng)
+	return p.Number, p.Mass
+}
@@ -0,0 +1,80 @@
+// Package yield reads evaluated fission product yield data.
+package yield
+
+// Kind of fission yields, equal to ENDF-6 MT number of the section.
+type Kind int
+
+const (
+	// Independent yields are yields of fragments directly after prompt neutron emission.
+	Independent Kind = 454
+
+	// Cumulative yields include all decays of precursors.
+	Cumulative Kind = 459
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Independent:
+		return "independent"
+	case Cumulative:
+		return "cumulative"
+	}
+	return "unknown"
+}
+
+// Product is a fission product with its yield per fission.
+type Product struct {
+	// Atomic number "Z" and mass number "A" of a product.
+	Number int `json:"atomic_number"`
+	Mass   int `json:"mass_number"`
+
+	// State is isomeric state, 0 for ground state.
+	State int `json:"state"`
+
+	Yield       float64 `json:"yield"`
+	Uncertainty float64 `json:"uncertainty"`
+}
+
+// Table is a set of fission yields of a parent nuclide at one incident neutron energy.
+type Table struct {
+	// Atomic and mass number of a fissioning nuclide.
+	Number int `json:"atomic_number"`
+	Mass   int `json:"mass_number"`
+
+	Kind Kind `json:"kind"`
+
+	// Energy of incident neutron in eV.
+	Energy float64 `json:"energy"`
+
+	Products []Product `json:"products"`
+}
+
+// Sum returns sum of all yields of a table, which is 2 for a complete set of independent yields.
+func (t *Table) Sum() float64 {
+	var sum float64
+	for _, p := range t.Products {
+		sum += p.Yield
+	}
+	return sum
+}
+
+// Find returns table of given parent and kind with incident energy closest to energy.
+func Find(tables []*Table, number, mass int, kind Kind, energy float64) (*Table, bool) {
+	var found *Table
+	for _, t := range tables {
+		if t.Number != number || t.Mass != mass || t.Kind != kind {
+			continue
+		}
+		if found == nil || abs(t.Energy-energy) < abs(found.Energy-energy) {
+			found = t
+		}
+	}
+	return found, found != nil
+}
+
+func abs(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}